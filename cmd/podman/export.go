@@ -66,5 +66,8 @@ func exportCmd(c *cli.Context) error {
 		return errors.Wrapf(err, "error looking up container %q", args[0])
 	}
 
+	if output == "/dev/stdout" {
+		return ctr.ExportToWriter(os.Stdout)
+	}
 	return ctr.Export(output)
 }
@@ -109,6 +109,12 @@ func NewBoltState(path, lockDir string, runtime *Runtime) (State, error) {
 		if _, err := tx.CreateBucketIfNotExists(runtimeConfigBkt); err != nil {
 			return errors.Wrapf(err, "error creating runtime-config bucket")
 		}
+		if _, err := tx.CreateBucketIfNotExists(volBkt); err != nil {
+			return errors.Wrapf(err, "error creating volumes bucket")
+		}
+		if _, err := tx.CreateBucketIfNotExists(volCtrsBkt); err != nil {
+			return errors.Wrapf(err, "error creating volume containers bucket")
+		}
 		return nil
 	})
 	if err != nil {
@@ -1369,6 +1375,11 @@ func (s *BoltState) RemovePodContainers(pod *Pod) error {
 			return err
 		}
 
+		volCtrsBkt, err := getVolCtrsBucket(tx)
+		if err != nil {
+			return err
+		}
+
 		// Check if the pod exists
 		podDB := podBkt.Bucket(podID)
 		if podDB == nil {
@@ -1409,6 +1420,18 @@ func (s *BoltState) RemovePodContainers(pod *Pod) error {
 
 			// Dependencies are set, we're clear to remove
 
+			configBytes := ctr.Get(configKey)
+			if configBytes == nil {
+				return errors.Wrapf(ErrInternal, "container %s missing config key in DB", string(id))
+			}
+			ctrConfig := new(ContainerConfig)
+			if err := json.Unmarshal(configBytes, ctrConfig); err != nil {
+				return errors.Wrapf(err, "error unmarshalling container %s config", string(id))
+			}
+			if err := removeVolumeReferences(volCtrsBkt, id, ctrConfig.NamedVolumes); err != nil {
+				return err
+			}
+
 			if err := ctrBkt.DeleteBucket(id); err != nil {
 				return errors.Wrapf(ErrInternal, "error deleting container %s from DB", string(id))
 			}
@@ -1675,3 +1698,257 @@ func (s *BoltState) AllPods() ([]*Pod, error) {
 
 	return pods, nil
 }
+
+// Volume retrieves a volume given its name
+func (s *BoltState) Volume(name string) (*Volume, error) {
+	if name == "" {
+		return nil, ErrEmptyID
+	}
+
+	if !s.valid {
+		return nil, ErrDBClosed
+	}
+
+	volName := []byte(name)
+
+	volume := new(Volume)
+	volume.config = new(VolumeConfig)
+
+	db, err := s.getDBCon()
+	if err != nil {
+		return nil, err
+	}
+	defer s.closeDBCon(db)
+
+	err = db.View(func(tx *bolt.Tx) error {
+		volBkt, err := getVolBucket(tx)
+		if err != nil {
+			return err
+		}
+
+		return s.getVolumeFromDB(volName, volume, volBkt)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return volume, nil
+}
+
+// HasVolume checks if a volume with the given name exists in the state
+func (s *BoltState) HasVolume(name string) (bool, error) {
+	if name == "" {
+		return false, ErrEmptyID
+	}
+
+	if !s.valid {
+		return false, ErrDBClosed
+	}
+
+	volName := []byte(name)
+
+	exists := false
+
+	db, err := s.getDBCon()
+	if err != nil {
+		return false, err
+	}
+	defer s.closeDBCon(db)
+
+	err = db.View(func(tx *bolt.Tx) error {
+		volBkt, err := getVolBucket(tx)
+		if err != nil {
+			return err
+		}
+
+		exists = volBkt.Bucket(volName) != nil
+
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return exists, nil
+}
+
+// AddVolume adds the given volume to the state
+func (s *BoltState) AddVolume(volume *Volume) error {
+	if !s.valid {
+		return ErrDBClosed
+	}
+
+	if !volume.valid {
+		return ErrVolumeRemoved
+	}
+
+	volName := []byte(volume.Name())
+
+	volConfigJSON, err := json.Marshal(volume.config)
+	if err != nil {
+		return errors.Wrapf(err, "error marshalling volume %s config to JSON", volume.Name())
+	}
+
+	db, err := s.getDBCon()
+	if err != nil {
+		return err
+	}
+	defer s.closeDBCon(db)
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		volBkt, err := getVolBucket(tx)
+		if err != nil {
+			return err
+		}
+
+		if volBkt.Bucket(volName) != nil {
+			return errors.Wrapf(ErrVolumeExists, "volume with name %s already exists", volume.Name())
+		}
+
+		newVolBkt, err := volBkt.CreateBucket(volName)
+		if err != nil {
+			return errors.Wrapf(err, "error creating bucket for volume %s", volume.Name())
+		}
+
+		if err := newVolBkt.Put(configKey, volConfigJSON); err != nil {
+			return errors.Wrapf(err, "error storing volume %s configuration in DB", volume.Name())
+		}
+
+		return nil
+	})
+	return err
+}
+
+// RemoveVolume removes the given volume from the state
+func (s *BoltState) RemoveVolume(volume *Volume) error {
+	if !s.valid {
+		return ErrDBClosed
+	}
+
+	volName := []byte(volume.Name())
+
+	db, err := s.getDBCon()
+	if err != nil {
+		return err
+	}
+	defer s.closeDBCon(db)
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		volBkt, err := getVolBucket(tx)
+		if err != nil {
+			return err
+		}
+
+		if volBkt.Bucket(volName) == nil {
+			volume.valid = false
+			return errors.Wrapf(ErrNoSuchVolume, "volume %s does not exist in DB", volume.Name())
+		}
+
+		if err := volBkt.DeleteBucket(volName); err != nil {
+			return errors.Wrapf(err, "error removing volume %s from DB", volume.Name())
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	volume.valid = false
+
+	return nil
+}
+
+// VolumeInUse returns the IDs of the containers using the given volume, in
+// all namespaces
+func (s *BoltState) VolumeInUse(volume *Volume) ([]string, error) {
+	if !s.valid {
+		return nil, ErrDBClosed
+	}
+
+	if !volume.valid {
+		return nil, ErrVolumeRemoved
+	}
+
+	volName := []byte(volume.Name())
+
+	ctrs := []string{}
+
+	db, err := s.getDBCon()
+	if err != nil {
+		return nil, err
+	}
+	defer s.closeDBCon(db)
+
+	err = db.View(func(tx *bolt.Tx) error {
+		volBkt, err := getVolBucket(tx)
+		if err != nil {
+			return err
+		}
+
+		if volBkt.Bucket(volName) == nil {
+			volume.valid = false
+			return errors.Wrapf(ErrNoSuchVolume, "volume %s does not exist in DB", volume.Name())
+		}
+
+		volCtrsBkt, err := getVolCtrsBucket(tx)
+		if err != nil {
+			return err
+		}
+
+		volCtrs := volCtrsBkt.Bucket(volName)
+		if volCtrs == nil {
+			return nil
+		}
+
+		return volCtrs.ForEach(func(id, name []byte) error {
+			ctrs = append(ctrs, string(id))
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return ctrs, nil
+}
+
+// AllVolumes returns all volumes present in the state
+func (s *BoltState) AllVolumes() ([]*Volume, error) {
+	if !s.valid {
+		return nil, ErrDBClosed
+	}
+
+	volumes := []*Volume{}
+
+	db, err := s.getDBCon()
+	if err != nil {
+		return nil, err
+	}
+	defer s.closeDBCon(db)
+
+	err = db.View(func(tx *bolt.Tx) error {
+		volBucket, err := getVolBucket(tx)
+		if err != nil {
+			return err
+		}
+
+		return volBucket.ForEach(func(name, value []byte) error {
+			volume := new(Volume)
+			volume.config = new(VolumeConfig)
+
+			if err := s.getVolumeFromDB(name, volume, volBucket); err != nil {
+				logrus.Errorf("Error retrieving volume %s from the database: %v", string(name), err)
+			} else {
+				volumes = append(volumes, volume)
+			}
+
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return volumes, nil
+}
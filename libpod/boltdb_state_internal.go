@@ -22,6 +22,8 @@ const (
 	podName           = "pod"
 	allPodsName       = "allPods"
 	runtimeConfigName = "runtime-config"
+	volName           = "vol"
+	volCtrsName       = "vol-ctrs"
 
 	configName       = "config"
 	stateName        = "state"
@@ -41,6 +43,8 @@ var (
 	podBkt           = []byte(podName)
 	allPodsBkt       = []byte(allPodsName)
 	runtimeConfigBkt = []byte(runtimeConfigName)
+	volBkt           = []byte(volName)
+	volCtrsBkt       = []byte(volCtrsName)
 
 	configKey       = []byte(configName)
 	stateKey        = []byte(stateName)
@@ -237,6 +241,57 @@ func getRuntimeConfigBucket(tx *bolt.Tx) (*bolt.Bucket, error) {
 	return bkt, nil
 }
 
+func getVolBucket(tx *bolt.Tx) (*bolt.Bucket, error) {
+	bkt := tx.Bucket(volBkt)
+	if bkt == nil {
+		return nil, errors.Wrapf(ErrDBBadConfig, "volumes bucket not found in DB")
+	}
+	return bkt, nil
+}
+
+func getVolCtrsBucket(tx *bolt.Tx) (*bolt.Bucket, error) {
+	bkt := tx.Bucket(volCtrsBkt)
+	if bkt == nil {
+		return nil, errors.Wrapf(ErrDBBadConfig, "volume containers bucket not found in DB")
+	}
+	return bkt, nil
+}
+
+// Add a container to the buckets of the containers using each of the given
+// volumes
+func addVolumeReferences(volCtrsBucket *bolt.Bucket, ctrID, ctrName []byte, volumes []*ContainerNamedVolume) error {
+	for _, vol := range volumes {
+		volCtrs, err := volCtrsBucket.CreateBucketIfNotExists([]byte(vol.Name))
+		if err != nil {
+			return errors.Wrapf(err, "error creating containers bucket of volume %s", vol.Name)
+		}
+		if err := volCtrs.Put(ctrID, ctrName); err != nil {
+			return errors.Wrapf(err, "error adding container %s to volume %s", string(ctrID), vol.Name)
+		}
+	}
+	return nil
+}
+
+// Remove a container from the buckets of the containers using each of the
+// given volumes
+func removeVolumeReferences(volCtrsBucket *bolt.Bucket, ctrID []byte, volumes []*ContainerNamedVolume) error {
+	for _, vol := range volumes {
+		volCtrs := volCtrsBucket.Bucket([]byte(vol.Name))
+		if volCtrs == nil {
+			continue
+		}
+		if err := volCtrs.Delete(ctrID); err != nil {
+			return errors.Wrapf(err, "error removing container %s from volume %s", string(ctrID), vol.Name)
+		}
+		if key, _ := volCtrs.Cursor().First(); key == nil {
+			if err := volCtrsBucket.DeleteBucket([]byte(vol.Name)); err != nil {
+				return errors.Wrapf(err, "error removing containers bucket of volume %s", vol.Name)
+			}
+		}
+	}
+	return nil
+}
+
 func (s *BoltState) getContainerFromDB(id []byte, ctr *Container, ctrsBkt *bolt.Bucket) error {
 	valid := true
 	ctrBkt := ctrsBkt.Bucket(id)
@@ -310,6 +365,35 @@ func (s *BoltState) getPodFromDB(id []byte, pod *Pod, podBkt *bolt.Bucket) error
 	return nil
 }
 
+func (s *BoltState) getVolumeFromDB(name []byte, volume *Volume, volBkt *bolt.Bucket) error {
+	volDB := volBkt.Bucket(name)
+	if volDB == nil {
+		return errors.Wrapf(ErrNoSuchVolume, "volume with name %s not found", string(name))
+	}
+
+	volConfigBytes := volDB.Get(configKey)
+	if volConfigBytes == nil {
+		return errors.Wrapf(ErrInternal, "volume %s is missing configuration key in DB", string(name))
+	}
+
+	if err := json.Unmarshal(volConfigBytes, volume.config); err != nil {
+		return errors.Wrapf(err, "error unmarshalling volume %s config from DB", string(name))
+	}
+
+	// Get the lock
+	lockPath := filepath.Join(s.lockDir, volumeLockPrefix+string(name))
+	lock, err := storage.GetLockfile(lockPath)
+	if err != nil {
+		return errors.Wrapf(err, "error retrieving lockfile for volume %s", string(name))
+	}
+	volume.lock = lock
+
+	volume.runtime = s.runtime
+	volume.valid = true
+
+	return nil
+}
+
 // Add a container to the DB
 // If pod is not nil, the container is added to the pod as well
 func (s *BoltState) addContainer(ctr *Container, pod *Pod) error {
@@ -371,6 +455,11 @@ func (s *BoltState) addContainer(ctr *Container, pod *Pod) error {
 			return err
 		}
 
+		volCtrsBucket, err := getVolCtrsBucket(tx)
+		if err != nil {
+			return err
+		}
+
 		// If a pod was given, check if it exists
 		var podDB *bolt.Bucket
 		var podCtrs *bolt.Bucket
@@ -496,6 +585,11 @@ func (s *BoltState) addContainer(ctr *Container, pod *Pod) error {
 			}
 		}
 
+		// Add the container to the volumes it uses
+		if err := addVolumeReferences(volCtrsBucket, ctrID, ctrName, ctr.config.NamedVolumes); err != nil {
+			return err
+		}
+
 		// Add ctr to pod
 		if pod != nil {
 			if err := podCtrs.Put(ctrID, ctrName); err != nil {
@@ -540,6 +634,11 @@ func (s *BoltState) removeContainer(ctr *Container, pod *Pod, tx *bolt.Tx) error
 		return err
 	}
 
+	volCtrsBucket, err := getVolCtrsBucket(tx)
+	if err != nil {
+		return err
+	}
+
 	// Does the pod exist?
 	var podDB *bolt.Bucket
 	if pod != nil {
@@ -627,6 +726,9 @@ func (s *BoltState) removeContainer(ctr *Container, pod *Pod, tx *bolt.Tx) error
 	if err := allCtrsBucket.Delete(ctrID); err != nil {
 		return errors.Wrapf(err, "error deleting container %s from all containers bucket in DB", ctr.ID())
 	}
+	if err := removeVolumeReferences(volCtrsBucket, ctrID, ctr.config.NamedVolumes); err != nil {
+		return err
+	}
 
 	depCtrs := ctr.Dependencies()
 
@@ -120,6 +120,28 @@ func getTestPod(id, name, locksDir string) (*Pod, error) {
 	return pod, nil
 }
 
+func getTestVolume(name, locksDir string) (*Volume, error) {
+	volume := &Volume{
+		config: &VolumeConfig{
+			Name:        name,
+			Labels:      map[string]string{"a": "b"},
+			MountPoint:  filepath.Join("/does/not/exist", name, "_data"),
+			Options:     map[string]string{},
+			CreatedTime: time.Now(),
+		},
+		valid: true,
+	}
+
+	lockPath := filepath.Join(locksDir, volumeLockPrefix+name)
+	lock, err := storage.GetLockfile(lockPath)
+	if err != nil {
+		return nil, err
+	}
+	volume.lock = lock
+
+	return volume, nil
+}
+
 func getTestCtrN(n, lockPath string) (*Container, error) {
 	return getTestContainer(strings.Repeat(n, 32), "test"+n, lockPath)
 }
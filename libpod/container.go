@@ -48,10 +48,144 @@ const CgroupfsDefaultCgroupParent = "/libpod_parent"
 // manager in libpod
 const SystemdDefaultCgroupParent = "machine.slice"
 
+// CgroupMode controls whether libpod creates cgroups for a container and its
+// conmon process, or adopts a cgroup that is managed by somebody else
+type CgroupMode string
+
+const (
+	// CgroupModeEnabled has libpod create a cgroup for the container under
+	// its cgroup parent, and move conmon into a cgroup of its own.
+	// This is the default.
+	CgroupModeEnabled CgroupMode = "enabled"
+	// CgroupModeDisabled has libpod not create any cgroups. The container
+	// and conmon are left in the cgroup of the process that started them.
+	CgroupModeDisabled CgroupMode = "disabled"
+	// CgroupModeNoConmon has libpod create a cgroup for the container, but
+	// leave conmon in the cgroup of the process that started it.
+	CgroupModeNoConmon CgroupMode = "no-conmon"
+	// CgroupModeSplit adopts the cgroup parent as an externally-managed
+	// cgroup. The container is placed directly in it and conmon is left in
+	// the cgroup of the process that started it. Libpod will never create
+	// or remove the cgroup parent.
+	CgroupModeSplit CgroupMode = "split"
+)
+
+// LogFullPolicy determines what libpod does when the filesystem holding a
+// container's log fills up and the log can no longer be written
+type LogFullPolicy string
+
+const (
+	// LogFullPolicyBlock leaves the container running; writes to the log
+	// fail until space is freed. This is the default.
+	LogFullPolicyBlock LogFullPolicy = "block"
+	// LogFullPolicyDrop discards the contents of the container's log so
+	// new output can be written
+	LogFullPolicyDrop LogFullPolicy = "drop"
+	// LogFullPolicyStop stops the container
+	LogFullPolicyStop LogFullPolicy = "stop"
+)
+
+const (
+	// LogDriverFile keeps the container's output only in its log file.
+	// This is the default.
+	LogDriverFile = "file"
+	// LogDriverJournald also sends the container's output to the systemd
+	// journal, with the container's ID and name as fields
+	LogDriverJournald = "journald"
+)
+
+const (
+	// RestartPolicyNo never restarts the container. This is the default.
+	RestartPolicyNo = "no"
+	// RestartPolicyOnFailure restarts the container when it exits with a
+	// non-zero exit code, up to the container's RestartRetries times in a
+	// row
+	RestartPolicyOnFailure = "on-failure"
+	// RestartPolicyAlways restarts the container whenever it exits, unless
+	// it was stopped with Stop()
+	RestartPolicyAlways = "always"
+	// RestartPolicyUnlessStopped restarts the container whenever it exits,
+	// unless it was stopped with Stop(). As libpod has no daemon that
+	// restarts stopped containers when it starts, this is the same as
+	// RestartPolicyAlways.
+	RestartPolicyUnlessStopped = "unless-stopped"
+)
+
+// StartupPhase is a phase of setting up and starting a container whose duration
+// can be recorded
+type StartupPhase string
+
+const (
+	// StartupPhaseSetupStorage is the creation of the container's storage
+	StartupPhaseSetupStorage StartupPhase = "setupStorage"
+	// StartupPhaseMountStorage is the mounting of the container's root
+	// filesystem and SHM
+	StartupPhaseMountStorage StartupPhase = "mountStorage"
+	// StartupPhaseGenerateSpec is the generation of the container's OCI
+	// spec and its writing to the bundle
+	StartupPhaseGenerateSpec StartupPhase = "generateSpec"
+	// StartupPhaseOCICreate is the creation of the container in the OCI
+	// runtime
+	StartupPhaseOCICreate StartupPhase = "ociCreate"
+	// StartupPhaseOCIStart is the start of the container in the OCI
+	// runtime
+	StartupPhaseOCIStart StartupPhase = "ociStart"
+)
+
+// RestartBackoff configures the delay between restarts of a container
+// The first restart is delayed by BaseDelay, and each following one by
+// Multiplier times the previous delay, up to MaxDelay. Each delay is then
+// shortened by a random fraction of up to Jitter of itself, so containers that
+// fail together do not all restart at the same time.
+type RestartBackoff struct {
+	// BaseDelay is the delay before the first restart
+	BaseDelay time.Duration `json:"baseDelay"`
+	// MaxDelay is the longest delay between restarts
+	MaxDelay time.Duration `json:"maxDelay"`
+	// Multiplier is the factor each delay grows by over the previous one
+	Multiplier float64 `json:"multiplier"`
+	// Jitter is the largest fraction of a delay, between 0 and 1, that it
+	// can be shortened by at random
+	Jitter float64 `json:"jitter,omitempty"`
+}
+
+// MountSourcePolicy determines what libpod does when the source of one of a
+// running container's bind mounts disappears from the host
+type MountSourcePolicy string
+
+const (
+	// MountSourcePolicyLog logs a warning
+	MountSourcePolicyLog MountSourcePolicy = "log"
+	// MountSourcePolicyEvent logs a structured event naming the container
+	// and the missing source
+	MountSourcePolicyEvent MountSourcePolicy = "event"
+	// MountSourcePolicyStop stops the container
+	MountSourcePolicyStop MountSourcePolicy = "stop"
+)
+
+// MachineIDMode determines what the container sees as /etc/machine-id
+type MachineIDMode string
+
+const (
+	// MachineIDModeNone leaves /etc/machine-id as it is in the container's
+	// root filesystem. This is the default.
+	MachineIDModeNone MachineIDMode = "none"
+	// MachineIDModeHost shares the host's machine ID with the container
+	MachineIDModeHost MachineIDMode = "host"
+	// MachineIDModeGenerate gives the container a random machine ID,
+	// which is kept for the life of the container
+	MachineIDModeGenerate MachineIDMode = "generate"
+	// MachineIDModeContainerID uses the container's ID as its machine ID
+	MachineIDModeContainerID MachineIDMode = "container-id"
+)
+
 // DefaultWaitInterval is the default interval between container status checks
 // while waiting.
 const DefaultWaitInterval = 250 * time.Millisecond
 
+// maxStateHistory is the number of state transitions kept for each container
+const maxStateHistory = 100
+
 // LinuxNS represents a Linux namespace
 type LinuxNS int
 
@@ -126,6 +260,15 @@ type Container struct {
 
 	rootlessSlirpSyncR *os.File
 	rootlessSlirpSyncW *os.File
+
+	// reuseExisting is whether creating the container returns the existing
+	// container with the same name instead of failing. Only used during
+	// creation.
+	reuseExisting bool
+
+	// restartedByPolicy is whether the last sync of the container
+	// restarted it under its restart policy
+	restartedByPolicy bool
 }
 
 // containerState contains the current state of the container
@@ -158,6 +301,13 @@ type containerState struct {
 	ExitCode int32 `json:"exitCode,omitempty"`
 	// Exited is whether the container has exited
 	Exited bool `json:"exited,omitempty"`
+	// ExitCodeSource is the path of the exit file ExitCode was read from,
+	// or ExitCodeSourceRuntime if the exit file was missing or could not
+	// be parsed
+	ExitCodeSource string `json:"exitCodeSource,omitempty"`
+	// PeakMemory is the peak memory usage of the container's cgroup in
+	// bytes, recorded when it last stopped
+	PeakMemory uint64 `json:"peakMemory,omitempty"`
 	// OOMKilled indicates that the container was killed as it ran out of
 	// memory
 	OOMKilled bool `json:"oomKilled,omitempty"`
@@ -177,6 +327,90 @@ type containerState struct {
 	// This maps the path the file will be mounted to in the container to
 	// the path of the file on disk outside the container
 	BindMounts map[string]string `json:"bindMounts,omitempty"`
+	// LogJournalOffset is how far into the container's log its lines were
+	// sent to the journal
+	LogJournalOffset int64 `json:"logJournalOffset,omitempty"`
+	// LogSplitOffset is how far into the container's log its lines were
+	// copied into the logs of their streams
+	LogSplitOffset int64 `json:"logSplitOffset,omitempty"`
+	// LogRate tracks the rate of the container's output, if it is limited
+	LogRate *logRateState `json:"logRate,omitempty"`
+	// RunDirFiles are the files and directories libpod created in RunDir,
+	// relative to RunDir. They are removed when the container's storage
+	// is cleaned up.
+	RunDirFiles []string `json:"runDirFiles,omitempty"`
+	// RootfsMountSources maps the IDs of containers whose root filesystems
+	// are mounted into this container to the paths we mounted them at.
+	// Each entry holds a reference on the other container's mount, which
+	// is released when this container's storage is cleaned up.
+	RootfsMountSources map[string]string `json:"rootfsMountSources,omitempty"`
+
+	// AutoRemoveError is why the container could not be removed
+	// automatically after it exited
+	AutoRemoveError string `json:"autoRemoveError,omitempty"`
+	// MissingMountSources are the sources of the container's bind mounts
+	// that were found to have disappeared from the host while the
+	// container was running. Only tracked if the container's mount
+	// sources are monitored.
+	MissingMountSources []string `json:"missingMountSources,omitempty"`
+	// HotMounts are the destinations of the bind mounts that were added to
+	// the container while it was running
+	HotMounts []string `json:"hotMounts,omitempty"`
+	// FSAuditPID is the PID of the process auditing the container's
+	// filesystem changes, if it is running
+	FSAuditPID int `json:"fsAuditPID,omitempty"`
+	// PortsClaimed is whether the container holds a claim on the host
+	// ports of its port mappings, which other containers cannot use
+	// until it is cleaned up
+	PortsClaimed bool `json:"portsClaimed,omitempty"`
+	// PortMappings are the container's port mappings with the host ports
+	// that were allocated for mappings requesting any host port, while
+	// the container holds its claim on them
+	PortMappings []ocicni.PortMapping `json:"portMappings,omitempty"`
+	// RelabelPoint is when the container's root filesystem was last
+	// relabeled with RelabelRootfs. Incremental relabels only relabel
+	// files changed since.
+	RelabelPoint time.Time `json:"relabelPoint,omitempty"`
+	// DivergentState is the state the OCI runtime reported the container
+	// in, if libpod refused to adopt it
+	DivergentState ContainerStatus `json:"divergentState,omitempty"`
+	// DivergentPID is the PID the OCI runtime reported along with
+	// DivergentState
+	DivergentPID int `json:"divergentPID,omitempty"`
+	// StartupTimings are the durations of the phases of the container's
+	// most recent setup and start. Only recorded if requested in the
+	// container's config.
+	StartupTimings map[StartupPhase]time.Duration `json:"startupTimings,omitempty"`
+	// RestartDelay is the delay before the container's pending restart,
+	// before jitter was applied. It is the delay the next one grows from.
+	RestartDelay time.Duration `json:"restartDelay,omitempty"`
+	// NextRestart is when the container's pending restart is due
+	NextRestart time.Time `json:"nextRestart,omitempty"`
+	// RestartCount is the number of times the container was restarted
+	// under its restart policy since it last exited successfully
+	RestartCount int `json:"restartCount,omitempty"`
+	// StoppedByUser is whether the container was last stopped with
+	// Stop(), in which case its restart policy does not restart it
+	StoppedByUser bool `json:"stoppedByUser,omitempty"`
+	// StopReason describes why libpod stopped the container, if libpod
+	// stopped it on its own accord rather than at the request of a user
+	StopReason string `json:"stopReason,omitempty"`
+	// StartError describes why the OCI runtime last failed to create or
+	// start the container. It is cleared when the container starts
+	// successfully.
+	StartError *StartError `json:"startError,omitempty"`
+	// Health is the result of the container's health checks, if it has a
+	// health check configured
+	Health *HealthState `json:"health,omitempty"`
+	// ConmonCgroupPath is the cgroup conmon was last placed in, if it was
+	// placed in the cgroup set by ConmonCgroup
+	ConmonCgroupPath string `json:"conmonCgroupPath,omitempty"`
+	// ConmonCgroupCreated is whether libpod created the cgroupfs cgroup at
+	// ConmonCgroupPath, and will remove it once it is no longer used
+	ConmonCgroupCreated bool `json:"conmonCgroupCreated,omitempty"`
+	// StateHistory records the most recent state transitions of the
+	// container, oldest first
+	StateHistory []StateTransition `json:"stateHistory,omitempty"`
 
 	// UserNSRoot is the directory used as root for the container when using
 	// user namespaces.
@@ -190,6 +424,132 @@ type containerState struct {
 	containerPlatformState
 }
 
+// RootfsMount describes the root filesystem of another container that is
+// bind-mounted into a container
+type RootfsMount struct {
+	// Container is the ID of the container providing the root filesystem
+	Container string `json:"container"`
+	// Destination is the path in the container the root filesystem will
+	// be mounted at
+	Destination string `json:"destination"`
+	// ReadOnly is whether the root filesystem will be mounted read-only
+	ReadOnly bool `json:"readOnly,omitempty"`
+}
+
+// ContainerNamedVolume is a named volume mounted into a container
+type ContainerNamedVolume struct {
+	// Name is the name of the volume. It is created when the container is
+	// mounted if it does not exist.
+	Name string `json:"volumeName"`
+	// Dest is the path in the container the volume is mounted at
+	Dest string `json:"dest"`
+	// Options are the options of the mount, like "ro"
+	Options []string `json:"options,omitempty"`
+}
+
+// UserInfo describes the user and groups a container's process runs as
+type UserInfo struct {
+	// UID is the user ID of the process
+	UID uint32
+	// GID is the primary group ID of the process
+	GID uint32
+	// Username is the name of the user in the container's /etc/passwd, or
+	// "" if the user is not listed there
+	Username string
+	// Groupname is the name of the primary group in the container's
+	// /etc/group, or "" if the group is not listed there
+	Groupname string
+	// Home is the home directory of the user
+	Home string
+	// AdditionalGids are the supplementary groups of the process
+	AdditionalGids []uint32
+}
+
+// StateTransition records a change in a container's state
+type StateTransition struct {
+	// From is the state the container left
+	From ContainerStatus `json:"from"`
+	// To is the state the container entered
+	To ContainerStatus `json:"to"`
+	// Time is when the transition was recorded
+	Time time.Time `json:"time"`
+}
+
+// StartError contains information on why the OCI runtime failed to create or
+// start a container
+type StartError struct {
+	// Stage is the operation that failed, either "create" or "start"
+	Stage string `json:"stage"`
+	// Message is the error that was returned
+	Message string `json:"message"`
+	// RuntimeOutput is the error output of the OCI runtime, if any was
+	// captured
+	RuntimeOutput string `json:"runtimeOutput,omitempty"`
+	// ExitCode is the exit code conmon wrote to the container's exit file,
+	// if it wrote one
+	ExitCode *int32 `json:"exitCode,omitempty"`
+	// Time is the time the failure occurred at
+	Time time.Time `json:"time"`
+}
+
+// HealthConfig configures a command run periodically in a container to check
+// whether it is healthy, like Docker's HEALTHCHECK
+type HealthConfig struct {
+	// Test is the command to run. Its first element is "CMD", followed by
+	// the command and its arguments, or "CMD-SHELL", followed by a command
+	// run with /bin/sh -c. "NONE" disables the health check.
+	Test []string `json:"test"`
+	// Interval is the time between checks. If 0, DefaultHealthInterval is
+	// used.
+	Interval time.Duration `json:"interval,omitempty"`
+	// Timeout is the time a check may run before it is considered failed.
+	// If 0, DefaultHealthTimeout is used.
+	Timeout time.Duration `json:"timeout,omitempty"`
+	// StartPeriod is the time after the container started during which
+	// failed checks do not count towards Retries
+	StartPeriod time.Duration `json:"startPeriod,omitempty"`
+	// Retries is the number of failed checks in a row after which the
+	// container is unhealthy. If 0, DefaultHealthRetries is used.
+	Retries int `json:"retries,omitempty"`
+}
+
+// HealthStatus is the health of a container with a health check
+type HealthStatus string
+
+const (
+	// HealthStatusStarting means no check has succeeded since the
+	// container started, and the container is not yet unhealthy
+	HealthStatusStarting HealthStatus = "starting"
+	// HealthStatusHealthy means the last check succeeded
+	HealthStatusHealthy HealthStatus = "healthy"
+	// HealthStatusUnhealthy means the container failed the configured
+	// number of checks in a row
+	HealthStatusUnhealthy HealthStatus = "unhealthy"
+)
+
+// HealthState is the result of a container's health checks
+type HealthState struct {
+	// Status is the container's health
+	Status HealthStatus `json:"status"`
+	// FailingStreak is the number of checks in a row that failed
+	FailingStreak int `json:"failingStreak"`
+	// Log holds the results of the most recent checks, oldest first
+	Log []HealthCheckResult `json:"log,omitempty"`
+}
+
+// HealthCheckResult is the result of a single health check
+type HealthCheckResult struct {
+	// Start is when the check started
+	Start time.Time `json:"start"`
+	// End is when the check finished
+	End time.Time `json:"end"`
+	// ExitCode is the exit code of the check command, or -1 if it could
+	// not be run or timed out
+	ExitCode int `json:"exitCode"`
+	// Output is the start of the combined output of the check command
+	Output string `json:"output,omitempty"`
+}
+
 // ExecSession contains information on an active exec session
 // easyjson:json
 type ExecSession struct {
@@ -198,6 +558,19 @@ type ExecSession struct {
 	PID     int      `json:"pid"`
 }
 
+// StorageMount is a mount libpod mounts on the host along with a container's
+// storage, to be used by the container's OCI spec
+type StorageMount struct {
+	// Source is the device, directory or file mounted
+	Source string `json:"source"`
+	// Type is the filesystem type of the mount, such as tmpfs or bind
+	Type string `json:"type"`
+	// Options are the mount options, as for mount(8). For bind mounts,
+	// z and Z relabel the source for the container, shared with other
+	// containers or not.
+	Options []string `json:"options,omitempty"`
+}
+
 // ContainerConfig contains all information that was used to create the
 // container. It may not be changed once created.
 // It is stored, read-only, on disk
@@ -221,12 +594,32 @@ type ContainerConfig struct {
 	RootfsImageName string `json:"rootfsImageName,omitempty"`
 	// Rootfs to use for the container, this conflicts with RootfsImageID
 	Rootfs string `json:"rootfs,omitempty"`
+	// StorageDriver is the containers/storage driver the container's
+	// storage was created with
+	StorageDriver string `json:"storageDriver,omitempty"`
 	// Whether to mount volumes specified in the image.
 	ImageVolumes bool `json:"imageVolumes"`
 	// Src path to be mounted on /dev/shm in container.
 	ShmDir string `json:"ShmDir,omitempty"`
 	// Size of the container's SHM.
 	ShmSize int64 `json:"shmSize"`
+	// Mode of the container's SHM. 0 selects the default, 1777.
+	ShmMode os.FileMode `json:"shmMode,omitempty"`
+	// Whether the host's /dev/shm is bind mounted into the container
+	// instead of a tmpfs of ShmSize
+	UseHostShm bool `json:"shmHost,omitempty"`
+	// ReadOnlyRootfs is whether the container's root filesystem is
+	// mounted read-only on the host, as well as in the container
+	ReadOnlyRootfs bool `json:"readOnlyRootfs,omitempty"`
+	// Whether a tmpfs is mounted on the container's /run
+	// Containers in systemd mode always get one
+	RunTmpfs bool `json:"runTmpfs,omitempty"`
+	// Size of the tmpfs on /run in bytes. If 0, DefaultRunTmpfsSize is
+	// used.
+	RunTmpfsSize int64 `json:"runTmpfsSize,omitempty"`
+	// Mode of the tmpfs on /run, including the setuid, setgid and sticky
+	// bits. If 0, DefaultRunTmpfsMode is used.
+	RunTmpfsMode uint32 `json:"runTmpfsMode,omitempty"`
 	// Static directory for container content that will persist across
 	// reboot.
 	StaticDir string `json:"staticDir"`
@@ -234,6 +627,15 @@ type ContainerConfig struct {
 	// These include the SHM mount.
 	// These must be unmounted before the container's rootfs is unmounted.
 	Mounts []string `json:"mounts,omitempty"`
+	// StorageMounts describe how the mounts in Mounts other than the SHM
+	// mount are mounted when the container's storage is mounted, by their
+	// mountpoint
+	StorageMounts map[string]StorageMount `json:"storageMounts,omitempty"`
+	// RootfsMounts are the root filesystems of other containers that will
+	// be bind-mounted into the container
+	RootfsMounts []RootfsMount `json:"rootfsMounts,omitempty"`
+	// NamedVolumes are the named volumes mounted into the container
+	NamedVolumes []*ContainerNamedVolume `json:"namedVolumes,omitempty"`
 
 	// Security Config
 
@@ -291,6 +693,14 @@ type ContainerConfig struct {
 	// DNS options to be set in container resolv.conf
 	// With override options in host resolv if set
 	DNSOption []string `json:"dnsOption,omitempty"`
+	// DNSCache indicates that the container's resolv.conf will point at a
+	// caching resolver run by libpod in the container's network namespace
+	// instead of at the nameservers themselves
+	DNSCache bool `json:"dnsCache,omitempty"`
+	// Timezone is the timezone of the container, as the name of a zone in
+	// the host's zoneinfo database, or "local" to use the host's timezone.
+	// If empty, the timezone of the image is used.
+	Timezone string `json:"timezone,omitempty"`
 	// Hosts to add in container
 	// Will be appended to host's host file
 	HostAdd []string `json:"hostsAdd,omitempty"`
@@ -326,12 +736,106 @@ type ContainerConfig struct {
 	StopSignal uint `json:"stopSignal,omitempty"`
 	// StopTimeout is the signal that will be used to stop the container
 	StopTimeout uint `json:"stopTimeout,omitempty"`
+	// StartupTimeout is the time, in seconds, the container is given to
+	// reach the running state before it is killed. 0 disables the timeout.
+	StartupTimeout uint `json:"startupTimeout,omitempty"`
+	// RecordStartupTimings is whether the durations of the phases of the
+	// container's setup and start are recorded in its state
+	RecordStartupTimings bool `json:"recordStartupTimings,omitempty"`
+	// RestartBackoff configures the delay between restarts of the
+	// container. If nil, it is restarted immediately.
+	RestartBackoff *RestartBackoff `json:"restartBackoff,omitempty"`
+	// HealthCheck configures the command run periodically to check the
+	// container's health while it runs. If nil, it is not checked.
+	HealthCheck *HealthConfig `json:"healthcheck,omitempty"`
+	// RestartPolicy is when the container is restarted after it exits,
+	// one of the RestartPolicy constants. If "", RestartPolicyNo is used.
+	RestartPolicy string `json:"restartPolicy,omitempty"`
+	// RestartRetries is the number of times in a row a container with the
+	// on-failure restart policy is restarted. 0 means no limit.
+	RestartRetries int `json:"restartRetries,omitempty"`
+	// StartPaused has Start() create the container in the OCI runtime but
+	// not begin executing it. The container's process is held before exec
+	// until Resume() is called.
+	StartPaused bool `json:"startPaused,omitempty"`
+	// AutoRemove is whether the container is removed once it has exited
+	// and been cleaned up
+	AutoRemove bool `json:"autoRemove,omitempty"`
+	// CoreDumpMaxSize is the total size, in bytes, of the core dumps that
+	// will be collected into the container's artifacts when it crashes.
+	// 0 disables core dump collection.
+	CoreDumpMaxSize int64 `json:"coreDumpMaxSize,omitempty"`
+	// FSAudit is whether changes to files in the container's root
+	// filesystem are recorded in its fsaudit.json artifact while it runs
+	FSAudit bool `json:"fsAudit,omitempty"`
+	// EnvSecrets maps environment variables of the container's process to
+	// the names of the secrets they are set to. The values are resolved
+	// when the container is created in the OCI runtime, and never stored.
+	EnvSecrets map[string]string `json:"envSecrets,omitempty"`
+	// FSAuditMaxSize is the size, in bytes, the audit log may grow to
+	// before auditing stops
+	FSAuditMaxSize int64 `json:"fsAuditMaxSize,omitempty"`
+	// FSAuditRate is the number of changes per second that are recorded.
+	// Further changes are only counted.
+	FSAuditRate uint `json:"fsAuditRate,omitempty"`
+	// CheckpointQuiesceCommand is run in the container before it is
+	// checkpointed, to bring the applications in it to a consistent state.
+	// If it fails, the checkpoint is aborted.
+	CheckpointQuiesceCommand []string `json:"checkpointQuiesceCommand,omitempty"`
+	// CheckpointResumeCommand is run in the container to undo
+	// CheckpointQuiesceCommand, once the container is restored or if its
+	// checkpoint fails
+	CheckpointResumeCommand []string `json:"checkpointResumeCommand,omitempty"`
+	// CheckpointSyncFS is whether the container's root filesystem is
+	// synced to disk before it is checkpointed
+	CheckpointSyncFS bool `json:"checkpointSyncFS,omitempty"`
 	// Time container was created
 	CreatedTime time.Time `json:"createdTime"`
 	// Cgroup parent of the container
 	CgroupParent string `json:"cgroupParent"`
+	// CgroupMode determines whether libpod creates cgroups for the
+	// container or adopts its cgroup parent. If empty, CgroupModeEnabled
+	// is used.
+	CgroupMode CgroupMode `json:"cgroupMode,omitempty"`
+	// ConmonCgroup is the cgroup conmon is placed in. With the cgroupfs
+	// manager this is a cgroup path, with the systemd manager a slice.
+	// If empty, conmon is placed in a cgroup under the cgroup parent.
+	ConmonCgroup string `json:"conmonCgroup,omitempty"`
 	// LogPath log location
 	LogPath string `json:"logPath"`
+	// LogFullPolicy is what libpod will do when the filesystem holding the
+	// container's log is full. If empty, LogFullPolicyBlock is used.
+	LogFullPolicy LogFullPolicy `json:"logFullPolicy,omitempty"`
+	// MountSourcePolicy is what libpod will do when the source of one of
+	// the container's bind mounts disappears while it is running. If
+	// empty, mount sources are not monitored.
+	MountSourcePolicy MountSourcePolicy `json:"mountSourcePolicy,omitempty"`
+	// LogDriver is where the container's output is logged, LogDriverFile
+	// or LogDriverJournald. If "", LogDriverFile is used.
+	LogDriver string `json:"logDriver,omitempty"`
+	// LogSizeMax is the size, in bytes, at which the container's log is
+	// rotated. 0 disables rotation.
+	LogSizeMax int64 `json:"logSizeMax,omitempty"`
+	// LogMaxFiles is the number of rotated logs kept when LogSizeMax is
+	// set; older ones are removed
+	LogMaxFiles int `json:"logMaxFiles,omitempty"`
+	// SplitLogStreams is whether the container's stdout and stderr are
+	// also written to separate logs in StaticDir
+	SplitLogStreams bool `json:"splitLogStreams,omitempty"`
+	// LogStreamMaxSize is the size, in bytes, at which the log of each
+	// stream is rotated when SplitLogStreams is set. 0 disables rotation.
+	LogStreamMaxSize int64 `json:"logStreamMaxSize,omitempty"`
+	// LogRateLimitLines is the number of lines per second of the
+	// container's output written to the logs of its streams; further
+	// lines are dropped. 0 is unlimited.
+	LogRateLimitLines int64 `json:"logRateLimitLines,omitempty"`
+	// LogRateLimitBytes is the number of bytes per second of the
+	// container's output written to the logs of its streams; further
+	// lines are dropped. 0 is unlimited.
+	LogRateLimitBytes int64 `json:"logRateLimitBytes,omitempty"`
+	// MachineIDMode is how the container's /etc/machine-id is provided.
+	// If empty, MachineIDModeNone is used.
+	MachineIDMode MachineIDMode `json:"machineIDMode,omitempty"`
 	// File containing the conmon PID
 	ConmonPidFile string `json:"conmonPidFile,omitempty"`
 	// TODO log options for log drivers
@@ -423,6 +927,17 @@ func (c *Container) Image() (string, string) {
 	return c.config.RootfsImageID, c.config.RootfsImageName
 }
 
+// NamedVolumes returns the named volumes mounted into the container
+func (c *Container) NamedVolumes() []ContainerNamedVolume {
+	volumes := make([]ContainerNamedVolume, 0, len(c.config.NamedVolumes))
+	for _, vol := range c.config.NamedVolumes {
+		volume := *vol
+		volume.Options = append([]string{}, vol.Options...)
+		volumes = append(volumes, volume)
+	}
+	return volumes
+}
+
 // ImageVolumes returns whether the container is configured to create
 // persistent volumes requested by the image
 func (c *Container) ImageVolumes() bool {
@@ -434,16 +949,61 @@ func (c *Container) ShmDir() string {
 	return c.config.ShmDir
 }
 
+// StorageMounts returns the mounts mounted on the host along with the
+// container's storage, by their mountpoint
+func (c *Container) StorageMounts() map[string]StorageMount {
+	mounts := make(map[string]StorageMount, len(c.config.StorageMounts))
+	for mountpoint, m := range c.config.StorageMounts {
+		m.Options = append([]string{}, m.Options...)
+		mounts[mountpoint] = m
+	}
+	return mounts
+}
+
 // ShmSize returns the size of SHM device to be mounted into the container
 func (c *Container) ShmSize() int64 {
 	return c.config.ShmSize
 }
 
+// ShmMode returns the mode of the SHM device to be mounted into the container,
+// or 0 if the default is used
+func (c *Container) ShmMode() os.FileMode {
+	return c.config.ShmMode
+}
+
+// ShmHost returns whether the host's /dev/shm is mounted into the container
+// instead of a separate SHM device
+func (c *Container) ShmHost() bool {
+	return c.config.UseHostShm
+}
+
+// RunTmpfs returns whether a tmpfs is mounted on the container's /run, along
+// with its size in bytes and its mode
+func (c *Container) RunTmpfs() (bool, int64, uint32) {
+	size := c.config.RunTmpfsSize
+	if size == 0 {
+		size = DefaultRunTmpfsSize
+	}
+	mode := c.config.RunTmpfsMode
+	if mode == 0 {
+		mode = DefaultRunTmpfsMode
+	}
+	return c.config.RunTmpfs || c.config.Systemd, size, mode
+}
+
 // StaticDir returns the directory used to store persistent container files
 func (c *Container) StaticDir() string {
 	return c.config.StaticDir
 }
 
+// RootfsMounts returns the root filesystems of other containers that will be
+// mounted into the container
+func (c *Container) RootfsMounts() []RootfsMount {
+	mounts := make([]RootfsMount, len(c.config.RootfsMounts))
+	copy(mounts, c.config.RootfsMounts)
+	return mounts
+}
+
 // Privileged returns whether the container is privileged
 func (c *Container) Privileged() bool {
 	return c.config.Privileged
@@ -497,6 +1057,11 @@ func (c *Container) Dependencies() []string {
 		dependsCtrs[id] = true
 	}
 
+	// Containers whose root filesystems we mount
+	for _, m := range c.config.RootfsMounts {
+		dependsCtrs[m.Container] = true
+	}
+
 	if len(dependsCtrs) == 0 {
 		return []string{}
 	}
@@ -521,6 +1086,22 @@ func (c *Container) PortMappings() []ocicni.PortMapping {
 	return c.config.PortMappings
 }
 
+// AllocatedPortMappings returns the ports mapped into the container with the
+// host ports in use, including the ones allocated for mappings requesting any
+// host port (host port 0)
+// Until the container is started, and after it is stopped, these are the same
+// as the ones returned by PortMappings().
+func (c *Container) AllocatedPortMappings() ([]ocicni.PortMapping, error) {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+		if err := c.syncContainer(); err != nil {
+			return nil, errors.Wrapf(err, "error updating container %s state", c.ID())
+		}
+	}
+	return c.portMappings(), nil
+}
+
 // DNSServers returns DNS servers that will be used in the container's
 // resolv.conf
 // If empty, DNS server from the host's resolv.conf will be used instead
@@ -542,6 +1123,18 @@ func (c *Container) DNSOption() []string {
 	return c.config.DNSOption
 }
 
+// DNSCache returns whether the container resolves names through a caching DNS
+// resolver run by libpod
+func (c *Container) DNSCache() bool {
+	return c.config.DNSCache
+}
+
+// Timezone returns the timezone of the container.
+// If empty, the image's timezone is used. "local" is the host's timezone.
+func (c *Container) Timezone() string {
+	return c.config.Timezone
+}
+
 // HostsAdd returns hosts that will be added to the container's hosts file
 // The host system's hosts file is used as a base, and these are appended to it
 func (c *Container) HostsAdd() []string {
@@ -610,6 +1203,172 @@ func (c *Container) StopTimeout() uint {
 	return c.config.StopTimeout
 }
 
+// StartupTimeout returns the time, in seconds, the container is given to start
+// before it is killed
+// A timeout of 0 means the container may take as long as it needs to start
+func (c *Container) StartupTimeout() uint {
+	return c.config.StartupTimeout
+}
+
+// StartupTimings returns the durations of the phases of the container's most
+// recent setup and start, if they are recorded
+func (c *Container) StartupTimings() (map[StartupPhase]time.Duration, error) {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+		if err := c.syncContainer(); err != nil {
+			return nil, errors.Wrapf(err, "error updating container %s state", c.ID())
+		}
+	}
+
+	timings := make(map[StartupPhase]time.Duration, len(c.state.StartupTimings))
+	for phase, duration := range c.state.StartupTimings {
+		timings[phase] = duration
+	}
+
+	return timings, nil
+}
+
+// RestartBackoff returns the configuration of the delay between restarts of the
+// container, or nil if it is restarted immediately
+func (c *Container) RestartBackoff() *RestartBackoff {
+	if c.config.RestartBackoff == nil {
+		return nil
+	}
+	backoff := *c.config.RestartBackoff
+	return &backoff
+}
+
+// RestartDelay returns the delay before the container's pending restart,
+// before jitter, and when the restart is due. Both are zero if no restart is
+// pending.
+func (c *Container) RestartDelay() (time.Duration, time.Time, error) {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+		if err := c.syncContainer(); err != nil {
+			return 0, time.Time{}, errors.Wrapf(err, "error updating container %s state", c.ID())
+		}
+	}
+
+	return c.state.RestartDelay, c.state.NextRestart, nil
+}
+
+// HealthCheck returns the configuration of the container's health check, or
+// nil if it has none
+func (c *Container) HealthCheck() *HealthConfig {
+	if c.config.HealthCheck == nil {
+		return nil
+	}
+	healthCheck := *c.config.HealthCheck
+	healthCheck.Test = append([]string{}, c.config.HealthCheck.Test...)
+	return &healthCheck
+}
+
+// Health returns the result of the container's health checks, or nil if it has
+// no health check or was not started since it got one
+func (c *Container) Health() (*HealthState, error) {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+		if err := c.syncContainer(); err != nil {
+			return nil, errors.Wrapf(err, "error updating container %s state", c.ID())
+		}
+	}
+
+	if c.state.Health == nil {
+		return nil, nil
+	}
+	health := *c.state.Health
+	health.Log = append([]HealthCheckResult{}, c.state.Health.Log...)
+	return &health, nil
+}
+
+// RestartPolicy returns when the container is restarted after it exits
+func (c *Container) RestartPolicy() string {
+	if c.config.RestartPolicy == "" {
+		return RestartPolicyNo
+	}
+	return c.config.RestartPolicy
+}
+
+// RestartRetries returns the number of times in a row the container is
+// restarted under the on-failure restart policy. 0 means no limit.
+func (c *Container) RestartRetries() int {
+	return c.config.RestartRetries
+}
+
+// RestartCount returns the number of times the container was restarted under
+// its restart policy since it last exited successfully
+func (c *Container) RestartCount() (int, error) {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+		if err := c.syncContainer(); err != nil {
+			return 0, errors.Wrapf(err, "error updating container %s state", c.ID())
+		}
+	}
+
+	return c.state.RestartCount, nil
+}
+
+// StartPaused returns whether Start() leaves the container created but not
+// executing until Resume() is called
+func (c *Container) StartPaused() bool {
+	return c.config.StartPaused
+}
+
+// AutoRemove returns whether the container is removed once it has exited and
+// been cleaned up
+func (c *Container) AutoRemove() bool {
+	return c.config.AutoRemove
+}
+
+// AutoRemoveError returns why the container could not be removed automatically
+// after it exited, or "" if automatic removal did not fail
+func (c *Container) AutoRemoveError() (string, error) {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+		if err := c.syncContainer(); err != nil {
+			return "", errors.Wrapf(err, "error updating container %s state", c.ID())
+		}
+	}
+
+	return c.state.AutoRemoveError, nil
+}
+
+// CoreDumpMaxSize returns the total size, in bytes, of the core dumps that are
+// collected when the container crashes
+// A size of 0 means core dumps are not collected
+func (c *Container) CoreDumpMaxSize() int64 {
+	return c.config.CoreDumpMaxSize
+}
+
+// FSAudit returns whether changes to files in the container's root filesystem
+// are recorded while it runs, and the size the audit log may grow to and the
+// number of changes per second recorded if they are
+func (c *Container) FSAudit() (bool, int64, uint) {
+	return c.config.FSAudit, c.config.FSAuditMaxSize, c.config.FSAuditRate
+}
+
+// EnvSecrets returns the environment variables of the container's process that
+// are set to secrets, mapped to the names of the secrets
+func (c *Container) EnvSecrets() map[string]string {
+	secrets := make(map[string]string, len(c.config.EnvSecrets))
+	for name, secret := range c.config.EnvSecrets {
+		secrets[name] = secret
+	}
+	return secrets
+}
+
+// StorageDriver returns the containers/storage driver the container's storage
+// was created with. It is empty for containers created before the driver was
+// recorded.
+func (c *Container) StorageDriver() string {
+	return c.config.StorageDriver
+}
+
 // CreatedTime gets the time when the container was created
 func (c *Container) CreatedTime() time.Time {
 	return c.config.CreatedTime
@@ -620,6 +1379,81 @@ func (c *Container) CgroupParent() string {
 	return c.config.CgroupParent
 }
 
+// CgroupMode returns the effective cgroup mode of the container
+func (c *Container) CgroupMode() CgroupMode {
+	if c.config.CgroupMode == "" {
+		return CgroupModeEnabled
+	}
+	return c.config.CgroupMode
+}
+
+// MachineIDMode returns how the container's /etc/machine-id is provided
+func (c *Container) MachineIDMode() MachineIDMode {
+	if c.config.MachineIDMode == "" {
+		return MachineIDModeNone
+	}
+	return c.config.MachineIDMode
+}
+
+// LogFullPolicy returns what libpod does when the filesystem holding the
+// container's log is full
+func (c *Container) LogFullPolicy() LogFullPolicy {
+	if c.config.LogFullPolicy == "" {
+		return LogFullPolicyBlock
+	}
+	return c.config.LogFullPolicy
+}
+
+// LogDriver returns where the container's output is logged
+func (c *Container) LogDriver() string {
+	if c.config.LogDriver == "" {
+		return LogDriverFile
+	}
+	return c.config.LogDriver
+}
+
+// MountSourcePolicy returns what libpod does when the source of one of the
+// container's bind mounts disappears while it is running
+// If "", the container's mount sources are not monitored
+func (c *Container) MountSourcePolicy() MountSourcePolicy {
+	return c.config.MountSourcePolicy
+}
+
+// MissingMountSources returns the sources of the container's bind mounts that
+// have disappeared from the host while it was running
+// They are only tracked if the container's mount sources are monitored
+func (c *Container) MissingMountSources() ([]string, error) {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+		if err := c.syncContainer(); err != nil {
+			return nil, errors.Wrapf(err, "error updating container %s state", c.ID())
+		}
+	}
+
+	missing := make([]string, len(c.state.MissingMountSources))
+	copy(missing, c.state.MissingMountSources)
+
+	return missing, nil
+}
+
+// HotMounts returns the destinations of the bind mounts that were added to the
+// container with AddMount while it was running
+func (c *Container) HotMounts() ([]string, error) {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+		if err := c.syncContainer(); err != nil {
+			return nil, errors.Wrapf(err, "error updating container %s state", c.ID())
+		}
+	}
+
+	mounts := make([]string, len(c.state.HotMounts))
+	copy(mounts, c.state.HotMounts)
+
+	return mounts, nil
+}
+
 // LogPath returns the path to the container's log file
 // This file will only be present after Init() is called to create the container
 // in the runtime
@@ -701,6 +1535,83 @@ func (c *Container) Mounted() (bool, string, error) {
 	return false, "", nil
 }
 
+// StopReason returns why libpod stopped the container on its own accord.
+// If the container was not stopped by libpod, "" is returned.
+func (c *Container) StopReason() (string, error) {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+		if err := c.syncContainer(); err != nil {
+			return "", errors.Wrapf(err, "error updating container %s state", c.ID())
+		}
+	}
+	return c.state.StopReason, nil
+}
+
+// RunDirContents returns the paths of the files and directories libpod created
+// in the container's run directory, such as its resolv.conf, hosts, and
+// hostname files. They are removed when the container's storage is cleaned up.
+// The container's attach socket is not kept in the run directory and is not
+// included.
+func (c *Container) RunDirContents() ([]string, error) {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+		if err := c.syncContainer(); err != nil {
+			return nil, errors.Wrapf(err, "error updating container %s state", c.ID())
+		}
+	}
+
+	paths := make([]string, 0, len(c.state.RunDirFiles))
+	for _, name := range c.state.RunDirFiles {
+		paths = append(paths, filepath.Join(c.state.RunDir, name))
+	}
+
+	return paths, nil
+}
+
+// StateHistory returns the most recent state transitions of the container,
+// oldest first
+// Only the last maxStateHistory transitions are kept
+func (c *Container) StateHistory() ([]StateTransition, error) {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+		if err := c.syncContainer(); err != nil {
+			return nil, errors.Wrapf(err, "error updating container %s state", c.ID())
+		}
+	}
+
+	history := make([]StateTransition, len(c.state.StateHistory))
+	copy(history, c.state.StateHistory)
+
+	return history, nil
+}
+
+// LastStartError returns why the OCI runtime last failed to create or start
+// the container, or nil if the container started successfully since
+func (c *Container) LastStartError() (*StartError, error) {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+		if err := c.syncContainer(); err != nil {
+			return nil, errors.Wrapf(err, "error updating container %s state", c.ID())
+		}
+	}
+
+	if c.state.StartError == nil {
+		return nil, nil
+	}
+
+	startErr := *c.state.StartError
+	if startErr.ExitCode != nil {
+		exitCode := *startErr.ExitCode
+		startErr.ExitCode = &exitCode
+	}
+
+	return &startErr, nil
+}
+
 // StartedTime is the time the container was started
 func (c *Container) StartedTime() (time.Time, error) {
 	if !c.batched {
@@ -740,6 +1651,34 @@ func (c *Container) ExitCode() (int32, bool, error) {
 	return c.state.ExitCode, c.state.Exited, nil
 }
 
+// PeakMemory returns the peak memory usage of the container in bytes during its
+// last run, recorded when it stopped. It is 0 if it is unknown, e.g. because
+// the container has not stopped yet or the host does not track it.
+func (c *Container) PeakMemory() (uint64, error) {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+		if err := c.syncContainer(); err != nil {
+			return 0, errors.Wrapf(err, "error updating container %s state", c.ID())
+		}
+	}
+	return c.state.PeakMemory, nil
+}
+
+// ExitCodeSource returns the path of the exit file the container's exit code
+// was read from, or ExitCodeSourceRuntime if there was no usable exit file and
+// the exit code is unknown
+func (c *Container) ExitCodeSource() (string, error) {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+		if err := c.syncContainer(); err != nil {
+			return "", errors.Wrapf(err, "error updating container %s state", c.ID())
+		}
+	}
+	return c.state.ExitCodeSource, nil
+}
+
 // OOMKilled returns whether the container was killed by an OOM condition
 func (c *Container) OOMKilled() (bool, error) {
 	if !c.batched {
@@ -928,6 +1867,13 @@ func (c *Container) NamespacePath(ns LinuxNS) (string, error) {
 
 // CGroupPath returns a cgroups "path" for a given container.
 func (c *Container) CGroupPath() (string, error) {
+	switch c.CgroupMode() {
+	case CgroupModeDisabled:
+		return "", errors.Wrapf(ErrInvalidArg, "container %s has cgroups disabled", c.ID())
+	case CgroupModeSplit:
+		return c.config.CgroupParent, nil
+	}
+
 	switch c.runtime.config.CgroupManager {
 	case CgroupfsCgroupsManager:
 		return filepath.Join(c.config.CgroupParent, fmt.Sprintf("libpod-%s", c.ID())), nil
@@ -962,6 +1908,48 @@ func (c *Container) RWSize() (int64, error) {
 	return c.rwSize()
 }
 
+// ContainerSize holds the size of a container's root filesystem and of its
+// top read-write layer
+type ContainerSize struct {
+	RootFsSize int64 `json:"rootFsSize"`
+	RwSize     int64 `json:"rwSize"`
+}
+
+// SizeOptions are options for computing the size of a container
+type SizeOptions struct {
+	// ForceRecompute recomputes the size of the container's immutable
+	// image layers instead of using the runtime's cached size
+	ForceRecompute bool
+}
+
+// Size returns both the root filesystem size and the read-write layer size of
+// the container, computed under a single lock
+// The size of the image layers of the root filesystem is cached, as they do not
+// change; the read-write layer is always measured.
+func (c *Container) Size(opts SizeOptions) (*ContainerSize, error) {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+		if err := c.syncContainer(); err != nil {
+			return nil, errors.Wrapf(err, "error updating container %s state", c.ID())
+		}
+	}
+
+	rootFsSize, err := c.rootFsSizeCached(opts.ForceRecompute)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error getting root filesystem size of container %s", c.ID())
+	}
+	rwSize, err := c.rwSize()
+	if err != nil {
+		return nil, errors.Wrapf(err, "error getting read-write layer size of container %s", c.ID())
+	}
+
+	return &ContainerSize{
+		RootFsSize: rootFsSize,
+		RwSize:     rwSize,
+	}, nil
+}
+
 // IDMappings returns the UID/GID mapping used for the container
 func (c *Container) IDMappings() (storage.IDMappingOptions, error) {
 	return c.config.IDMappings, nil
@@ -994,5 +1982,5 @@ func (c *Container) IsInfra() bool {
 
 // IsReadOnly returns whether the container is running in read only mode
 func (c *Container) IsReadOnly() bool {
-	return c.config.Spec.Root.Readonly
+	return c.config.ReadOnlyRootfs || c.config.Spec.Root.Readonly
 }
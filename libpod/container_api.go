@@ -3,8 +3,10 @@ package libpod
 import (
 	"context"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
@@ -12,11 +14,12 @@ import (
 	"github.com/containers/libpod/libpod/driver"
 	"github.com/containers/libpod/pkg/inspect"
 	"github.com/containers/libpod/pkg/lookup"
+	"github.com/containers/storage/pkg/archive"
 	"github.com/containers/storage/pkg/stringid"
 	"github.com/docker/docker/daemon/caps"
+	digest "github.com/opencontainers/go-digest"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
-	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/tools/remotecommand"
 )
 
@@ -46,7 +49,7 @@ func (c *Container) Init(ctx context.Context) (err error) {
 		return errors.Wrapf(ErrCtrStateInvalid, "some dependencies of container %s are not started: %s", c.ID(), depString)
 	}
 
-	if err := c.prepare(); err != nil {
+	if err := c.prepare(ctx); err != nil {
 		return err
 	}
 	defer func() {
@@ -99,7 +102,11 @@ func (c *Container) Start(ctx context.Context) (err error) {
 		return errors.Wrapf(ErrCtrStateInvalid, "some dependencies of container %s are not started: %s", c.ID(), depString)
 	}
 
-	if err := c.prepare(); err != nil {
+	// Containers that were already created are started, even if they were
+	// created paused
+	holdPaused := c.config.StartPaused && c.state.State != ContainerStateCreated
+
+	if err := c.prepare(ctx); err != nil {
 		return err
 	}
 	defer func() {
@@ -123,6 +130,12 @@ func (c *Container) Start(ctx context.Context) (err error) {
 		}
 	}
 
+	// Leave the container created until Resume() is called
+	if holdPaused {
+		logrus.Debugf("Container %s created paused, waiting to be resumed", c.ID())
+		return nil
+	}
+
 	// Start the container
 	return c.start()
 }
@@ -164,7 +177,7 @@ func (c *Container) StartAndAttach(ctx context.Context, streams *AttachStreams,
 		return nil, errors.Wrapf(ErrCtrStateInvalid, "some dependencies of container %s are not started: %s", c.ID(), depString)
 	}
 
-	if err := c.prepare(); err != nil {
+	if err := c.prepare(ctx); err != nil {
 		return nil, err
 	}
 	defer func() {
@@ -427,6 +440,97 @@ func (c *Container) Attach(streams *AttachStreams, keys string, resize <-chan re
 	return c.attach(streams, keys, resize, false)
 }
 
+// RefreshAttachSocket reconciles the container's attach socket with the conmon
+// monitoring the container, e.g. after our temporary directory was cleaned up
+// while the container was running.
+// An error is returned if conmon is no longer listening on the socket.
+func (c *Container) RefreshAttachSocket() error {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return err
+		}
+	}
+
+	if c.state.State != ContainerStateCreated &&
+		c.state.State != ContainerStateRunning &&
+		c.state.State != ContainerStatePaused {
+		return errors.Wrapf(ErrCtrStateInvalid, "can only refresh the attach socket of created, running, or paused containers")
+	}
+
+	return c.refreshAttachSocket()
+}
+
+// DivergentState returns the state the OCI runtime reports the container in,
+// if libpod refused to adopt it under the runtime's state divergence policy.
+// If there is no divergence, false is returned.
+func (c *Container) DivergentState() (ContainerStatus, bool, error) {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return ContainerStateUnknown, false, err
+		}
+	}
+
+	if c.state.DivergentState == ContainerStateUnknown {
+		return ContainerStateUnknown, false, nil
+	}
+	return c.state.DivergentState, true, nil
+}
+
+// AcceptDivergentState adopts the state the OCI runtime reports the container
+// in, after libpod refused to adopt it under the runtime's state divergence
+// policy
+func (c *Container) AcceptDivergentState() error {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return err
+		}
+	}
+
+	if c.state.DivergentState == ContainerStateUnknown {
+		return errors.Wrapf(ErrCtrStateInvalid, "state of container %s has not diverged from the OCI runtime", c.ID())
+	}
+
+	logrus.Infof("Adopting state %s of container %s reported by the OCI runtime", c.state.DivergentState, c.ID())
+	c.state.State = c.state.DivergentState
+	c.state.PID = c.state.DivergentPID
+	c.state.DivergentState = ContainerStateUnknown
+	c.state.DivergentPID = 0
+
+	return c.save()
+}
+
+// GenerateKubeYAML writes a Kubernetes pod running the container to w as YAML
+// The container's image, command, environment, ports, bind mounts and resource
+// limits are converted. Features Kubernetes cannot express, such as joining
+// another container's namespaces, are listed in annotations of the pod and in
+// comments preceding it.
+func (c *Container) GenerateKubeYAML(w io.Writer) error {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return err
+		}
+	}
+
+	pod, err := c.generateForKube()
+	if err != nil {
+		return err
+	}
+
+	return writeKubeYAML(w, pod)
+}
+
 // Mount mounts a container's filesystem on the host
 // The path where the container has been mounted is returned
 func (c *Container) Mount() (string, error) {
@@ -468,9 +572,83 @@ func (c *Container) Unmount(force bool) error {
 			return errors.Wrapf(ErrInternal, "can't unmount %s last mount, it is still in use", c.ID())
 		}
 	}
+	if force {
+		// A forced unmount would pull the root filesystem out from
+		// under containers that have it mounted
+		consumers, err := c.rootfsConsumers()
+		if err != nil {
+			return err
+		}
+		if len(consumers) > 0 {
+			return errors.Wrapf(ErrCtrStateInvalid, "root filesystem of container %s is mounted into containers %s, refusing to unmount", c.ID(), strings.Join(consumers, ","))
+		}
+	}
 	return c.unmount(force)
 }
 
+// AdoptMount reconciles libpod's view of the container's root filesystem with
+// c/storage, for example after the container's layer was mounted out of band.
+// If the storage is mounted, the mountpoint is recorded in the container's
+// state and returned. If it is not mounted, "" is returned, and a stale
+// mountpoint is cleared from the state of containers that are not running.
+func (c *Container) AdoptMount() (string, error) {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return "", err
+		}
+	}
+
+	mountedTimes, err := c.runtime.storageService.MountedContainerImage(c.ID())
+	if err != nil {
+		return "", errors.Wrapf(err, "error determining whether container %s is mounted", c.ID())
+	}
+
+	if mountedTimes == 0 {
+		if c.state.Mounted && c.state.State != ContainerStateRunning && c.state.State != ContainerStatePaused {
+			logrus.Debugf("Storage for container %s is no longer mounted, clearing mountpoint %s", c.ID(), c.state.Mountpoint)
+			c.state.Mounted = false
+			c.state.Mountpoint = ""
+			c.state.RealMountpoint = ""
+			if err := c.save(); err != nil {
+				return "", err
+			}
+		}
+		return "", nil
+	}
+
+	mountPoint, err := c.runtime.storageService.GetMountpoint(c.ID())
+	if err != nil {
+		return "", errors.Wrapf(err, "error retrieving mountpoint of container %s", c.ID())
+	}
+	mountPoint, err = filepath.EvalSymlinks(mountPoint)
+	if err != nil {
+		return "", errors.Wrapf(err, "error resolving storage path for container %s", c.ID())
+	}
+
+	if c.state.Mounted && c.state.Mountpoint == mountPoint {
+		return mountPoint, nil
+	}
+
+	c.state.Mounted = true
+	c.state.Mountpoint = mountPoint
+	if c.state.UserNSRoot == "" {
+		c.state.RealMountpoint = mountPoint
+	} else {
+		c.state.RealMountpoint = filepath.Join(c.state.UserNSRoot, "mountpoint")
+	}
+
+	logrus.Debugf("Adopted mountpoint %s for container %s", mountPoint, c.ID())
+
+	if err := c.save(); err != nil {
+		return "", err
+	}
+
+	return mountPoint, nil
+}
+
 // Pause pauses a container
 func (c *Container) Pause() error {
 	if !c.batched {
@@ -510,6 +688,177 @@ func (c *Container) Unpause() error {
 	return c.unpause()
 }
 
+// Resume begins or continues execution of a container.
+// A container that was created but not started, such as one created paused
+// with WithStartPaused(), is started. A paused container is unpaused.
+func (c *Container) Resume() error {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return err
+		}
+	}
+
+	switch c.state.State {
+	case ContainerStatePaused:
+		return c.unpause()
+	case ContainerStateCreated:
+		notRunning, err := c.checkDependenciesRunning()
+		if err != nil {
+			return errors.Wrapf(err, "error checking dependencies for container %s", c.ID())
+		}
+		if len(notRunning) > 0 {
+			depString := strings.Join(notRunning, ",")
+			return errors.Wrapf(ErrCtrStateInvalid, "some dependencies of container %s are not started: %s", c.ID(), depString)
+		}
+
+		return c.start()
+	default:
+		return errors.Wrapf(ErrCtrStateInvalid, "container %s must be created or paused to be resumed", c.ID())
+	}
+}
+
+// Logs writes the container's logs of the given stream to w. If stream is "",
+// the logs of stdout and stderr are merged in the order they were written.
+// Lines are written in the format conmon logs them in.
+// The container must have been created with WithSplitLogStreams(), or log to
+// the journal, in which case its logs are read from the journal.
+func (c *Container) Logs(stream LogStream, w io.Writer) error {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return err
+		}
+	}
+
+	streams := []LogStream{LogStreamStdout, LogStreamStderr}
+	switch stream {
+	case "":
+	case LogStreamStdout, LogStreamStderr:
+		streams = []LogStream{stream}
+	default:
+		return errors.Wrapf(ErrInvalidArg, "invalid log stream %q", stream)
+	}
+
+	if c.LogDriver() == LogDriverJournald {
+		return c.writeJournalLog(streams, w)
+	}
+
+	if !c.config.SplitLogStreams {
+		return errors.Wrapf(ErrInvalidArg, "container %s does not split its log streams", c.ID())
+	}
+
+	// Pick up lines logged since we last split the log
+	offset := c.state.LogSplitOffset
+	if err := c.splitLogStreams(); err != nil {
+		return err
+	}
+	if c.state.LogSplitOffset != offset {
+		if err := c.save(); err != nil {
+			return err
+		}
+	}
+
+	return c.writeLogStreams(streams, w)
+}
+
+// LogLines sends the lines of the container's log to out, in the format conmon
+// logs them in, without their trailing newlines, and closes out when done.
+// Lines are filtered by opts.Since and opts.Tail. If opts.Follow is set, lines
+// logged afterwards are sent as well, until the container stops.
+func (c *Container) LogLines(opts LogOptions, out chan<- string) error {
+	defer close(out)
+
+	if opts.Tail < 0 {
+		return errors.Wrapf(ErrInvalidArg, "number of log lines must not be negative")
+	}
+
+	if c.batched {
+		// We cannot release the lock to let the container run
+		if opts.Follow {
+			return errors.Wrapf(ErrInvalidArg, "cannot follow the log of container %s in a batch operation", c.ID())
+		}
+	} else {
+		c.lock.Lock()
+		err := c.syncContainer()
+		c.lock.Unlock()
+		if err != nil {
+			return err
+		}
+	}
+
+	reader, err := c.newLogLineReader()
+	if err != nil {
+		return err
+	}
+	defer reader.close()
+
+	lines, err := reader.readLines()
+	if err != nil {
+		return errors.Wrapf(err, "error reading log of container %s", c.ID())
+	}
+	for _, line := range filterLogLines(lines, opts.Since, opts.Tail) {
+		out <- line
+	}
+
+	if !opts.Follow {
+		return nil
+	}
+
+	for {
+		// Check before reading, so the lines logged before the
+		// container stopped are read once it has
+		done, err := c.logFollowDone()
+		if err != nil {
+			return err
+		}
+
+		lines, err := reader.readLines()
+		if err != nil {
+			return errors.Wrapf(err, "error reading log of container %s", c.ID())
+		}
+		for _, line := range filterLogLines(lines, opts.Since, 0) {
+			out <- line
+		}
+
+		if done {
+			return nil
+		}
+		time.Sleep(logFollowInterval)
+	}
+}
+
+// ValidateResources checks the memory and CPU resources of the container's spec
+// against the host's total memory, free memory and CPUs, and returns the ways
+// in which the host cannot currently satisfy them.
+// If the runtime's resource check policy is ResourceCheckPolicyRefuse, an
+// error wrapping ErrInsufficientResources is returned instead when there are
+// any, as creating the container in the OCI runtime would fail.
+func (c *Container) ValidateResources() ([]string, error) {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return nil, err
+		}
+	}
+
+	problems, err := c.validateResources()
+	if err != nil {
+		return nil, err
+	}
+	if len(problems) > 0 && c.runtime.config.ResourceCheckPolicy == ResourceCheckPolicyRefuse {
+		return nil, errors.Wrapf(ErrInsufficientResources, "container %s: %s", c.ID(), strings.Join(problems, "; "))
+	}
+
+	return problems, nil
+}
+
 // Export exports a container's root filesystem as a tar archive
 // The archive will be saved as a file at the given path
 func (c *Container) Export(path string) error {
@@ -525,15 +874,360 @@ func (c *Container) Export(path string) error {
 	return c.export(path)
 }
 
+// ExportToWriter writes a container's root filesystem as a tar archive to w,
+// for example to pipe it to another process without staging it on disk
+// The caller owns w and is responsible for closing it.
+func (c *Container) ExportToWriter(w io.Writer) error {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return err
+		}
+	}
+
+	return c.exportToWriter(w)
+}
+
+// AddMount bind mounts source from the host onto dest in the running
+// container's mount namespace. dest must already exist in the container, and
+// must be a directory if source is one and a file otherwise. It may not be the
+// root of the container or a path libpod manages, such as /etc/resolv.conf or
+// anything under /proc, /sys or /dev.
+// opts may contain "bind" for a non-recursive mount, "rbind" (the default),
+// "ro" and "rw" (the default); read-only applies to the top-level mount only.
+// Added mounts are removed when the container stops.
+// This requires Linux 5.2 or later, for open_tree and move_mount, and
+// CAP_SYS_ADMIN. It is not supported for rootless containers or containers
+// with user namespaces.
+func (c *Container) AddMount(source, dest string, opts []string) error {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return err
+		}
+	}
+
+	return c.addMount(source, dest, opts)
+}
+
+// VerifyBundle compares the OCI spec in the container's bundle, which is what
+// the OCI runtime uses, against the container's stored spec, and returns their
+// differences, e.g. after the bundle was edited by hand.
+// Fields libpod sets itself when generating the bundle, such as the root path,
+// hostname, namespaces, hooks and annotations, are not compared, and the
+// bundle may add mounts and environment variables to the stored ones.
+func (c *Container) VerifyBundle() ([]string, error) {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return nil, err
+		}
+	}
+
+	return c.verifyBundle()
+}
+
+// RegenerateBundle regenerates the OCI spec in the container's bundle from the
+// container's stored config, discarding any changes made to it.
+// The container's storage must be mounted; containers that are not are given
+// a new bundle when they are next started anyway. A container already created
+// in the OCI runtime keeps running with the spec it was created with.
+func (c *Container) RegenerateBundle(ctx context.Context) error {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return err
+		}
+	}
+
+	return c.regenerateBundle(ctx)
+}
+
+// RelabelRootfs relabels the container's root filesystem with its SELinux mount
+// label, e.g. after a policy update, and returns the number of paths that were
+// relabeled, or -1 if the whole root filesystem was.
+// If incremental is set, only the files the container added or modified since
+// the last relabel are relabeled. The whole root filesystem is relabeled if it
+// was never relabeled before, or if it is not in containers/storage.
+// Containers without a mount label are left alone.
+func (c *Container) RelabelRootfs(incremental bool) (int, error) {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return 0, err
+		}
+	}
+
+	return c.relabelRootfs(incremental)
+}
+
+// ExportWithOptions exports a container's root filesystem as a tar archive at
+// the given path, compressed as set in opts, and returns the path written.
+// If path ends in .tar or the extension of another compression, the extension
+// is replaced with the one of the chosen compression, so exporting to
+// "rootfs.tar" with gzip compression writes "rootfs.tar.gz".
+// If opts.Format is ExportFormatOCILayout, an OCI image layout of the
+// container's layers is written to the directory at path instead, which must
+// not exist or be empty.
+// On failure, no partially written archive is left behind.
+func (c *Container) ExportWithOptions(path string, opts ExportOptions) (string, error) {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return "", err
+		}
+	}
+
+	return c.exportWithOptions(path, opts)
+}
+
+// ExportWithMounts exports the container to a tar archive at path containing
+// two files: rootfs.tar, a tar archive of the container's root filesystem, and
+// mounts.json, an ExportMountManifest describing the container's bind mounts and
+// volumes, so they can be recreated when the container is imported.
+// Secret mounts are not included in the manifest.
+func (c *Container) ExportWithMounts(path string) error {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return err
+		}
+	}
+
+	return c.exportWithMounts(path)
+}
+
+// ExportFiltered exports the container's root filesystem as a tar archive at
+// path like Export, but leaves out the paths matching patterns, and returns the
+// total size of the regular files that were left out.
+// Patterns are absolute paths in the container and may contain the wildcards
+// of filepath.Match; a pattern matching a directory excludes its contents, and
+// patterns starting with "!" re-include paths an earlier pattern excluded.
+// If no patterns are given, DefaultExportExcludes are used.
+func (c *Container) ExportFiltered(path string, patterns []string) (int64, error) {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return 0, err
+		}
+	}
+
+	return c.exportFiltered(path, patterns)
+}
+
+// ExportLayers exports each layer of the container, from the base layer of its
+// image to the container's own layer, to dir as an uncompressed tar archive
+// named after its digest, e.g. <sha256 hex>.tar, along with a layers.json
+// manifest listing them in order. The manifest is also returned.
+// Containers that do not use an image have no layers to export.
+func (c *Container) ExportLayers(dir string) (*ExportLayerManifest, error) {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return nil, err
+		}
+	}
+
+	return c.exportLayers(dir)
+}
+
+// ExportWithTemplate exports the container's root filesystem as a tar archive
+// with the given compression to a file in dir, and returns the file's path.
+// The file is named by expanding tmpl as a Go template with the fields Name,
+// ShortID, Timestamp (UTC, as 20060102T150405Z) and Ext (the extension of the
+// compressed archive, such as tar.gz); for example
+// "{{.Name}}-{{.Timestamp}}.{{.Ext}}".
+// The expanded name must be a single path component.
+func (c *Container) ExportWithTemplate(dir, tmpl string, compression archive.Compression) (string, error) {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return "", err
+		}
+	}
+
+	return c.exportWithTemplate(dir, tmpl, compression)
+}
+
+// ExportChunks exports the container's root filesystem as a tar archive split
+// into content-defined chunks, for deduplicating backups.
+// emit is called with the data of each chunk whose digest is not in known,
+// once per digest. The returned manifest lists the digests of all chunks of
+// the archive, in order.
+func (c *Container) ExportChunks(known map[digest.Digest]bool, emit ExportChunkFunc) (*ExportChunkManifest, error) {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return nil, err
+		}
+	}
+
+	return c.exportChunks(known, emit)
+}
+
+// ExportSquashfs writes the container's root filesystem to a squashfs image at
+// path, preserving ownership, permissions and extended attributes.
+// compression selects the algorithm used (gzip, lzma, lzo, lz4, xz or zstd);
+// "" selects gzip.
+// This requires the mksquashfs binary from squashfs-tools to be installed.
+func (c *Container) ExportSquashfs(path, compression string) error {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return err
+		}
+	}
+
+	return c.exportSquashfs(path, compression)
+}
+
+// Changes returns the files that were added, modified, or deleted in the
+// container's root filesystem, sorted by path, like `docker diff`.
+// Only the container's RW layer is considered; changes to volumes and other
+// directories bind mounted into the container are not included.
+// Changes can be retrieved from both running and stopped containers.
+func (c *Container) Changes() ([]archive.Change, error) {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return nil, err
+		}
+	}
+
+	return c.changes()
+}
+
+// ExportChanges writes the changes to the container's root filesystem since a
+// baseline image, layer, or container, for use in incremental backups.
+// A tar archive of the files that were added or modified is written to w, and
+// the paths of files that were deleted are written to deletions, one per line.
+// If baseline is "", changes since the container's image are exported.
+// The caller is responsible for closing w and deletions
+func (c *Container) ExportChanges(baseline string, w, deletions io.Writer) error {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return err
+		}
+	}
+
+	return c.exportChanges(baseline, w, deletions)
+}
+
+// ExportPaths writes a tar archive of the given paths in the container's
+// root filesystem to w, preserving their structure relative to the root
+// The caller is responsible for closing w
+func (c *Container) ExportPaths(paths []string, w io.Writer) error {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return err
+		}
+	}
+
+	return c.exportPaths(paths, w)
+}
+
+// CopyInto copies src on the host to dest in the container's root
+// filesystem, preserving ownership and permissions
+// If dest is an existing directory, src is copied into it
+// The container's storage is mounted temporarily if necessary
+func (c *Container) CopyInto(src, dest string) error {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return err
+		}
+	}
+
+	return c.copyInto(src, dest)
+}
+
+// EffectiveUser resolves the user the container's process runs as against the
+// container's /etc/passwd and /etc/group, returning the user and group names
+// along with the supplementary groups the OCI runtime will apply.
+// The container's storage is mounted temporarily if necessary.
+func (c *Container) EffectiveUser() (*UserInfo, error) {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return nil, err
+		}
+	}
+
+	return c.effectiveUser()
+}
+
+// ConmonLog returns the messages conmon logged about the container, if the
+// runtime is configured to log them to a file
+// These are conmon's diagnostics, not the container's output, which are found
+// in the container's log at LogPath().
+func (c *Container) ConmonLog() ([]byte, error) {
+	contents, err := ioutil.ReadFile(c.getArtifactPath(conmonLogArtifact))
+	if err != nil {
+		return nil, errors.Wrapf(err, "error reading conmon log of container %s", c.ID())
+	}
+	return contents, nil
+}
+
 // AddArtifact creates and writes to an artifact file for the container
 func (c *Container) AddArtifact(name string, data []byte) error {
 	if !c.valid {
 		return ErrCtrRemoved
 	}
 
+	if err := c.repairArtifacts(); err != nil {
+		return err
+	}
+
 	return ioutil.WriteFile(c.getArtifactPath(name), data, 0740)
 }
 
+// RepairArtifacts re-creates the container's artifacts directory if it is
+// missing, and resets its permissions, ownership, and SELinux label to match
+// the container, for example after its storage was migrated
+func (c *Container) RepairArtifacts() error {
+	if !c.valid {
+		return ErrCtrRemoved
+	}
+
+	return c.repairArtifacts()
+}
+
 // GetArtifact reads the specified artifact file from the container
 func (c *Container) GetArtifact(name string) ([]byte, error) {
 	if !c.valid {
@@ -581,57 +1275,119 @@ func (c *Container) Inspect(size bool) (*inspect.ContainerInspectData, error) {
 
 // Wait blocks until the container exits and returns its exit code.
 func (c *Container) Wait() (int32, error) {
-	return c.WaitWithInterval(DefaultWaitInterval)
+	return c.WaitWithContext(context.Background(), DefaultWaitInterval)
 }
 
 // WaitWithInterval blocks until the container to exit and returns its exit
 // code. The argument is the interval at which checks the container's status.
 func (c *Container) WaitWithInterval(waitTimeout time.Duration) (int32, error) {
+	return c.WaitWithContext(context.Background(), waitTimeout)
+}
+
+// WaitWithContext blocks until the container exits and returns its exit code.
+// The container's status is checked once conmon writes its exit file, or every
+// pollInterval if the exit file cannot be watched. If ctx is cancelled or
+// times out first, ctx.Err() is returned.
+func (c *Container) WaitWithContext(ctx context.Context, pollInterval time.Duration) (int32, error) {
 	if !c.valid {
 		return -1, ErrCtrRemoved
 	}
-	err := wait.PollImmediateInfinite(waitTimeout,
-		func() (bool, error) {
-			logrus.Debugf("Checking container %s status...", c.ID())
-			stopped, err := c.isStopped()
-			if err != nil {
-				return false, err
-			}
-			if !stopped {
-				return false, nil
-			}
-			return true, nil
-		},
-	)
+
+	// Watch before the first check, so an exit in between is not missed
+	var exitWritten <-chan struct{}
+	var poll <-chan time.Time
+	watcher, written, err := c.watchExitFile()
 	if err != nil {
-		return 0, err
+		logrus.Debugf("Polling status of container %s: %v", c.ID(), err)
+		ticker := time.NewTicker(pollInterval)
+		defer ticker.Stop()
+		poll = ticker.C
+	} else {
+		defer watcher.Close()
+		exitWritten = written
+	}
+
+	for {
+		logrus.Debugf("Checking container %s status...", c.ID())
+		stopped, err := c.isStopped()
+		if err != nil {
+			return 0, err
+		}
+		if stopped {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return -1, ctx.Err()
+		case <-exitWritten:
+			// The exit file is only written once, check the status
+			// and poll from here on in case it is not updated yet
+			exitWritten = nil
+			ticker := time.NewTicker(pollInterval)
+			defer ticker.Stop()
+			poll = ticker.C
+		case <-poll:
+		}
 	}
-	exitCode := c.state.ExitCode
-	return exitCode, nil
+
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+	}
+	return c.state.ExitCode, nil
 }
 
 // Cleanup unmounts all mount points in container and cleans up container storage
 // It also cleans up the network stack
+// Containers created with WithAutoRemove() are removed afterwards, unless this
+// is called as part of a batch operation
 func (c *Container) Cleanup(ctx context.Context) error {
+	exited, err := c.lockAndCleanup(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Containers cannot be removed during batch operations
+	if c.config.AutoRemove && exited && !c.batched {
+		return c.autoRemove(ctx)
+	}
+
+	return nil
+}
+
+// lockAndCleanup is the locked part of Cleanup
+// It returns whether the container has exited
+func (c *Container) lockAndCleanup(ctx context.Context) (bool, error) {
 	if !c.batched {
 		c.lock.Lock()
 		defer c.lock.Unlock()
 		if err := c.syncContainer(); err != nil {
-			return err
+			return false, err
 		}
 	}
 
+	// Syncing restarted the container under its restart policy, which
+	// cleaned up after its previous run
+	if c.restartedByPolicy {
+		return false, nil
+	}
+
 	// Check if state is good
 	if c.state.State == ContainerStateRunning || c.state.State == ContainerStatePaused {
-		return errors.Wrapf(ErrCtrStateInvalid, "container %s is running or paused, refusing to clean up", c.ID())
+		return false, errors.Wrapf(ErrCtrStateInvalid, "container %s is running or paused, refusing to clean up", c.ID())
 	}
 
 	// Check if we have active exec sessions
 	if len(c.state.ExecSessions) != 0 {
-		return errors.Wrapf(ErrCtrStateInvalid, "container %s has active exec sessions, refusing to clean up", c.ID())
+		return false, errors.Wrapf(ErrCtrStateInvalid, "container %s has active exec sessions, refusing to clean up", c.ID())
 	}
 
-	return c.cleanup(ctx)
+	if err := c.cleanup(ctx); err != nil {
+		return false, err
+	}
+
+	return c.state.State == ContainerStateStopped, nil
 }
 
 // Batch starts a batch operation on the given container
@@ -801,7 +1557,7 @@ func (c *Container) Refresh(ctx context.Context) error {
 
 	// Initialize the container if it was created in runc
 	if wasCreated || wasRunning || wasPaused {
-		if err := c.prepare(); err != nil {
+		if err := c.prepare(ctx); err != nil {
 			return err
 		}
 		if err := c.init(ctx); err != nil {
@@ -841,6 +1597,48 @@ func (c *Container) Checkpoint(ctx context.Context, keep bool) error {
 	return c.checkpoint(ctx, keep)
 }
 
+// ExportForMigration checkpoints the container and writes an archive of its
+// checkpoint, configuration, and the changes to its root filesystem to w, for
+// Runtime.ImportMigration to restore it on another host.
+// The container must be running, and must not be part of a pod or depend on
+// other containers. Volumes and other directories bind mounted into the
+// container are not included.
+// Once the archive is written, the container is left checkpointed, and should
+// be removed once it has been restored elsewhere. If the archive cannot be
+// written, the container is restored.
+func (c *Container) ExportForMigration(ctx context.Context, w io.Writer) error {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return err
+		}
+	}
+
+	return c.exportForMigration(ctx, w)
+}
+
+// ValidateCheckpoint verifies that the checkpoint at the given path is
+// complete and can be restored on this host, without modifying the container.
+// If path is empty, the container's own checkpoint is checked.
+func (c *Container) ValidateCheckpoint(path string) error {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return err
+		}
+	}
+
+	if path == "" {
+		path = c.CheckpointPath()
+	}
+
+	return c.validateCheckpoint(path)
+}
+
 // Restore restores a container
 func (c *Container) Restore(ctx context.Context, keep bool) (err error) {
 	logrus.Debugf("Trying to restore container %s", c)
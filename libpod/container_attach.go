@@ -86,18 +86,19 @@ func (c *Container) attachContainerSocket(resize <-chan remotecommand.TerminalSi
 		}
 	})
 
-	socketPath := c.AttachSocketPath()
-
-	maxUnixLength := int(C.unix_path_length())
-	if maxUnixLength < len(socketPath) {
-		socketPath = socketPath[0:maxUnixLength]
-	}
-
-	logrus.Debug("connecting to socket ", socketPath)
-
-	conn, err := net.DialUnix("unixpacket", nil, &net.UnixAddr{Name: socketPath, Net: "unixpacket"})
+	conn, err := c.dialAttachSocket()
 	if err != nil {
-		return errors.Wrapf(err, "failed to connect to container's attach socket: %v", socketPath)
+		// The socket may be stale, e.g. if our temporary directory was
+		// cleaned up while conmon was running. Try to reconcile it
+		// with conmon and connect again.
+		logrus.Debugf("Error connecting to attach socket of container %s, refreshing it: %v", c.ID(), err)
+		if err2 := c.refreshAttachSocket(); err2 != nil {
+			return err2
+		}
+		conn, err = c.dialAttachSocket()
+		if err != nil {
+			return err
+		}
 	}
 	defer conn.Close()
 
@@ -136,6 +137,90 @@ func (c *Container) attachContainerSocket(resize <-chan remotecommand.TerminalSi
 	return nil
 }
 
+// dialAttachSocket connects to the container's attach socket
+func (c *Container) dialAttachSocket() (*net.UnixConn, error) {
+	socketPath := c.AttachSocketPath()
+
+	maxUnixLength := int(C.unix_path_length())
+	if maxUnixLength < len(socketPath) {
+		socketPath = socketPath[0:maxUnixLength]
+	}
+
+	logrus.Debug("connecting to socket ", socketPath)
+
+	conn, err := net.DialUnix("unixpacket", nil, &net.UnixAddr{Name: socketPath, Net: "unixpacket"})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to connect to container's attach socket: %v", socketPath)
+	}
+	return conn, nil
+}
+
+// refreshAttachSocket reconciles the container's attach socket with its conmon
+// Conmon creates the socket in the container's bundle, and links the bundle
+// into the OCI runtime's sockets directory to keep the socket's path short.
+// If the link is missing or points elsewhere, it is atomically replaced.
+// If conmon is no longer listening on the socket, the stale socket is removed
+// and an error is returned, as conmon will not create it again.
+func (c *Container) refreshAttachSocket() error {
+	linkPath := filepath.Dir(c.AttachSocketPath())
+
+	target, err := os.Readlink(linkPath)
+	if err != nil && !os.IsNotExist(err) {
+		return errors.Wrapf(err, "error reading attach socket link of container %s", c.ID())
+	}
+	if err != nil || target != c.bundlePath() {
+		logrus.Debugf("Replacing attach socket link of container %s", c.ID())
+		tmpPath := linkPath + ".tmp"
+		if err := os.Remove(tmpPath); err != nil && !os.IsNotExist(err) {
+			return errors.Wrapf(err, "error removing %s", tmpPath)
+		}
+		if err := os.Symlink(c.bundlePath(), tmpPath); err != nil {
+			return errors.Wrapf(err, "error linking attach socket of container %s", c.ID())
+		}
+		if err := os.Rename(tmpPath, linkPath); err != nil {
+			if err2 := os.Remove(tmpPath); err2 != nil {
+				logrus.Errorf("Error removing %s: %v", tmpPath, err2)
+			}
+			return errors.Wrapf(err, "error replacing attach socket link of container %s", c.ID())
+		}
+	}
+
+	socketPath := filepath.Join(c.bundlePath(), "attach")
+	if _, err := os.Stat(socketPath); err != nil {
+		if os.IsNotExist(err) {
+			return errors.Wrapf(ErrCtrStateInvalid, "container %s has no attach socket", c.ID())
+		}
+		return errors.Wrapf(err, "error accessing attach socket of container %s", c.ID())
+	}
+
+	conn, err := c.dialAttachSocket()
+	if err == nil {
+		conn.Close()
+		return nil
+	}
+	if opErr, ok := errors.Cause(err).(*net.OpError); !ok || !isConnRefused(opErr) {
+		return err
+	}
+
+	// Nobody is listening, remove the stale socket so attach attempts
+	// fail instead of connecting to it
+	logrus.Warnf("Attach socket of container %s is stale, removing it", c.ID())
+	if err := os.Remove(socketPath); err != nil && !os.IsNotExist(err) {
+		return errors.Wrapf(err, "error removing stale attach socket of container %s", c.ID())
+	}
+	return errors.Wrapf(ErrCtrStateInvalid, "conmon is no longer listening on the attach socket of container %s", c.ID())
+}
+
+// isConnRefused returns whether a failed dial was refused because nobody is
+// listening on the socket
+func isConnRefused(opErr *net.OpError) bool {
+	sysErr, ok := opErr.Err.(*os.SyscallError)
+	if !ok {
+		return false
+	}
+	return sysErr.Err == unix.ECONNREFUSED
+}
+
 func redirectResponseToOutputStreams(outputStream, errorStream io.Writer, writeOutput, writeError bool, conn io.Reader) error {
 	var err error
 	buf := make([]byte, 8192+1) /* Sync with conmon STDIO_BUF_SIZE */
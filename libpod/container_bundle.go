@@ -0,0 +1,186 @@
+package libpod
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	spec "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/pkg/errors"
+)
+
+// bundleInjectedFields are the fields of the OCI spec, by their JSON path, that
+// libpod sets itself when generating a container's bundle, so they are
+// expected to differ from the container's stored spec
+var bundleInjectedFields = map[string]bool{
+	"annotations":          true,
+	"hooks":                true,
+	"hostname":             true,
+	"linux.cgroupsPath":    true,
+	"linux.mountLabel":     true,
+	"linux.namespaces":     true,
+	"mounts":               true,
+	"process.env":          true,
+	"process.rlimits":      true,
+	"process.selinuxLabel": true,
+	"process.user":         true,
+	"root.path":            true,
+}
+
+// verifyBundle compares the OCI spec in the container's bundle against the
+// container's stored spec
+func (c *Container) verifyBundle() ([]string, error) {
+	bundleSpec, err := c.readBundleSpec()
+	if err != nil {
+		return nil, err
+	}
+
+	return bundleDiscrepancies(c.config.Spec, bundleSpec)
+}
+
+// readBundleSpec reads the OCI spec in the container's bundle
+func (c *Container) readBundleSpec() (*spec.Spec, error) {
+	jsonPath := filepath.Join(c.bundlePath(), "config.json")
+	contents, err := ioutil.ReadFile(jsonPath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error reading bundle spec of container %s", c.ID())
+	}
+	bundleSpec := new(spec.Spec)
+	if err := json.Unmarshal(contents, bundleSpec); err != nil {
+		return nil, errors.Wrapf(err, "error decoding bundle spec of container %s", c.ID())
+	}
+
+	return bundleSpec, nil
+}
+
+// regenerateBundle generates the container's OCI spec from its stored config
+// and writes it to its bundle, replacing the one there
+func (c *Container) regenerateBundle(ctx context.Context) error {
+	if !c.state.Mounted {
+		return errors.Wrapf(ErrCtrStateInvalid, "storage of container %s is not mounted; its bundle is regenerated when it next starts", c.ID())
+	}
+
+	spec, err := c.generateSpec(ctx)
+	if err != nil {
+		return err
+	}
+	if err := c.saveSpec(spec); err != nil {
+		return err
+	}
+
+	return c.save()
+}
+
+// bundleDiscrepancies returns the differences between the stored spec of a
+// container and the spec in its bundle, other than the ones libpod makes when
+// generating the bundle
+func bundleDiscrepancies(stored, bundle *spec.Spec) ([]string, error) {
+	storedMap, err := specToMap(stored)
+	if err != nil {
+		return nil, err
+	}
+	bundleMap, err := specToMap(bundle)
+	if err != nil {
+		return nil, err
+	}
+
+	discrepancies := []string{}
+	diffJSONValues("", storedMap, bundleMap, &discrepancies)
+
+	// libpod adds mounts and environment variables, but should keep all
+	// of those in the stored spec
+	bundleMounts := make(map[string]bool, len(bundle.Mounts))
+	for _, m := range bundle.Mounts {
+		bundleMounts[filepath.Clean(m.Destination)] = true
+	}
+	for _, m := range stored.Mounts {
+		if !bundleMounts[filepath.Clean(m.Destination)] {
+			discrepancies = append(discrepancies, fmt.Sprintf("mounts: %s is missing from the bundle", m.Destination))
+		}
+	}
+	if stored.Process != nil {
+		bundleEnv := make(map[string]bool)
+		if bundle.Process != nil {
+			for _, env := range bundle.Process.Env {
+				bundleEnv[env] = true
+			}
+		}
+		for _, env := range stored.Process.Env {
+			if !bundleEnv[env] {
+				name := strings.SplitN(env, "=", 2)[0]
+				discrepancies = append(discrepancies, fmt.Sprintf("process.env: %s is missing or differs in the bundle", name))
+			}
+		}
+	}
+
+	return discrepancies, nil
+}
+
+// specToMap converts a spec to its generic JSON representation
+func specToMap(s *spec.Spec) (map[string]interface{}, error) {
+	if s == nil {
+		s = new(spec.Spec)
+	}
+	contents, err := json.Marshal(s)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error encoding OCI spec")
+	}
+	m := make(map[string]interface{})
+	if err := json.Unmarshal(contents, &m); err != nil {
+		return nil, errors.Wrapf(err, "error decoding OCI spec")
+	}
+	return m, nil
+}
+
+// diffJSONValues appends the differences between two generic JSON values at
+// path to diffs, skipping the fields libpod injects into bundles
+func diffJSONValues(path string, stored, bundle interface{}, diffs *[]string) {
+	if bundleInjectedFields[path] {
+		return
+	}
+
+	storedObj, storedIsObj := stored.(map[string]interface{})
+	bundleObj, bundleIsObj := bundle.(map[string]interface{})
+	if storedIsObj && bundleIsObj {
+		keys := make(map[string]bool)
+		for key := range storedObj {
+			keys[key] = true
+		}
+		for key := range bundleObj {
+			keys[key] = true
+		}
+		sorted := make([]string, 0, len(keys))
+		for key := range keys {
+			sorted = append(sorted, key)
+		}
+		sort.Strings(sorted)
+
+		for _, key := range sorted {
+			childPath := key
+			if path != "" {
+				childPath = path + "." + key
+			}
+			diffJSONValues(childPath, storedObj[key], bundleObj[key], diffs)
+		}
+		return
+	}
+
+	storedArr, storedIsArr := stored.([]interface{})
+	bundleArr, bundleIsArr := bundle.([]interface{})
+	if storedIsArr && bundleIsArr && len(storedArr) == len(bundleArr) {
+		for i := range storedArr {
+			diffJSONValues(fmt.Sprintf("%s[%d]", path, i), storedArr[i], bundleArr[i], diffs)
+		}
+		return
+	}
+
+	storedJSON, _ := json.Marshal(stored)
+	bundleJSON, _ := json.Marshal(bundle)
+	if string(storedJSON) != string(bundleJSON) {
+		*diffs = append(*diffs, fmt.Sprintf("%s: stored %s, bundle %s", path, storedJSON, bundleJSON))
+	}
+}
@@ -0,0 +1,53 @@
+package libpod
+
+import (
+	"testing"
+
+	spec "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBundleDiscrepancies(t *testing.T) {
+	stored := &spec.Spec{
+		Version: "1.0.0",
+		Process: &spec.Process{
+			Args: []string{"sh"},
+			Env:  []string{"PATH=/bin", "FOO=bar"},
+		},
+		Root:   &spec.Root{Path: "/tmp/root"},
+		Mounts: []spec.Mount{{Destination: "/data", Source: "/srv/data"}},
+	}
+
+	// Fields libpod injects are ignored
+	bundle := &spec.Spec{
+		Version:  "1.0.0",
+		Hostname: "abc",
+		Process: &spec.Process{
+			Args: []string{"sh"},
+			Env:  []string{"PATH=/bin", "FOO=bar", "HOSTNAME=abc"},
+		},
+		Root: &spec.Root{Path: "/var/lib/containers/storage/overlay/abc/merged"},
+		Mounts: []spec.Mount{
+			{Destination: "/etc/resolv.conf", Source: "/run/resolv.conf"},
+			{Destination: "/data", Source: "/srv/data"},
+		},
+		Annotations: map[string]string{"a": "b"},
+	}
+	discrepancies, err := bundleDiscrepancies(stored, bundle)
+	require.NoError(t, err)
+	assert.Empty(t, discrepancies)
+
+	bundle.Process.Args = []string{"bash"}
+	bundle.Process.Env = []string{"PATH=/bin"}
+	bundle.Mounts = nil
+	bundle.Root.Readonly = true
+	discrepancies, err = bundleDiscrepancies(stored, bundle)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		`process.args[0]: stored "sh", bundle "bash"`,
+		`root.readonly: stored null, bundle true`,
+		`mounts: /data is missing from the bundle`,
+		`process.env: FOO is missing or differs in the bundle`,
+	}, discrepancies)
+}
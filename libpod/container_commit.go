@@ -2,6 +2,7 @@ package libpod
 
 import (
 	"context"
+	"io"
 	"strings"
 
 	"github.com/containers/buildah"
@@ -24,6 +25,32 @@ type ContainerCommitOptions struct {
 	Changes []string
 }
 
+// ContainerPushOptions is a struct used to push an image committed from a
+// container to a registry
+type ContainerPushOptions struct {
+	// ManifestMIMEType is the manifest type of the pushed image. If empty,
+	// the type of the committed image is kept where possible.
+	ManifestMIMEType string
+	// AuthFile is the path of the registry credentials to use. If empty,
+	// credentials are read from the standard locations.
+	AuthFile string
+	// DockerRegistryOptions are credentials and TLS options for the
+	// registry
+	DockerRegistryOptions image.DockerRegistryOptions
+	// SigningOptions determine how the pushed image is signed
+	SigningOptions image.SigningOptions
+	// ForceCompress compresses the image's layers even if the destination
+	// does not require it
+	ForceCompress bool
+	// ForceSecure requires TLS verification of the registry
+	ForceSecure bool
+	// ProgressWriter receives the progress of the push. May be nil.
+	ProgressWriter io.Writer
+	// RemoveOnFailure removes the committed image from local storage if it
+	// cannot be pushed
+	RemoveOnFailure bool
+}
+
 // ChangeCmds is the list of valid Changes commands to passed to the Commit call
 var ChangeCmds = []string{"CMD", "ENTRYPOINT", "ENV", "EXPOSE", "LABEL", "ONBUILD", "STOPSIGNAL", "USER", "VOLUME", "WORKDIR"}
 
@@ -95,7 +122,11 @@ func (c *Container) Commit(ctx context.Context, destImage string, options Contai
 	// Should we store the ENV we actually want in the spec separately?
 	if c.config.Spec.Process != nil {
 		for _, e := range c.config.Spec.Process.Env {
-			splitEnv := strings.Split(e, "=")
+			// Values may contain "=" themselves
+			splitEnv := strings.SplitN(e, "=", 2)
+			if len(splitEnv) != 2 {
+				continue
+			}
 			importBuilder.SetEnv(splitEnv[0], splitEnv[1])
 		}
 	}
@@ -132,7 +163,11 @@ func (c *Container) Commit(ctx context.Context, destImage string, options Contai
 				importBuilder.ClearEnv()
 				isEnvCleared = true
 			}
-			importBuilder.SetEnv(splitChange[1], splitChange[2])
+			key, value, err := splitCommitChange(change)
+			if err != nil {
+				return nil, err
+			}
+			importBuilder.SetEnv(key, value)
 		case "EXPOSE":
 			if !isExposeCleared { // Multiple values are valid, only clear once
 				importBuilder.ClearPorts()
@@ -144,7 +179,11 @@ func (c *Container) Commit(ctx context.Context, destImage string, options Contai
 				importBuilder.ClearLabels()
 				isLabelCleared = true
 			}
-			importBuilder.SetLabel(splitChange[1], splitChange[2])
+			key, value, err := splitCommitChange(change)
+			if err != nil {
+				return nil, err
+			}
+			importBuilder.SetLabel(key, value)
 		case "ONBUILD":
 			importBuilder.SetOnBuild(splitChange[1])
 		case "STOPSIGNAL":
@@ -178,3 +217,38 @@ func (c *Container) Commit(ctx context.Context, destImage string, options Contai
 	}
 	return c.runtime.imageRuntime.NewFromLocal(id)
 }
+
+// splitCommitChange splits an ENV or LABEL change of the form
+// INSTRUCTION=key=value into its key and value. The value may contain "=".
+func splitCommitChange(change string) (string, string, error) {
+	splitChange := strings.SplitN(change, "=", 3)
+	if len(splitChange) != 3 {
+		return "", "", errors.Wrapf(ErrInvalidArg, "invalid change %q, must be of the form %s=key=value", change, strings.ToUpper(splitChange[0]))
+	}
+	return splitChange[1], splitChange[2], nil
+}
+
+// CommitAndPush commits the changes between a container and its image, and
+// pushes the new image to the given registry reference.
+// The image is also kept in local storage under ref, unless it cannot be pushed
+// and pushOptions.RemoveOnFailure is set.
+func (c *Container) CommitAndPush(ctx context.Context, ref string, commitOptions ContainerCommitOptions, pushOptions ContainerPushOptions) (*image.Image, error) {
+	// The registry transport is implied for the local name
+	localName := strings.TrimPrefix(ref, "docker://")
+
+	newImage, err := c.Commit(ctx, localName, commitOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := newImage.PushImageToHeuristicDestination(ctx, ref, pushOptions.ManifestMIMEType, pushOptions.AuthFile, c.runtime.config.SignaturePolicyPath, pushOptions.ProgressWriter, pushOptions.ForceCompress, pushOptions.SigningOptions, &pushOptions.DockerRegistryOptions, pushOptions.ForceSecure, nil); err != nil {
+		if pushOptions.RemoveOnFailure {
+			if _, err2 := c.runtime.RemoveImage(ctx, newImage, false); err2 != nil {
+				logrus.Errorf("Error removing image %s committed from container %s: %v", newImage.ID(), c.ID(), err2)
+			}
+		}
+		return nil, errors.Wrapf(err, "error pushing image committed from container %s to %q", c.ID(), ref)
+	}
+
+	return newImage, nil
+}
@@ -0,0 +1,68 @@
+package libpod
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/containers/storage/pkg/chrootarchive"
+	"github.com/opencontainers/selinux/go-selinux/label"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// copyInto copies src on the host to dest in the container's root filesystem,
+// preserving ownership and permissions, and labels the copy for the container.
+// If dest is an existing directory, src is copied into it. dest is resolved
+// inside the root filesystem, so it cannot escape it through ".." or symlinks.
+// The container's storage is mounted for the copy if it is not already, and
+// unmounted again afterwards.
+func (c *Container) copyInto(src, dest string) error {
+	srcInfo, err := os.Stat(src)
+	if err != nil {
+		return errors.Wrapf(err, "error accessing %q to copy into container %s", src, c.ID())
+	}
+	if !srcInfo.Mode().IsRegular() && !srcInfo.IsDir() {
+		return errors.Wrapf(ErrInvalidArg, "%q is not a regular file or directory, cannot copy it into container %s", src, c.ID())
+	}
+
+	mountPoint := c.state.Mountpoint
+	if c.config.Rootfs != "" {
+		mountPoint = c.config.Rootfs
+	} else if !c.state.Mounted {
+		mount, err := c.runtime.store.Mount(c.ID(), c.config.MountLabel)
+		if err != nil {
+			return errors.Wrapf(err, "error mounting container %q", c.ID())
+		}
+		mountPoint = mount
+		defer func() {
+			if _, err := c.runtime.store.Unmount(c.ID(), false); err != nil {
+				logrus.Errorf("error unmounting container %q: %v", c.ID(), err)
+			}
+		}()
+	}
+
+	relDest, err := resolveRootfsPath(mountPoint, dest)
+	if err != nil {
+		return errors.Wrapf(err, "error copying to %q in container %s", dest, c.ID())
+	}
+	if info, err := os.Stat(filepath.Join(mountPoint, relDest)); err == nil && info.IsDir() {
+		relDest, err = resolveRootfsPath(mountPoint, filepath.Join(relDest, filepath.Base(src)))
+		if err != nil {
+			return errors.Wrapf(err, "error copying to %q in container %s", dest, c.ID())
+		}
+	}
+	if relDest == "." {
+		return errors.Wrapf(ErrInvalidArg, "cannot replace the root filesystem of container %s", c.ID())
+	}
+	target := filepath.Join(mountPoint, relDest)
+
+	if err := chrootarchive.NewArchiver(nil).CopyWithTar(src, target); err != nil {
+		return errors.Wrapf(err, "error copying %q to %q in container %s", src, dest, c.ID())
+	}
+
+	if err := label.Relabel(target, c.config.MountLabel, false); err != nil {
+		return errors.Wrapf(err, "error labeling %q in container %s", dest, c.ID())
+	}
+
+	return nil
+}
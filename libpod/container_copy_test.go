@@ -0,0 +1,50 @@
+package libpod
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCopyInto(t *testing.T) {
+	dir, err := ioutil.TempDir("", "libpod-copy")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	rootfs := filepath.Join(dir, "rootfs")
+	outside := filepath.Join(dir, "outside")
+	require.NoError(t, os.MkdirAll(filepath.Join(rootfs, "etc"), 0755))
+	require.NoError(t, os.MkdirAll(outside, 0755))
+	require.NoError(t, os.Symlink(outside, filepath.Join(rootfs, "escape")))
+
+	src := filepath.Join(dir, "file")
+	require.NoError(t, ioutil.WriteFile(src, []byte("hello"), 0640))
+
+	ctr := &Container{
+		config: &ContainerConfig{ID: "copy", Rootfs: rootfs},
+		state:  &containerState{},
+	}
+
+	// Copying to a directory copies into it
+	require.NoError(t, ctr.copyInto(src, "/etc"))
+	contents, err := ioutil.ReadFile(filepath.Join(rootfs, "etc", "file"))
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(contents))
+	info, err := os.Stat(filepath.Join(rootfs, "etc", "file"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0640), info.Mode().Perm())
+
+	// Symlinks are resolved inside the root filesystem
+	require.NoError(t, ctr.copyInto(src, "/escape/file"))
+	_, err = os.Stat(filepath.Join(outside, "file"))
+	assert.True(t, os.IsNotExist(err))
+	_, err = os.Stat(filepath.Join(rootfs, outside, "file"))
+	assert.NoError(t, err)
+
+	assert.Error(t, ctr.copyInto(src, "../file"))
+	assert.Error(t, ctr.copyInto(src, "/"))
+}
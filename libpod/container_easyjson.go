@@ -17,6 +17,7 @@ import (
 	specs_go "github.com/opencontainers/runtime-spec/specs-go"
 	net "net"
 	os "os"
+	time "time"
 )
 
 // suppress unused package warning
@@ -72,6 +73,10 @@ func easyjson1dbef17bDecodeGithubComContainersLibpodLibpod(in *jlexer.Lexer, out
 			out.ExitCode = int32(in.Int32())
 		case "exited":
 			out.Exited = bool(in.Bool())
+		case "exitCodeSource":
+			out.ExitCodeSource = string(in.String())
+		case "peakMemory":
+			out.PeakMemory = uint64(in.Uint64())
 		case "oomKilled":
 			out.OOMKilled = bool(in.Bool())
 		case "pid":
@@ -157,6 +162,225 @@ func easyjson1dbef17bDecodeGithubComContainersLibpodLibpod(in *jlexer.Lexer, out
 				}
 				in.Delim('}')
 			}
+		case "logJournalOffset":
+			out.LogJournalOffset = int64(in.Int64())
+		case "logSplitOffset":
+			out.LogSplitOffset = int64(in.Int64())
+		case "logRate":
+			if in.IsNull() {
+				in.Skip()
+				out.LogRate = nil
+			} else {
+				if out.LogRate == nil {
+					out.LogRate = new(logRateState)
+				}
+				easyjson1dbef17bDecodeGithubComContainersLibpodLibpod1(in, &*out.LogRate)
+			}
+		case "runDirFiles":
+			if in.IsNull() {
+				in.Skip()
+				out.RunDirFiles = nil
+			} else {
+				in.Delim('[')
+				if out.RunDirFiles == nil {
+					if !in.IsDelim(']') {
+						out.RunDirFiles = make([]string, 0, 4)
+					} else {
+						out.RunDirFiles = []string{}
+					}
+				} else {
+					out.RunDirFiles = (out.RunDirFiles)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v4 string
+					v4 = string(in.String())
+					out.RunDirFiles = append(out.RunDirFiles, v4)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "rootfsMountSources":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				if !in.IsDelim('}') {
+					out.RootfsMountSources = make(map[string]string)
+				} else {
+					out.RootfsMountSources = nil
+				}
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v5 string
+					v5 = string(in.String())
+					(out.RootfsMountSources)[key] = v5
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
+		case "autoRemoveError":
+			out.AutoRemoveError = string(in.String())
+		case "missingMountSources":
+			if in.IsNull() {
+				in.Skip()
+				out.MissingMountSources = nil
+			} else {
+				in.Delim('[')
+				if out.MissingMountSources == nil {
+					if !in.IsDelim(']') {
+						out.MissingMountSources = make([]string, 0, 4)
+					} else {
+						out.MissingMountSources = []string{}
+					}
+				} else {
+					out.MissingMountSources = (out.MissingMountSources)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v6 string
+					v6 = string(in.String())
+					out.MissingMountSources = append(out.MissingMountSources, v6)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "hotMounts":
+			if in.IsNull() {
+				in.Skip()
+				out.HotMounts = nil
+			} else {
+				in.Delim('[')
+				if out.HotMounts == nil {
+					if !in.IsDelim(']') {
+						out.HotMounts = make([]string, 0, 4)
+					} else {
+						out.HotMounts = []string{}
+					}
+				} else {
+					out.HotMounts = (out.HotMounts)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v7 string
+					v7 = string(in.String())
+					out.HotMounts = append(out.HotMounts, v7)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "fsAuditPID":
+			out.FSAuditPID = int(in.Int())
+		case "portsClaimed":
+			out.PortsClaimed = bool(in.Bool())
+		case "portMappings":
+			if in.IsNull() {
+				in.Skip()
+				out.PortMappings = nil
+			} else {
+				in.Delim('[')
+				if out.PortMappings == nil {
+					if !in.IsDelim(']') {
+						out.PortMappings = make([]ocicni.PortMapping, 0, 1)
+					} else {
+						out.PortMappings = []ocicni.PortMapping{}
+					}
+				} else {
+					out.PortMappings = (out.PortMappings)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v8 ocicni.PortMapping
+					easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComCriOOcicniPkgOcicni(in, &v8)
+					out.PortMappings = append(out.PortMappings, v8)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "relabelPoint":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.RelabelPoint).UnmarshalJSON(data))
+			}
+		case "divergentState":
+			out.DivergentState = ContainerStatus(in.Int())
+		case "divergentPID":
+			out.DivergentPID = int(in.Int())
+		case "startupTimings":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				if !in.IsDelim('}') {
+					out.StartupTimings = make(map[StartupPhase]time.Duration)
+				} else {
+					out.StartupTimings = nil
+				}
+				for !in.IsDelim('}') {
+					key := StartupPhase(in.String())
+					in.WantColon()
+					var v9 time.Duration
+					v9 = time.Duration(in.Int64())
+					(out.StartupTimings)[key] = v9
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
+		case "restartDelay":
+			out.RestartDelay = time.Duration(in.Int64())
+		case "nextRestart":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.NextRestart).UnmarshalJSON(data))
+			}
+		case "restartCount":
+			out.RestartCount = int(in.Int())
+		case "stoppedByUser":
+			out.StoppedByUser = bool(in.Bool())
+		case "stopReason":
+			out.StopReason = string(in.String())
+		case "startError":
+			if in.IsNull() {
+				in.Skip()
+				out.StartError = nil
+			} else {
+				if out.StartError == nil {
+					out.StartError = new(StartError)
+				}
+				easyjson1dbef17bDecodeGithubComContainersLibpodLibpod2(in, &*out.StartError)
+			}
+		case "health":
+			if in.IsNull() {
+				in.Skip()
+				out.Health = nil
+			} else {
+				if out.Health == nil {
+					out.Health = new(HealthState)
+				}
+				easyjson1dbef17bDecodeGithubComContainersLibpodLibpod3(in, &*out.Health)
+			}
+		case "conmonCgroupPath":
+			out.ConmonCgroupPath = string(in.String())
+		case "conmonCgroupCreated":
+			out.ConmonCgroupCreated = bool(in.Bool())
+		case "stateHistory":
+			if in.IsNull() {
+				in.Skip()
+				out.StateHistory = nil
+			} else {
+				in.Delim('[')
+				if out.StateHistory == nil {
+					if !in.IsDelim(']') {
+						out.StateHistory = make([]StateTransition, 0, 1)
+					} else {
+						out.StateHistory = []StateTransition{}
+					}
+				} else {
+					out.StateHistory = (out.StateHistory)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v10 StateTransition
+					easyjson1dbef17bDecodeGithubComContainersLibpodLibpod4(in, &v10)
+					out.StateHistory = append(out.StateHistory, v10)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
 		case "userNSRoot":
 			out.UserNSRoot = string(in.String())
 		case "extensionStageHooks":
@@ -172,30 +396,30 @@ func easyjson1dbef17bDecodeGithubComContainersLibpodLibpod(in *jlexer.Lexer, out
 				for !in.IsDelim('}') {
 					key := string(in.String())
 					in.WantColon()
-					var v4 []specs_go.Hook
+					var v11 []specs_go.Hook
 					if in.IsNull() {
 						in.Skip()
-						v4 = nil
+						v11 = nil
 					} else {
 						in.Delim('[')
-						if v4 == nil {
+						if v11 == nil {
 							if !in.IsDelim(']') {
-								v4 = make([]specs_go.Hook, 0, 1)
+								v11 = make([]specs_go.Hook, 0, 1)
 							} else {
-								v4 = []specs_go.Hook{}
+								v11 = []specs_go.Hook{}
 							}
 						} else {
-							v4 = (v4)[:0]
+							v11 = (v11)[:0]
 						}
 						for !in.IsDelim(']') {
-							var v5 specs_go.Hook
-							easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo(in, &v5)
-							v4 = append(v4, v5)
+							var v12 specs_go.Hook
+							easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo(in, &v12)
+							v11 = append(v11, v12)
 							in.WantComma()
 						}
 						in.Delim(']')
 					}
-					(out.ExtensionStageHooks)[key] = v4
+					(out.ExtensionStageHooks)[key] = v11
 					in.WantComma()
 				}
 				in.Delim('}')
@@ -324,6 +548,26 @@ func easyjson1dbef17bEncodeGithubComContainersLibpodLibpod(out *jwriter.Writer,
 		}
 		out.Bool(bool(in.Exited))
 	}
+	if in.ExitCodeSource != "" {
+		const prefix string = ",\"exitCodeSource\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.ExitCodeSource))
+	}
+	if in.PeakMemory != 0 {
+		const prefix string = ",\"peakMemory\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Uint64(uint64(in.PeakMemory))
+	}
 	if in.OOMKilled {
 		const prefix string = ",\"oomKilled\":"
 		if first {
@@ -354,19 +598,19 @@ func easyjson1dbef17bEncodeGithubComContainersLibpodLibpod(out *jwriter.Writer,
 		}
 		{
 			out.RawByte('{')
-			v6First := true
-			for v6Name, v6Value := range in.ExecSessions {
-				if v6First {
-					v6First = false
+			v13First := true
+			for v13Name, v13Value := range in.ExecSessions {
+				if v13First {
+					v13First = false
 				} else {
 					out.RawByte(',')
 				}
-				out.String(string(v6Name))
+				out.String(string(v13Name))
 				out.RawByte(':')
-				if v6Value == nil {
+				if v13Value == nil {
 					out.RawString("null")
 				} else {
-					out.Raw((*v6Value).MarshalJSON())
+					out.Raw((*v13Value).MarshalJSON())
 				}
 			}
 			out.RawByte('}')
@@ -382,14 +626,14 @@ func easyjson1dbef17bEncodeGithubComContainersLibpodLibpod(out *jwriter.Writer,
 		}
 		{
 			out.RawByte('[')
-			for v7, v8 := range in.NetworkStatus {
-				if v7 > 0 {
+			for v14, v15 := range in.NetworkStatus {
+				if v14 > 0 {
 					out.RawByte(',')
 				}
-				if v8 == nil {
+				if v15 == nil {
 					out.RawString("null")
 				} else {
-					easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComContainernetworkingCniPkgTypesCurrent(out, *v8)
+					easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComContainernetworkingCniPkgTypesCurrent(out, *v15)
 				}
 			}
 			out.RawByte(']')
@@ -405,32 +649,71 @@ func easyjson1dbef17bEncodeGithubComContainersLibpodLibpod(out *jwriter.Writer,
 		}
 		{
 			out.RawByte('{')
-			v9First := true
-			for v9Name, v9Value := range in.BindMounts {
-				if v9First {
-					v9First = false
+			v16First := true
+			for v16Name, v16Value := range in.BindMounts {
+				if v16First {
+					v16First = false
 				} else {
 					out.RawByte(',')
 				}
-				out.String(string(v9Name))
+				out.String(string(v16Name))
 				out.RawByte(':')
-				out.String(string(v9Value))
+				out.String(string(v16Value))
 			}
 			out.RawByte('}')
 		}
 	}
-	if in.UserNSRoot != "" {
-		const prefix string = ",\"userNSRoot\":"
+	if in.LogJournalOffset != 0 {
+		const prefix string = ",\"logJournalOffset\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		out.String(string(in.UserNSRoot))
+		out.Int64(int64(in.LogJournalOffset))
 	}
-	if len(in.ExtensionStageHooks) != 0 {
-		const prefix string = ",\"extensionStageHooks\":"
+	if in.LogSplitOffset != 0 {
+		const prefix string = ",\"logSplitOffset\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.LogSplitOffset))
+	}
+	if in.LogRate != nil {
+		const prefix string = ",\"logRate\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		easyjson1dbef17bEncodeGithubComContainersLibpodLibpod1(out, *in.LogRate)
+	}
+	if len(in.RunDirFiles) != 0 {
+		const prefix string = ",\"runDirFiles\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v17, v18 := range in.RunDirFiles {
+				if v17 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v18))
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.RootfsMountSources) != 0 {
+		const prefix string = ",\"rootfsMountSources\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
@@ -439,160 +722,90 @@ func easyjson1dbef17bEncodeGithubComContainersLibpodLibpod(out *jwriter.Writer,
 		}
 		{
 			out.RawByte('{')
-			v10First := true
-			for v10Name, v10Value := range in.ExtensionStageHooks {
-				if v10First {
-					v10First = false
+			v19First := true
+			for v19Name, v19Value := range in.RootfsMountSources {
+				if v19First {
+					v19First = false
 				} else {
 					out.RawByte(',')
 				}
-				out.String(string(v10Name))
+				out.String(string(v19Name))
 				out.RawByte(':')
-				if v10Value == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
-					out.RawString("null")
-				} else {
-					out.RawByte('[')
-					for v11, v12 := range v10Value {
-						if v11 > 0 {
-							out.RawByte(',')
-						}
-						easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo(out, v12)
-					}
-					out.RawByte(']')
-				}
+				out.String(string(v19Value))
 			}
 			out.RawByte('}')
 		}
 	}
-	out.RawByte('}')
-}
-
-// MarshalJSON supports json.Marshaler interface
-func (v containerState) MarshalJSON() ([]byte, error) {
-	w := jwriter.Writer{}
-	easyjson1dbef17bEncodeGithubComContainersLibpodLibpod(&w, v)
-	return w.Buffer.BuildBytes(), w.Error
-}
-
-// MarshalEasyJSON supports easyjson.Marshaler interface
-func (v containerState) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson1dbef17bEncodeGithubComContainersLibpodLibpod(w, v)
-}
-
-// UnmarshalJSON supports json.Unmarshaler interface
-func (v *containerState) UnmarshalJSON(data []byte) error {
-	r := jlexer.Lexer{Data: data}
-	easyjson1dbef17bDecodeGithubComContainersLibpodLibpod(&r, v)
-	return r.Error()
-}
-
-// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *containerState) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson1dbef17bDecodeGithubComContainersLibpodLibpod(l, v)
-}
-func easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo(in *jlexer.Lexer, out *specs_go.Hook) {
-	isTopLevel := in.IsStart()
-	if in.IsNull() {
-		if isTopLevel {
-			in.Consumed()
+	if in.AutoRemoveError != "" {
+		const prefix string = ",\"autoRemoveError\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
 		}
-		in.Skip()
-		return
+		out.String(string(in.AutoRemoveError))
 	}
-	in.Delim('{')
-	for !in.IsDelim('}') {
-		key := in.UnsafeString()
-		in.WantColon()
-		if in.IsNull() {
-			in.Skip()
-			in.WantComma()
-			continue
+	if len(in.MissingMountSources) != 0 {
+		const prefix string = ",\"missingMountSources\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
 		}
-		switch key {
-		case "path":
-			out.Path = string(in.String())
-		case "args":
-			if in.IsNull() {
-				in.Skip()
-				out.Args = nil
-			} else {
-				in.Delim('[')
-				if out.Args == nil {
-					if !in.IsDelim(']') {
-						out.Args = make([]string, 0, 4)
-					} else {
-						out.Args = []string{}
-					}
-				} else {
-					out.Args = (out.Args)[:0]
-				}
-				for !in.IsDelim(']') {
-					var v13 string
-					v13 = string(in.String())
-					out.Args = append(out.Args, v13)
-					in.WantComma()
+		{
+			out.RawByte('[')
+			for v20, v21 := range in.MissingMountSources {
+				if v20 > 0 {
+					out.RawByte(',')
 				}
-				in.Delim(']')
+				out.String(string(v21))
 			}
-		case "env":
-			if in.IsNull() {
-				in.Skip()
-				out.Env = nil
-			} else {
-				in.Delim('[')
-				if out.Env == nil {
-					if !in.IsDelim(']') {
-						out.Env = make([]string, 0, 4)
-					} else {
-						out.Env = []string{}
-					}
-				} else {
-					out.Env = (out.Env)[:0]
-				}
-				for !in.IsDelim(']') {
-					var v14 string
-					v14 = string(in.String())
-					out.Env = append(out.Env, v14)
-					in.WantComma()
-				}
-				in.Delim(']')
-			}
-		case "timeout":
-			if in.IsNull() {
-				in.Skip()
-				out.Timeout = nil
-			} else {
-				if out.Timeout == nil {
-					out.Timeout = new(int)
+			out.RawByte(']')
+		}
+	}
+	if len(in.HotMounts) != 0 {
+		const prefix string = ",\"hotMounts\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v22, v23 := range in.HotMounts {
+				if v22 > 0 {
+					out.RawByte(',')
 				}
-				*out.Timeout = int(in.Int())
+				out.String(string(v23))
 			}
-		default:
-			in.SkipRecursive()
+			out.RawByte(']')
 		}
-		in.WantComma()
 	}
-	in.Delim('}')
-	if isTopLevel {
-		in.Consumed()
+	if in.FSAuditPID != 0 {
+		const prefix string = ",\"fsAuditPID\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.FSAuditPID))
 	}
-}
-func easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo(out *jwriter.Writer, in specs_go.Hook) {
-	out.RawByte('{')
-	first := true
-	_ = first
-	{
-		const prefix string = ",\"path\":"
+	if in.PortsClaimed {
+		const prefix string = ",\"portsClaimed\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		out.String(string(in.Path))
+		out.Bool(bool(in.PortsClaimed))
 	}
-	if len(in.Args) != 0 {
-		const prefix string = ",\"args\":"
+	if len(in.PortMappings) != 0 {
+		const prefix string = ",\"portMappings\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
@@ -601,17 +814,161 @@ func easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComOpencontainer
 		}
 		{
 			out.RawByte('[')
-			for v15, v16 := range in.Args {
-				if v15 > 0 {
+			for v24, v25 := range in.PortMappings {
+				if v24 > 0 {
 					out.RawByte(',')
 				}
-				out.String(string(v16))
+				easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComCriOOcicniPkgOcicni(out, v25)
 			}
 			out.RawByte(']')
 		}
 	}
-	if len(in.Env) != 0 {
-		const prefix string = ",\"env\":"
+	if true {
+		const prefix string = ",\"relabelPoint\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Raw((in.RelabelPoint).MarshalJSON())
+	}
+	if in.DivergentState != 0 {
+		const prefix string = ",\"divergentState\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.DivergentState))
+	}
+	if in.DivergentPID != 0 {
+		const prefix string = ",\"divergentPID\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.DivergentPID))
+	}
+	if len(in.StartupTimings) != 0 {
+		const prefix string = ",\"startupTimings\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('{')
+			v26First := true
+			for v26Name, v26Value := range in.StartupTimings {
+				if v26First {
+					v26First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v26Name))
+				out.RawByte(':')
+				out.Int64(int64(v26Value))
+			}
+			out.RawByte('}')
+		}
+	}
+	if in.RestartDelay != 0 {
+		const prefix string = ",\"restartDelay\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.RestartDelay))
+	}
+	if true {
+		const prefix string = ",\"nextRestart\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Raw((in.NextRestart).MarshalJSON())
+	}
+	if in.RestartCount != 0 {
+		const prefix string = ",\"restartCount\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.RestartCount))
+	}
+	if in.StoppedByUser {
+		const prefix string = ",\"stoppedByUser\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Bool(bool(in.StoppedByUser))
+	}
+	if in.StopReason != "" {
+		const prefix string = ",\"stopReason\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.StopReason))
+	}
+	if in.StartError != nil {
+		const prefix string = ",\"startError\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		easyjson1dbef17bEncodeGithubComContainersLibpodLibpod2(out, *in.StartError)
+	}
+	if in.Health != nil {
+		const prefix string = ",\"health\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		easyjson1dbef17bEncodeGithubComContainersLibpodLibpod3(out, *in.Health)
+	}
+	if in.ConmonCgroupPath != "" {
+		const prefix string = ",\"conmonCgroupPath\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.ConmonCgroupPath))
+	}
+	if in.ConmonCgroupCreated {
+		const prefix string = ",\"conmonCgroupCreated\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Bool(bool(in.ConmonCgroupCreated))
+	}
+	if len(in.StateHistory) != 0 {
+		const prefix string = ",\"stateHistory\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
@@ -620,28 +977,87 @@ func easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComOpencontainer
 		}
 		{
 			out.RawByte('[')
-			for v17, v18 := range in.Env {
-				if v17 > 0 {
+			for v27, v28 := range in.StateHistory {
+				if v27 > 0 {
 					out.RawByte(',')
 				}
-				out.String(string(v18))
+				easyjson1dbef17bEncodeGithubComContainersLibpodLibpod4(out, v28)
 			}
 			out.RawByte(']')
 		}
 	}
-	if in.Timeout != nil {
-		const prefix string = ",\"timeout\":"
+	if in.UserNSRoot != "" {
+		const prefix string = ",\"userNSRoot\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		out.Int(int(*in.Timeout))
+		out.String(string(in.UserNSRoot))
+	}
+	if len(in.ExtensionStageHooks) != 0 {
+		const prefix string = ",\"extensionStageHooks\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('{')
+			v29First := true
+			for v29Name, v29Value := range in.ExtensionStageHooks {
+				if v29First {
+					v29First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v29Name))
+				out.RawByte(':')
+				if v29Value == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+					out.RawString("null")
+				} else {
+					out.RawByte('[')
+					for v30, v31 := range v29Value {
+						if v30 > 0 {
+							out.RawByte(',')
+						}
+						easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo(out, v31)
+					}
+					out.RawByte(']')
+				}
+			}
+			out.RawByte('}')
+		}
 	}
 	out.RawByte('}')
 }
-func easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComContainernetworkingCniPkgTypesCurrent(in *jlexer.Lexer, out *current.Result) {
+
+// MarshalJSON supports json.Marshaler interface
+func (v containerState) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson1dbef17bEncodeGithubComContainersLibpodLibpod(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v containerState) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson1dbef17bEncodeGithubComContainersLibpodLibpod(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *containerState) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson1dbef17bDecodeGithubComContainersLibpodLibpod(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *containerState) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson1dbef17bDecodeGithubComContainersLibpodLibpod(l, v)
+}
+func easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo(in *jlexer.Lexer, out *specs_go.Hook) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -660,107 +1076,64 @@ func easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComContainernetw
 			continue
 		}
 		switch key {
-		case "cniVersion":
-			out.CNIVersion = string(in.String())
-		case "interfaces":
+		case "path":
+			out.Path = string(in.String())
+		case "args":
 			if in.IsNull() {
 				in.Skip()
-				out.Interfaces = nil
+				out.Args = nil
 			} else {
 				in.Delim('[')
-				if out.Interfaces == nil {
+				if out.Args == nil {
 					if !in.IsDelim(']') {
-						out.Interfaces = make([]*current.Interface, 0, 8)
+						out.Args = make([]string, 0, 4)
 					} else {
-						out.Interfaces = []*current.Interface{}
+						out.Args = []string{}
 					}
 				} else {
-					out.Interfaces = (out.Interfaces)[:0]
+					out.Args = (out.Args)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v19 *current.Interface
-					if in.IsNull() {
-						in.Skip()
-						v19 = nil
-					} else {
-						if v19 == nil {
-							v19 = new(current.Interface)
-						}
-						easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComContainernetworkingCniPkgTypesCurrent1(in, &*v19)
-					}
-					out.Interfaces = append(out.Interfaces, v19)
+					var v32 string
+					v32 = string(in.String())
+					out.Args = append(out.Args, v32)
 					in.WantComma()
 				}
 				in.Delim(']')
 			}
-		case "ips":
+		case "env":
 			if in.IsNull() {
 				in.Skip()
-				out.IPs = nil
+				out.Env = nil
 			} else {
 				in.Delim('[')
-				if out.IPs == nil {
+				if out.Env == nil {
 					if !in.IsDelim(']') {
-						out.IPs = make([]*current.IPConfig, 0, 8)
+						out.Env = make([]string, 0, 4)
 					} else {
-						out.IPs = []*current.IPConfig{}
+						out.Env = []string{}
 					}
 				} else {
-					out.IPs = (out.IPs)[:0]
+					out.Env = (out.Env)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v20 *current.IPConfig
-					if in.IsNull() {
-						in.Skip()
-						v20 = nil
-					} else {
-						if v20 == nil {
-							v20 = new(current.IPConfig)
-						}
-						if data := in.Raw(); in.Ok() {
-							in.AddError((*v20).UnmarshalJSON(data))
-						}
-					}
-					out.IPs = append(out.IPs, v20)
+					var v33 string
+					v33 = string(in.String())
+					out.Env = append(out.Env, v33)
 					in.WantComma()
 				}
 				in.Delim(']')
 			}
-		case "routes":
+		case "timeout":
 			if in.IsNull() {
 				in.Skip()
-				out.Routes = nil
+				out.Timeout = nil
 			} else {
-				in.Delim('[')
-				if out.Routes == nil {
-					if !in.IsDelim(']') {
-						out.Routes = make([]*types.Route, 0, 8)
-					} else {
-						out.Routes = []*types.Route{}
-					}
-				} else {
-					out.Routes = (out.Routes)[:0]
-				}
-				for !in.IsDelim(']') {
-					var v21 *types.Route
-					if in.IsNull() {
-						in.Skip()
-						v21 = nil
-					} else {
-						if v21 == nil {
-							v21 = new(types.Route)
-						}
-						if data := in.Raw(); in.Ok() {
-							in.AddError((*v21).UnmarshalJSON(data))
-						}
-					}
-					out.Routes = append(out.Routes, v21)
-					in.WantComma()
+				if out.Timeout == nil {
+					out.Timeout = new(int)
 				}
-				in.Delim(']')
+				*out.Timeout = int(in.Int())
 			}
-		case "dns":
-			easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComContainernetworkingCniPkgTypes(in, &out.DNS)
 		default:
 			in.SkipRecursive()
 		}
@@ -771,22 +1144,22 @@ func easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComContainernetw
 		in.Consumed()
 	}
 }
-func easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComContainernetworkingCniPkgTypesCurrent(out *jwriter.Writer, in current.Result) {
+func easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo(out *jwriter.Writer, in specs_go.Hook) {
 	out.RawByte('{')
 	first := true
 	_ = first
-	if in.CNIVersion != "" {
-		const prefix string = ",\"cniVersion\":"
+	{
+		const prefix string = ",\"path\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		out.String(string(in.CNIVersion))
+		out.String(string(in.Path))
 	}
-	if len(in.Interfaces) != 0 {
-		const prefix string = ",\"interfaces\":"
+	if len(in.Args) != 0 {
+		const prefix string = ",\"args\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
@@ -795,21 +1168,17 @@ func easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComContainernetw
 		}
 		{
 			out.RawByte('[')
-			for v22, v23 := range in.Interfaces {
-				if v22 > 0 {
+			for v34, v35 := range in.Args {
+				if v34 > 0 {
 					out.RawByte(',')
 				}
-				if v23 == nil {
-					out.RawString("null")
-				} else {
-					easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComContainernetworkingCniPkgTypesCurrent1(out, *v23)
-				}
+				out.String(string(v35))
 			}
 			out.RawByte(']')
 		}
 	}
-	if len(in.IPs) != 0 {
-		const prefix string = ",\"ips\":"
+	if len(in.Env) != 0 {
+		const prefix string = ",\"env\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
@@ -818,55 +1187,101 @@ func easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComContainernetw
 		}
 		{
 			out.RawByte('[')
-			for v24, v25 := range in.IPs {
-				if v24 > 0 {
+			for v36, v37 := range in.Env {
+				if v36 > 0 {
 					out.RawByte(',')
 				}
-				if v25 == nil {
-					out.RawString("null")
-				} else {
-					out.Raw((*v25).MarshalJSON())
-				}
+				out.String(string(v37))
 			}
 			out.RawByte(']')
 		}
 	}
-	if len(in.Routes) != 0 {
-		const prefix string = ",\"routes\":"
+	if in.Timeout != nil {
+		const prefix string = ",\"timeout\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		{
-			out.RawByte('[')
-			for v26, v27 := range in.Routes {
-				if v26 > 0 {
-					out.RawByte(',')
-				}
-				if v27 == nil {
-					out.RawString("null")
-				} else {
-					out.Raw((*v27).MarshalJSON())
-				}
+		out.Int(int(*in.Timeout))
+	}
+	out.RawByte('}')
+}
+func easyjson1dbef17bDecodeGithubComContainersLibpodLibpod4(in *jlexer.Lexer, out *StateTransition) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "from":
+			out.From = ContainerStatus(in.Int())
+		case "to":
+			out.To = ContainerStatus(in.Int())
+		case "time":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.Time).UnmarshalJSON(data))
 			}
-			out.RawByte(']')
+		default:
+			in.SkipRecursive()
 		}
+		in.WantComma()
 	}
-	if true {
-		const prefix string = ",\"dns\":"
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson1dbef17bEncodeGithubComContainersLibpodLibpod4(out *jwriter.Writer, in StateTransition) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"from\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComContainernetworkingCniPkgTypes(out, in.DNS)
+		out.Int(int(in.From))
+	}
+	{
+		const prefix string = ",\"to\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.To))
+	}
+	{
+		const prefix string = ",\"time\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Raw((in.Time).MarshalJSON())
 	}
 	out.RawByte('}')
 }
-func easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComContainernetworkingCniPkgTypes(in *jlexer.Lexer, out *types.DNS) {
+func easyjson1dbef17bDecodeGithubComContainersLibpodLibpod3(in *jlexer.Lexer, out *HealthState) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -885,73 +1300,29 @@ func easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComContainernetw
 			continue
 		}
 		switch key {
-		case "nameservers":
-			if in.IsNull() {
-				in.Skip()
-				out.Nameservers = nil
-			} else {
-				in.Delim('[')
-				if out.Nameservers == nil {
-					if !in.IsDelim(']') {
-						out.Nameservers = make([]string, 0, 4)
-					} else {
-						out.Nameservers = []string{}
-					}
-				} else {
-					out.Nameservers = (out.Nameservers)[:0]
-				}
-				for !in.IsDelim(']') {
-					var v28 string
-					v28 = string(in.String())
-					out.Nameservers = append(out.Nameservers, v28)
-					in.WantComma()
-				}
-				in.Delim(']')
-			}
-		case "domain":
-			out.Domain = string(in.String())
-		case "search":
-			if in.IsNull() {
-				in.Skip()
-				out.Search = nil
-			} else {
-				in.Delim('[')
-				if out.Search == nil {
-					if !in.IsDelim(']') {
-						out.Search = make([]string, 0, 4)
-					} else {
-						out.Search = []string{}
-					}
-				} else {
-					out.Search = (out.Search)[:0]
-				}
-				for !in.IsDelim(']') {
-					var v29 string
-					v29 = string(in.String())
-					out.Search = append(out.Search, v29)
-					in.WantComma()
-				}
-				in.Delim(']')
-			}
-		case "options":
+		case "status":
+			out.Status = HealthStatus(in.String())
+		case "failingStreak":
+			out.FailingStreak = int(in.Int())
+		case "log":
 			if in.IsNull() {
 				in.Skip()
-				out.Options = nil
+				out.Log = nil
 			} else {
 				in.Delim('[')
-				if out.Options == nil {
+				if out.Log == nil {
 					if !in.IsDelim(']') {
-						out.Options = make([]string, 0, 4)
+						out.Log = make([]HealthCheckResult, 0, 1)
 					} else {
-						out.Options = []string{}
+						out.Log = []HealthCheckResult{}
 					}
 				} else {
-					out.Options = (out.Options)[:0]
+					out.Log = (out.Log)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v30 string
-					v30 = string(in.String())
-					out.Options = append(out.Options, v30)
+					var v38 HealthCheckResult
+					easyjson1dbef17bDecodeGithubComContainersLibpodLibpod5(in, &v38)
+					out.Log = append(out.Log, v38)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -966,60 +1337,32 @@ func easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComContainernetw
 		in.Consumed()
 	}
 }
-func easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComContainernetworkingCniPkgTypes(out *jwriter.Writer, in types.DNS) {
+func easyjson1dbef17bEncodeGithubComContainersLibpodLibpod3(out *jwriter.Writer, in HealthState) {
 	out.RawByte('{')
 	first := true
 	_ = first
-	if len(in.Nameservers) != 0 {
-		const prefix string = ",\"nameservers\":"
-		if first {
-			first = false
-			out.RawString(prefix[1:])
-		} else {
-			out.RawString(prefix)
-		}
-		{
-			out.RawByte('[')
-			for v31, v32 := range in.Nameservers {
-				if v31 > 0 {
-					out.RawByte(',')
-				}
-				out.String(string(v32))
-			}
-			out.RawByte(']')
-		}
-	}
-	if in.Domain != "" {
-		const prefix string = ",\"domain\":"
+	{
+		const prefix string = ",\"status\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		out.String(string(in.Domain))
+		out.String(string(in.Status))
 	}
-	if len(in.Search) != 0 {
-		const prefix string = ",\"search\":"
+	{
+		const prefix string = ",\"failingStreak\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		{
-			out.RawByte('[')
-			for v33, v34 := range in.Search {
-				if v33 > 0 {
-					out.RawByte(',')
-				}
-				out.String(string(v34))
-			}
-			out.RawByte(']')
-		}
+		out.Int(int(in.FailingStreak))
 	}
-	if len(in.Options) != 0 {
-		const prefix string = ",\"options\":"
+	if len(in.Log) != 0 {
+		const prefix string = ",\"log\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
@@ -1028,18 +1371,18 @@ func easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComContainernetw
 		}
 		{
 			out.RawByte('[')
-			for v35, v36 := range in.Options {
-				if v35 > 0 {
+			for v39, v40 := range in.Log {
+				if v39 > 0 {
 					out.RawByte(',')
 				}
-				out.String(string(v36))
+				easyjson1dbef17bEncodeGithubComContainersLibpodLibpod5(out, v40)
 			}
 			out.RawByte(']')
 		}
 	}
 	out.RawByte('}')
 }
-func easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComContainernetworkingCniPkgTypesCurrent1(in *jlexer.Lexer, out *current.Interface) {
+func easyjson1dbef17bDecodeGithubComContainersLibpodLibpod5(in *jlexer.Lexer, out *HealthCheckResult) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1058,12 +1401,18 @@ func easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComContainernetw
 			continue
 		}
 		switch key {
-		case "name":
-			out.Name = string(in.String())
-		case "mac":
-			out.Mac = string(in.String())
-		case "sandbox":
-			out.Sandbox = string(in.String())
+		case "start":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.Start).UnmarshalJSON(data))
+			}
+		case "end":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.End).UnmarshalJSON(data))
+			}
+		case "exitCode":
+			out.ExitCode = int(in.Int())
+		case "output":
+			out.Output = string(in.String())
 		default:
 			in.SkipRecursive()
 		}
@@ -1074,43 +1423,53 @@ func easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComContainernetw
 		in.Consumed()
 	}
 }
-func easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComContainernetworkingCniPkgTypesCurrent1(out *jwriter.Writer, in current.Interface) {
+func easyjson1dbef17bEncodeGithubComContainersLibpodLibpod5(out *jwriter.Writer, in HealthCheckResult) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"name\":"
+		const prefix string = ",\"start\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		out.String(string(in.Name))
+		out.Raw((in.Start).MarshalJSON())
 	}
-	if in.Mac != "" {
-		const prefix string = ",\"mac\":"
+	{
+		const prefix string = ",\"end\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		out.String(string(in.Mac))
+		out.Raw((in.End).MarshalJSON())
 	}
-	if in.Sandbox != "" {
-		const prefix string = ",\"sandbox\":"
+	{
+		const prefix string = ",\"exitCode\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		out.String(string(in.Sandbox))
+		out.Int(int(in.ExitCode))
+	}
+	if in.Output != "" {
+		const prefix string = ",\"output\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Output))
 	}
 	out.RawByte('}')
 }
-func easyjson1dbef17bDecodeGithubComContainersLibpodLibpod1(in *jlexer.Lexer, out *ExecSession) {
+func easyjson1dbef17bDecodeGithubComContainersLibpodLibpod2(in *jlexer.Lexer, out *StartError) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1129,33 +1488,26 @@ func easyjson1dbef17bDecodeGithubComContainersLibpodLibpod1(in *jlexer.Lexer, ou
 			continue
 		}
 		switch key {
-		case "id":
-			out.ID = string(in.String())
-		case "command":
+		case "stage":
+			out.Stage = string(in.String())
+		case "message":
+			out.Message = string(in.String())
+		case "runtimeOutput":
+			out.RuntimeOutput = string(in.String())
+		case "exitCode":
 			if in.IsNull() {
 				in.Skip()
-				out.Command = nil
+				out.ExitCode = nil
 			} else {
-				in.Delim('[')
-				if out.Command == nil {
-					if !in.IsDelim(']') {
-						out.Command = make([]string, 0, 4)
-					} else {
-						out.Command = []string{}
-					}
-				} else {
-					out.Command = (out.Command)[:0]
-				}
-				for !in.IsDelim(']') {
-					var v37 string
-					v37 = string(in.String())
-					out.Command = append(out.Command, v37)
-					in.WantComma()
+				if out.ExitCode == nil {
+					out.ExitCode = new(int32)
 				}
-				in.Delim(']')
+				*out.ExitCode = int32(in.Int32())
+			}
+		case "time":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.Time).UnmarshalJSON(data))
 			}
-		case "pid":
-			out.PID = int(in.Int())
 		default:
 			in.SkipRecursive()
 		}
@@ -1166,78 +1518,146 @@ func easyjson1dbef17bDecodeGithubComContainersLibpodLibpod1(in *jlexer.Lexer, ou
 		in.Consumed()
 	}
 }
-func easyjson1dbef17bEncodeGithubComContainersLibpodLibpod1(out *jwriter.Writer, in ExecSession) {
+func easyjson1dbef17bEncodeGithubComContainersLibpodLibpod2(out *jwriter.Writer, in StartError) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"id\":"
+		const prefix string = ",\"stage\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		out.String(string(in.ID))
+		out.String(string(in.Stage))
 	}
 	{
-		const prefix string = ",\"command\":"
+		const prefix string = ",\"message\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		if in.Command == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
-			out.RawString("null")
+		out.String(string(in.Message))
+	}
+	if in.RuntimeOutput != "" {
+		const prefix string = ",\"runtimeOutput\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
 		} else {
-			out.RawByte('[')
-			for v38, v39 := range in.Command {
-				if v38 > 0 {
-					out.RawByte(',')
-				}
-				out.String(string(v39))
-			}
-			out.RawByte(']')
+			out.RawString(prefix)
+		}
+		out.String(string(in.RuntimeOutput))
+	}
+	if in.ExitCode != nil {
+		const prefix string = ",\"exitCode\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
 		}
+		out.Int32(int32(*in.ExitCode))
 	}
 	{
-		const prefix string = ",\"pid\":"
+		const prefix string = ",\"time\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		out.Int(int(in.PID))
+		out.Raw((in.Time).MarshalJSON())
 	}
 	out.RawByte('}')
 }
-
-// MarshalJSON supports json.Marshaler interface
-func (v ExecSession) MarshalJSON() ([]byte, error) {
-	w := jwriter.Writer{}
-	easyjson1dbef17bEncodeGithubComContainersLibpodLibpod1(&w, v)
-	return w.Buffer.BuildBytes(), w.Error
-}
-
-// MarshalEasyJSON supports easyjson.Marshaler interface
-func (v ExecSession) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson1dbef17bEncodeGithubComContainersLibpodLibpod1(w, v)
-}
-
-// UnmarshalJSON supports json.Unmarshaler interface
-func (v *ExecSession) UnmarshalJSON(data []byte) error {
-	r := jlexer.Lexer{Data: data}
-	easyjson1dbef17bDecodeGithubComContainersLibpodLibpod1(&r, v)
-	return r.Error()
+func easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComCriOOcicniPkgOcicni(in *jlexer.Lexer, out *ocicni.PortMapping) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "hostPort":
+			out.HostPort = int32(in.Int32())
+		case "containerPort":
+			out.ContainerPort = int32(in.Int32())
+		case "protocol":
+			out.Protocol = string(in.String())
+		case "hostIP":
+			out.HostIP = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
 }
-
-// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *ExecSession) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson1dbef17bDecodeGithubComContainersLibpodLibpod1(l, v)
+func easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComCriOOcicniPkgOcicni(out *jwriter.Writer, in ocicni.PortMapping) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"hostPort\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int32(int32(in.HostPort))
+	}
+	{
+		const prefix string = ",\"containerPort\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int32(int32(in.ContainerPort))
+	}
+	{
+		const prefix string = ",\"protocol\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Protocol))
+	}
+	{
+		const prefix string = ",\"hostIP\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.HostIP))
+	}
+	out.RawByte('}')
 }
-func easyjson1dbef17bDecodeGithubComContainersLibpodLibpod2(in *jlexer.Lexer, out *ContainerConfig) {
+func easyjson1dbef17bDecodeGithubComContainersLibpodLibpod1(in *jlexer.Lexer, out *logRateState) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1256,346 +1676,594 @@ func easyjson1dbef17bDecodeGithubComContainersLibpodLibpod2(in *jlexer.Lexer, ou
 			continue
 		}
 		switch key {
-		case "spec":
-			if in.IsNull() {
-				in.Skip()
-				out.Spec = nil
-			} else {
-				if out.Spec == nil {
-					out.Spec = new(specs_go.Spec)
-				}
-				easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo1(in, &*out.Spec)
+		case "window":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.Window).UnmarshalJSON(data))
 			}
-		case "id":
-			out.ID = string(in.String())
-		case "name":
-			out.Name = string(in.String())
-		case "pod":
-			out.Pod = string(in.String())
-		case "namespace":
-			out.Namespace = string(in.String())
-		case "idMappingsOptions":
-			easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComContainersStorage(in, &out.IDMappings)
-		case "rootfsImageID":
-			out.RootfsImageID = string(in.String())
-		case "rootfsImageName":
-			out.RootfsImageName = string(in.String())
-		case "rootfs":
-			out.Rootfs = string(in.String())
-		case "imageVolumes":
-			out.ImageVolumes = bool(in.Bool())
-		case "ShmDir":
-			out.ShmDir = string(in.String())
-		case "shmSize":
-			out.ShmSize = int64(in.Int64())
-		case "staticDir":
-			out.StaticDir = string(in.String())
-		case "mounts":
+		case "lines":
+			out.Lines = int64(in.Int64())
+		case "bytes":
+			out.Bytes = int64(in.Int64())
+		case "suppressed":
 			if in.IsNull() {
 				in.Skip()
-				out.Mounts = nil
 			} else {
-				in.Delim('[')
-				if out.Mounts == nil {
-					if !in.IsDelim(']') {
-						out.Mounts = make([]string, 0, 4)
-					} else {
-						out.Mounts = []string{}
-					}
+				in.Delim('{')
+				if !in.IsDelim('}') {
+					out.Suppressed = make(map[LogStream]int64)
 				} else {
-					out.Mounts = (out.Mounts)[:0]
+					out.Suppressed = nil
 				}
-				for !in.IsDelim(']') {
-					var v40 string
-					v40 = string(in.String())
-					out.Mounts = append(out.Mounts, v40)
+				for !in.IsDelim('}') {
+					key := LogStream(in.String())
+					in.WantColon()
+					var v41 int64
+					v41 = int64(in.Int64())
+					(out.Suppressed)[key] = v41
 					in.WantComma()
 				}
-				in.Delim(']')
+				in.Delim('}')
 			}
-		case "privileged":
-			out.Privileged = bool(in.Bool())
-		case "ProcessLabel":
-			out.ProcessLabel = string(in.String())
-		case "MountLabel":
-			out.MountLabel = string(in.String())
-		case "labelopts":
-			if in.IsNull() {
-				in.Skip()
-				out.LabelOpts = nil
-			} else {
-				in.Delim('[')
-				if out.LabelOpts == nil {
-					if !in.IsDelim(']') {
-						out.LabelOpts = make([]string, 0, 4)
-					} else {
-						out.LabelOpts = []string{}
-					}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson1dbef17bEncodeGithubComContainersLibpodLibpod1(out *jwriter.Writer, in logRateState) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if true {
+		const prefix string = ",\"window\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Raw((in.Window).MarshalJSON())
+	}
+	if in.Lines != 0 {
+		const prefix string = ",\"lines\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.Lines))
+	}
+	if in.Bytes != 0 {
+		const prefix string = ",\"bytes\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.Bytes))
+	}
+	if len(in.Suppressed) != 0 {
+		const prefix string = ",\"suppressed\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('{')
+			v42First := true
+			for v42Name, v42Value := range in.Suppressed {
+				if v42First {
+					v42First = false
 				} else {
-					out.LabelOpts = (out.LabelOpts)[:0]
-				}
-				for !in.IsDelim(']') {
-					var v41 string
-					v41 = string(in.String())
-					out.LabelOpts = append(out.LabelOpts, v41)
-					in.WantComma()
+					out.RawByte(',')
 				}
-				in.Delim(']')
+				out.String(string(v42Name))
+				out.RawByte(':')
+				out.Int64(int64(v42Value))
 			}
-		case "user":
-			out.User = string(in.String())
-		case "groups":
+			out.RawByte('}')
+		}
+	}
+	out.RawByte('}')
+}
+func easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComContainernetworkingCniPkgTypesCurrent(in *jlexer.Lexer, out *current.Result) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "cniVersion":
+			out.CNIVersion = string(in.String())
+		case "interfaces":
 			if in.IsNull() {
 				in.Skip()
-				out.Groups = nil
+				out.Interfaces = nil
 			} else {
 				in.Delim('[')
-				if out.Groups == nil {
+				if out.Interfaces == nil {
 					if !in.IsDelim(']') {
-						out.Groups = make([]string, 0, 4)
+						out.Interfaces = make([]*current.Interface, 0, 8)
 					} else {
-						out.Groups = []string{}
+						out.Interfaces = []*current.Interface{}
 					}
 				} else {
-					out.Groups = (out.Groups)[:0]
+					out.Interfaces = (out.Interfaces)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v42 string
-					v42 = string(in.String())
-					out.Groups = append(out.Groups, v42)
+					var v43 *current.Interface
+					if in.IsNull() {
+						in.Skip()
+						v43 = nil
+					} else {
+						if v43 == nil {
+							v43 = new(current.Interface)
+						}
+						easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComContainernetworkingCniPkgTypesCurrent1(in, &*v43)
+					}
+					out.Interfaces = append(out.Interfaces, v43)
 					in.WantComma()
 				}
 				in.Delim(']')
 			}
-		case "ipcNsCtr":
-			out.IPCNsCtr = string(in.String())
-		case "mountNsCtr":
-			out.MountNsCtr = string(in.String())
-		case "netNsCtr":
-			out.NetNsCtr = string(in.String())
-		case "pidNsCtr":
-			out.PIDNsCtr = string(in.String())
-		case "userNsCtr":
-			out.UserNsCtr = string(in.String())
-		case "utsNsCtr":
-			out.UTSNsCtr = string(in.String())
-		case "cgroupNsCtr":
-			out.CgroupNsCtr = string(in.String())
-		case "Dependencies":
+		case "ips":
 			if in.IsNull() {
 				in.Skip()
-				out.Dependencies = nil
+				out.IPs = nil
 			} else {
 				in.Delim('[')
-				if out.Dependencies == nil {
+				if out.IPs == nil {
 					if !in.IsDelim(']') {
-						out.Dependencies = make([]string, 0, 4)
+						out.IPs = make([]*current.IPConfig, 0, 8)
 					} else {
-						out.Dependencies = []string{}
+						out.IPs = []*current.IPConfig{}
 					}
 				} else {
-					out.Dependencies = (out.Dependencies)[:0]
+					out.IPs = (out.IPs)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v43 string
-					v43 = string(in.String())
-					out.Dependencies = append(out.Dependencies, v43)
+					var v44 *current.IPConfig
+					if in.IsNull() {
+						in.Skip()
+						v44 = nil
+					} else {
+						if v44 == nil {
+							v44 = new(current.IPConfig)
+						}
+						if data := in.Raw(); in.Ok() {
+							in.AddError((*v44).UnmarshalJSON(data))
+						}
+					}
+					out.IPs = append(out.IPs, v44)
 					in.WantComma()
 				}
 				in.Delim(']')
 			}
-		case "createNetNS":
-			out.CreateNetNS = bool(in.Bool())
-		case "staticIP":
-			if data := in.UnsafeBytes(); in.Ok() {
-				in.AddError((out.StaticIP).UnmarshalText(data))
-			}
-		case "portMappings":
+		case "routes":
 			if in.IsNull() {
 				in.Skip()
-				out.PortMappings = nil
+				out.Routes = nil
 			} else {
 				in.Delim('[')
-				if out.PortMappings == nil {
+				if out.Routes == nil {
 					if !in.IsDelim(']') {
-						out.PortMappings = make([]ocicni.PortMapping, 0, 1)
+						out.Routes = make([]*types.Route, 0, 8)
 					} else {
-						out.PortMappings = []ocicni.PortMapping{}
+						out.Routes = []*types.Route{}
 					}
 				} else {
-					out.PortMappings = (out.PortMappings)[:0]
+					out.Routes = (out.Routes)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v44 ocicni.PortMapping
-					easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComCriOOcicniPkgOcicni(in, &v44)
-					out.PortMappings = append(out.PortMappings, v44)
+					var v45 *types.Route
+					if in.IsNull() {
+						in.Skip()
+						v45 = nil
+					} else {
+						if v45 == nil {
+							v45 = new(types.Route)
+						}
+						if data := in.Raw(); in.Ok() {
+							in.AddError((*v45).UnmarshalJSON(data))
+						}
+					}
+					out.Routes = append(out.Routes, v45)
 					in.WantComma()
 				}
 				in.Delim(']')
 			}
-		case "dnsServer":
+		case "dns":
+			easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComContainernetworkingCniPkgTypes(in, &out.DNS)
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComContainernetworkingCniPkgTypesCurrent(out *jwriter.Writer, in current.Result) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.CNIVersion != "" {
+		const prefix string = ",\"cniVersion\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.CNIVersion))
+	}
+	if len(in.Interfaces) != 0 {
+		const prefix string = ",\"interfaces\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v46, v47 := range in.Interfaces {
+				if v46 > 0 {
+					out.RawByte(',')
+				}
+				if v47 == nil {
+					out.RawString("null")
+				} else {
+					easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComContainernetworkingCniPkgTypesCurrent1(out, *v47)
+				}
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.IPs) != 0 {
+		const prefix string = ",\"ips\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v48, v49 := range in.IPs {
+				if v48 > 0 {
+					out.RawByte(',')
+				}
+				if v49 == nil {
+					out.RawString("null")
+				} else {
+					out.Raw((*v49).MarshalJSON())
+				}
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.Routes) != 0 {
+		const prefix string = ",\"routes\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v50, v51 := range in.Routes {
+				if v50 > 0 {
+					out.RawByte(',')
+				}
+				if v51 == nil {
+					out.RawString("null")
+				} else {
+					out.Raw((*v51).MarshalJSON())
+				}
+			}
+			out.RawByte(']')
+		}
+	}
+	if true {
+		const prefix string = ",\"dns\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComContainernetworkingCniPkgTypes(out, in.DNS)
+	}
+	out.RawByte('}')
+}
+func easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComContainernetworkingCniPkgTypes(in *jlexer.Lexer, out *types.DNS) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "nameservers":
 			if in.IsNull() {
 				in.Skip()
-				out.DNSServer = nil
+				out.Nameservers = nil
 			} else {
 				in.Delim('[')
-				if out.DNSServer == nil {
+				if out.Nameservers == nil {
 					if !in.IsDelim(']') {
-						out.DNSServer = make([]net.IP, 0, 2)
+						out.Nameservers = make([]string, 0, 4)
 					} else {
-						out.DNSServer = []net.IP{}
+						out.Nameservers = []string{}
 					}
 				} else {
-					out.DNSServer = (out.DNSServer)[:0]
+					out.Nameservers = (out.Nameservers)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v45 net.IP
-					if data := in.UnsafeBytes(); in.Ok() {
-						in.AddError((v45).UnmarshalText(data))
-					}
-					out.DNSServer = append(out.DNSServer, v45)
+					var v52 string
+					v52 = string(in.String())
+					out.Nameservers = append(out.Nameservers, v52)
 					in.WantComma()
 				}
 				in.Delim(']')
 			}
-		case "dnsSearch":
+		case "domain":
+			out.Domain = string(in.String())
+		case "search":
 			if in.IsNull() {
 				in.Skip()
-				out.DNSSearch = nil
+				out.Search = nil
 			} else {
 				in.Delim('[')
-				if out.DNSSearch == nil {
+				if out.Search == nil {
 					if !in.IsDelim(']') {
-						out.DNSSearch = make([]string, 0, 4)
+						out.Search = make([]string, 0, 4)
 					} else {
-						out.DNSSearch = []string{}
+						out.Search = []string{}
 					}
 				} else {
-					out.DNSSearch = (out.DNSSearch)[:0]
+					out.Search = (out.Search)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v46 string
-					v46 = string(in.String())
-					out.DNSSearch = append(out.DNSSearch, v46)
+					var v53 string
+					v53 = string(in.String())
+					out.Search = append(out.Search, v53)
 					in.WantComma()
 				}
 				in.Delim(']')
 			}
-		case "dnsOption":
+		case "options":
 			if in.IsNull() {
 				in.Skip()
-				out.DNSOption = nil
+				out.Options = nil
 			} else {
 				in.Delim('[')
-				if out.DNSOption == nil {
+				if out.Options == nil {
 					if !in.IsDelim(']') {
-						out.DNSOption = make([]string, 0, 4)
+						out.Options = make([]string, 0, 4)
 					} else {
-						out.DNSOption = []string{}
-					}
-				} else {
-					out.DNSOption = (out.DNSOption)[:0]
-				}
-				for !in.IsDelim(']') {
-					var v47 string
-					v47 = string(in.String())
-					out.DNSOption = append(out.DNSOption, v47)
-					in.WantComma()
-				}
-				in.Delim(']')
-			}
-		case "hostsAdd":
-			if in.IsNull() {
-				in.Skip()
-				out.HostAdd = nil
-			} else {
-				in.Delim('[')
-				if out.HostAdd == nil {
-					if !in.IsDelim(']') {
-						out.HostAdd = make([]string, 0, 4)
-					} else {
-						out.HostAdd = []string{}
+						out.Options = []string{}
 					}
 				} else {
-					out.HostAdd = (out.HostAdd)[:0]
+					out.Options = (out.Options)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v48 string
-					v48 = string(in.String())
-					out.HostAdd = append(out.HostAdd, v48)
+					var v54 string
+					v54 = string(in.String())
+					out.Options = append(out.Options, v54)
 					in.WantComma()
 				}
 				in.Delim(']')
 			}
-		case "networks":
-			if in.IsNull() {
-				in.Skip()
-				out.Networks = nil
-			} else {
-				in.Delim('[')
-				if out.Networks == nil {
-					if !in.IsDelim(']') {
-						out.Networks = make([]string, 0, 4)
-					} else {
-						out.Networks = []string{}
-					}
-				} else {
-					out.Networks = (out.Networks)[:0]
-				}
-				for !in.IsDelim(']') {
-					var v49 string
-					v49 = string(in.String())
-					out.Networks = append(out.Networks, v49)
-					in.WantComma()
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComContainernetworkingCniPkgTypes(out *jwriter.Writer, in types.DNS) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if len(in.Nameservers) != 0 {
+		const prefix string = ",\"nameservers\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v55, v56 := range in.Nameservers {
+				if v55 > 0 {
+					out.RawByte(',')
 				}
-				in.Delim(']')
+				out.String(string(v56))
 			}
-		case "userVolumes":
-			if in.IsNull() {
-				in.Skip()
-				out.UserVolumes = nil
-			} else {
-				in.Delim('[')
-				if out.UserVolumes == nil {
-					if !in.IsDelim(']') {
-						out.UserVolumes = make([]string, 0, 4)
-					} else {
-						out.UserVolumes = []string{}
-					}
-				} else {
-					out.UserVolumes = (out.UserVolumes)[:0]
-				}
-				for !in.IsDelim(']') {
-					var v50 string
-					v50 = string(in.String())
-					out.UserVolumes = append(out.UserVolumes, v50)
-					in.WantComma()
+			out.RawByte(']')
+		}
+	}
+	if in.Domain != "" {
+		const prefix string = ",\"domain\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Domain))
+	}
+	if len(in.Search) != 0 {
+		const prefix string = ",\"search\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v57, v58 := range in.Search {
+				if v57 > 0 {
+					out.RawByte(',')
 				}
-				in.Delim(']')
+				out.String(string(v58))
 			}
-		case "entrypoint":
-			if in.IsNull() {
-				in.Skip()
-				out.Entrypoint = nil
-			} else {
-				in.Delim('[')
-				if out.Entrypoint == nil {
-					if !in.IsDelim(']') {
-						out.Entrypoint = make([]string, 0, 4)
-					} else {
-						out.Entrypoint = []string{}
-					}
-				} else {
-					out.Entrypoint = (out.Entrypoint)[:0]
-				}
-				for !in.IsDelim(']') {
-					var v51 string
-					v51 = string(in.String())
-					out.Entrypoint = append(out.Entrypoint, v51)
-					in.WantComma()
+			out.RawByte(']')
+		}
+	}
+	if len(in.Options) != 0 {
+		const prefix string = ",\"options\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v59, v60 := range in.Options {
+				if v59 > 0 {
+					out.RawByte(',')
 				}
-				in.Delim(']')
+				out.String(string(v60))
 			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+func easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComContainernetworkingCniPkgTypesCurrent1(in *jlexer.Lexer, out *current.Interface) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "name":
+			out.Name = string(in.String())
+		case "mac":
+			out.Mac = string(in.String())
+		case "sandbox":
+			out.Sandbox = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComContainernetworkingCniPkgTypesCurrent1(out *jwriter.Writer, in current.Interface) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"name\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Name))
+	}
+	if in.Mac != "" {
+		const prefix string = ",\"mac\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Mac))
+	}
+	if in.Sandbox != "" {
+		const prefix string = ",\"sandbox\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Sandbox))
+	}
+	out.RawByte('}')
+}
+func easyjson1dbef17bDecodeGithubComContainersLibpodLibpod6(in *jlexer.Lexer, out *ExecSession) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = string(in.String())
 		case "command":
 			if in.IsNull() {
 				in.Skip()
@@ -1612,251 +2280,1673 @@ func easyjson1dbef17bDecodeGithubComContainersLibpodLibpod2(in *jlexer.Lexer, ou
 					out.Command = (out.Command)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v52 string
-					v52 = string(in.String())
-					out.Command = append(out.Command, v52)
+					var v61 string
+					v61 = string(in.String())
+					out.Command = append(out.Command, v61)
 					in.WantComma()
 				}
 				in.Delim(']')
 			}
-		case "stdin":
-			out.Stdin = bool(in.Bool())
-		case "labels":
-			if in.IsNull() {
-				in.Skip()
-			} else {
-				in.Delim('{')
-				if !in.IsDelim('}') {
-					out.Labels = make(map[string]string)
-				} else {
-					out.Labels = nil
+		case "pid":
+			out.PID = int(in.Int())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson1dbef17bEncodeGithubComContainersLibpodLibpod6(out *jwriter.Writer, in ExecSession) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.ID))
+	}
+	{
+		const prefix string = ",\"command\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		if in.Command == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v62, v63 := range in.Command {
+				if v62 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v63))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"pid\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.PID))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ExecSession) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson1dbef17bEncodeGithubComContainersLibpodLibpod6(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ExecSession) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson1dbef17bEncodeGithubComContainersLibpodLibpod6(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ExecSession) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson1dbef17bDecodeGithubComContainersLibpodLibpod6(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ExecSession) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson1dbef17bDecodeGithubComContainersLibpodLibpod6(l, v)
+}
+func easyjson1dbef17bDecodeGithubComContainersLibpodLibpod7(in *jlexer.Lexer, out *ContainerConfig) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "spec":
+			if in.IsNull() {
+				in.Skip()
+				out.Spec = nil
+			} else {
+				if out.Spec == nil {
+					out.Spec = new(specs_go.Spec)
+				}
+				easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo1(in, &*out.Spec)
+			}
+		case "id":
+			out.ID = string(in.String())
+		case "name":
+			out.Name = string(in.String())
+		case "pod":
+			out.Pod = string(in.String())
+		case "namespace":
+			out.Namespace = string(in.String())
+		case "idMappingsOptions":
+			easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComContainersStorage(in, &out.IDMappings)
+		case "rootfsImageID":
+			out.RootfsImageID = string(in.String())
+		case "rootfsImageName":
+			out.RootfsImageName = string(in.String())
+		case "rootfs":
+			out.Rootfs = string(in.String())
+		case "storageDriver":
+			out.StorageDriver = string(in.String())
+		case "imageVolumes":
+			out.ImageVolumes = bool(in.Bool())
+		case "ShmDir":
+			out.ShmDir = string(in.String())
+		case "shmSize":
+			out.ShmSize = int64(in.Int64())
+		case "shmMode":
+			out.ShmMode = os.FileMode(in.Uint32())
+		case "shmHost":
+			out.UseHostShm = bool(in.Bool())
+		case "readOnlyRootfs":
+			out.ReadOnlyRootfs = bool(in.Bool())
+		case "runTmpfs":
+			out.RunTmpfs = bool(in.Bool())
+		case "runTmpfsSize":
+			out.RunTmpfsSize = int64(in.Int64())
+		case "runTmpfsMode":
+			out.RunTmpfsMode = uint32(in.Uint32())
+		case "staticDir":
+			out.StaticDir = string(in.String())
+		case "mounts":
+			if in.IsNull() {
+				in.Skip()
+				out.Mounts = nil
+			} else {
+				in.Delim('[')
+				if out.Mounts == nil {
+					if !in.IsDelim(']') {
+						out.Mounts = make([]string, 0, 4)
+					} else {
+						out.Mounts = []string{}
+					}
+				} else {
+					out.Mounts = (out.Mounts)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v64 string
+					v64 = string(in.String())
+					out.Mounts = append(out.Mounts, v64)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "storageMounts":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				if !in.IsDelim('}') {
+					out.StorageMounts = make(map[string]StorageMount)
+				} else {
+					out.StorageMounts = nil
+				}
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v65 StorageMount
+					easyjson1dbef17bDecodeGithubComContainersLibpodLibpod8(in, &v65)
+					(out.StorageMounts)[key] = v65
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
+		case "rootfsMounts":
+			if in.IsNull() {
+				in.Skip()
+				out.RootfsMounts = nil
+			} else {
+				in.Delim('[')
+				if out.RootfsMounts == nil {
+					if !in.IsDelim(']') {
+						out.RootfsMounts = make([]RootfsMount, 0, 1)
+					} else {
+						out.RootfsMounts = []RootfsMount{}
+					}
+				} else {
+					out.RootfsMounts = (out.RootfsMounts)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v66 RootfsMount
+					easyjson1dbef17bDecodeGithubComContainersLibpodLibpod9(in, &v66)
+					out.RootfsMounts = append(out.RootfsMounts, v66)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "namedVolumes":
+			if in.IsNull() {
+				in.Skip()
+				out.NamedVolumes = nil
+			} else {
+				in.Delim('[')
+				if out.NamedVolumes == nil {
+					if !in.IsDelim(']') {
+						out.NamedVolumes = make([]*ContainerNamedVolume, 0, 8)
+					} else {
+						out.NamedVolumes = []*ContainerNamedVolume{}
+					}
+				} else {
+					out.NamedVolumes = (out.NamedVolumes)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v67 *ContainerNamedVolume
+					if in.IsNull() {
+						in.Skip()
+						v67 = nil
+					} else {
+						if v67 == nil {
+							v67 = new(ContainerNamedVolume)
+						}
+						easyjson1dbef17bDecodeGithubComContainersLibpodLibpod10(in, &*v67)
+					}
+					out.NamedVolumes = append(out.NamedVolumes, v67)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "privileged":
+			out.Privileged = bool(in.Bool())
+		case "ProcessLabel":
+			out.ProcessLabel = string(in.String())
+		case "MountLabel":
+			out.MountLabel = string(in.String())
+		case "labelopts":
+			if in.IsNull() {
+				in.Skip()
+				out.LabelOpts = nil
+			} else {
+				in.Delim('[')
+				if out.LabelOpts == nil {
+					if !in.IsDelim(']') {
+						out.LabelOpts = make([]string, 0, 4)
+					} else {
+						out.LabelOpts = []string{}
+					}
+				} else {
+					out.LabelOpts = (out.LabelOpts)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v68 string
+					v68 = string(in.String())
+					out.LabelOpts = append(out.LabelOpts, v68)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "user":
+			out.User = string(in.String())
+		case "groups":
+			if in.IsNull() {
+				in.Skip()
+				out.Groups = nil
+			} else {
+				in.Delim('[')
+				if out.Groups == nil {
+					if !in.IsDelim(']') {
+						out.Groups = make([]string, 0, 4)
+					} else {
+						out.Groups = []string{}
+					}
+				} else {
+					out.Groups = (out.Groups)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v69 string
+					v69 = string(in.String())
+					out.Groups = append(out.Groups, v69)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "ipcNsCtr":
+			out.IPCNsCtr = string(in.String())
+		case "mountNsCtr":
+			out.MountNsCtr = string(in.String())
+		case "netNsCtr":
+			out.NetNsCtr = string(in.String())
+		case "pidNsCtr":
+			out.PIDNsCtr = string(in.String())
+		case "userNsCtr":
+			out.UserNsCtr = string(in.String())
+		case "utsNsCtr":
+			out.UTSNsCtr = string(in.String())
+		case "cgroupNsCtr":
+			out.CgroupNsCtr = string(in.String())
+		case "Dependencies":
+			if in.IsNull() {
+				in.Skip()
+				out.Dependencies = nil
+			} else {
+				in.Delim('[')
+				if out.Dependencies == nil {
+					if !in.IsDelim(']') {
+						out.Dependencies = make([]string, 0, 4)
+					} else {
+						out.Dependencies = []string{}
+					}
+				} else {
+					out.Dependencies = (out.Dependencies)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v70 string
+					v70 = string(in.String())
+					out.Dependencies = append(out.Dependencies, v70)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "createNetNS":
+			out.CreateNetNS = bool(in.Bool())
+		case "staticIP":
+			if data := in.UnsafeBytes(); in.Ok() {
+				in.AddError((out.StaticIP).UnmarshalText(data))
+			}
+		case "portMappings":
+			if in.IsNull() {
+				in.Skip()
+				out.PortMappings = nil
+			} else {
+				in.Delim('[')
+				if out.PortMappings == nil {
+					if !in.IsDelim(']') {
+						out.PortMappings = make([]ocicni.PortMapping, 0, 1)
+					} else {
+						out.PortMappings = []ocicni.PortMapping{}
+					}
+				} else {
+					out.PortMappings = (out.PortMappings)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v71 ocicni.PortMapping
+					easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComCriOOcicniPkgOcicni(in, &v71)
+					out.PortMappings = append(out.PortMappings, v71)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "dnsServer":
+			if in.IsNull() {
+				in.Skip()
+				out.DNSServer = nil
+			} else {
+				in.Delim('[')
+				if out.DNSServer == nil {
+					if !in.IsDelim(']') {
+						out.DNSServer = make([]net.IP, 0, 2)
+					} else {
+						out.DNSServer = []net.IP{}
+					}
+				} else {
+					out.DNSServer = (out.DNSServer)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v72 net.IP
+					if data := in.UnsafeBytes(); in.Ok() {
+						in.AddError((v72).UnmarshalText(data))
+					}
+					out.DNSServer = append(out.DNSServer, v72)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "dnsSearch":
+			if in.IsNull() {
+				in.Skip()
+				out.DNSSearch = nil
+			} else {
+				in.Delim('[')
+				if out.DNSSearch == nil {
+					if !in.IsDelim(']') {
+						out.DNSSearch = make([]string, 0, 4)
+					} else {
+						out.DNSSearch = []string{}
+					}
+				} else {
+					out.DNSSearch = (out.DNSSearch)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v73 string
+					v73 = string(in.String())
+					out.DNSSearch = append(out.DNSSearch, v73)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "dnsOption":
+			if in.IsNull() {
+				in.Skip()
+				out.DNSOption = nil
+			} else {
+				in.Delim('[')
+				if out.DNSOption == nil {
+					if !in.IsDelim(']') {
+						out.DNSOption = make([]string, 0, 4)
+					} else {
+						out.DNSOption = []string{}
+					}
+				} else {
+					out.DNSOption = (out.DNSOption)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v74 string
+					v74 = string(in.String())
+					out.DNSOption = append(out.DNSOption, v74)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "dnsCache":
+			out.DNSCache = bool(in.Bool())
+		case "timezone":
+			out.Timezone = string(in.String())
+		case "hostsAdd":
+			if in.IsNull() {
+				in.Skip()
+				out.HostAdd = nil
+			} else {
+				in.Delim('[')
+				if out.HostAdd == nil {
+					if !in.IsDelim(']') {
+						out.HostAdd = make([]string, 0, 4)
+					} else {
+						out.HostAdd = []string{}
+					}
+				} else {
+					out.HostAdd = (out.HostAdd)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v75 string
+					v75 = string(in.String())
+					out.HostAdd = append(out.HostAdd, v75)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "networks":
+			if in.IsNull() {
+				in.Skip()
+				out.Networks = nil
+			} else {
+				in.Delim('[')
+				if out.Networks == nil {
+					if !in.IsDelim(']') {
+						out.Networks = make([]string, 0, 4)
+					} else {
+						out.Networks = []string{}
+					}
+				} else {
+					out.Networks = (out.Networks)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v76 string
+					v76 = string(in.String())
+					out.Networks = append(out.Networks, v76)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "userVolumes":
+			if in.IsNull() {
+				in.Skip()
+				out.UserVolumes = nil
+			} else {
+				in.Delim('[')
+				if out.UserVolumes == nil {
+					if !in.IsDelim(']') {
+						out.UserVolumes = make([]string, 0, 4)
+					} else {
+						out.UserVolumes = []string{}
+					}
+				} else {
+					out.UserVolumes = (out.UserVolumes)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v77 string
+					v77 = string(in.String())
+					out.UserVolumes = append(out.UserVolumes, v77)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "entrypoint":
+			if in.IsNull() {
+				in.Skip()
+				out.Entrypoint = nil
+			} else {
+				in.Delim('[')
+				if out.Entrypoint == nil {
+					if !in.IsDelim(']') {
+						out.Entrypoint = make([]string, 0, 4)
+					} else {
+						out.Entrypoint = []string{}
+					}
+				} else {
+					out.Entrypoint = (out.Entrypoint)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v78 string
+					v78 = string(in.String())
+					out.Entrypoint = append(out.Entrypoint, v78)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "command":
+			if in.IsNull() {
+				in.Skip()
+				out.Command = nil
+			} else {
+				in.Delim('[')
+				if out.Command == nil {
+					if !in.IsDelim(']') {
+						out.Command = make([]string, 0, 4)
+					} else {
+						out.Command = []string{}
+					}
+				} else {
+					out.Command = (out.Command)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v79 string
+					v79 = string(in.String())
+					out.Command = append(out.Command, v79)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "stdin":
+			out.Stdin = bool(in.Bool())
+		case "labels":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				if !in.IsDelim('}') {
+					out.Labels = make(map[string]string)
+				} else {
+					out.Labels = nil
+				}
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v80 string
+					v80 = string(in.String())
+					(out.Labels)[key] = v80
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
+		case "stopSignal":
+			out.StopSignal = uint(in.Uint())
+		case "stopTimeout":
+			out.StopTimeout = uint(in.Uint())
+		case "startupTimeout":
+			out.StartupTimeout = uint(in.Uint())
+		case "recordStartupTimings":
+			out.RecordStartupTimings = bool(in.Bool())
+		case "restartBackoff":
+			if in.IsNull() {
+				in.Skip()
+				out.RestartBackoff = nil
+			} else {
+				if out.RestartBackoff == nil {
+					out.RestartBackoff = new(RestartBackoff)
+				}
+				easyjson1dbef17bDecodeGithubComContainersLibpodLibpod11(in, &*out.RestartBackoff)
+			}
+		case "healthcheck":
+			if in.IsNull() {
+				in.Skip()
+				out.HealthCheck = nil
+			} else {
+				if out.HealthCheck == nil {
+					out.HealthCheck = new(HealthConfig)
+				}
+				easyjson1dbef17bDecodeGithubComContainersLibpodLibpod12(in, &*out.HealthCheck)
+			}
+		case "restartPolicy":
+			out.RestartPolicy = string(in.String())
+		case "restartRetries":
+			out.RestartRetries = int(in.Int())
+		case "startPaused":
+			out.StartPaused = bool(in.Bool())
+		case "autoRemove":
+			out.AutoRemove = bool(in.Bool())
+		case "coreDumpMaxSize":
+			out.CoreDumpMaxSize = int64(in.Int64())
+		case "fsAudit":
+			out.FSAudit = bool(in.Bool())
+		case "envSecrets":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				if !in.IsDelim('}') {
+					out.EnvSecrets = make(map[string]string)
+				} else {
+					out.EnvSecrets = nil
+				}
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v81 string
+					v81 = string(in.String())
+					(out.EnvSecrets)[key] = v81
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
+		case "fsAuditMaxSize":
+			out.FSAuditMaxSize = int64(in.Int64())
+		case "fsAuditRate":
+			out.FSAuditRate = uint(in.Uint())
+		case "checkpointQuiesceCommand":
+			if in.IsNull() {
+				in.Skip()
+				out.CheckpointQuiesceCommand = nil
+			} else {
+				in.Delim('[')
+				if out.CheckpointQuiesceCommand == nil {
+					if !in.IsDelim(']') {
+						out.CheckpointQuiesceCommand = make([]string, 0, 4)
+					} else {
+						out.CheckpointQuiesceCommand = []string{}
+					}
+				} else {
+					out.CheckpointQuiesceCommand = (out.CheckpointQuiesceCommand)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v82 string
+					v82 = string(in.String())
+					out.CheckpointQuiesceCommand = append(out.CheckpointQuiesceCommand, v82)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "checkpointResumeCommand":
+			if in.IsNull() {
+				in.Skip()
+				out.CheckpointResumeCommand = nil
+			} else {
+				in.Delim('[')
+				if out.CheckpointResumeCommand == nil {
+					if !in.IsDelim(']') {
+						out.CheckpointResumeCommand = make([]string, 0, 4)
+					} else {
+						out.CheckpointResumeCommand = []string{}
+					}
+				} else {
+					out.CheckpointResumeCommand = (out.CheckpointResumeCommand)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v83 string
+					v83 = string(in.String())
+					out.CheckpointResumeCommand = append(out.CheckpointResumeCommand, v83)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "checkpointSyncFS":
+			out.CheckpointSyncFS = bool(in.Bool())
+		case "createdTime":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.CreatedTime).UnmarshalJSON(data))
+			}
+		case "cgroupParent":
+			out.CgroupParent = string(in.String())
+		case "cgroupMode":
+			out.CgroupMode = CgroupMode(in.String())
+		case "conmonCgroup":
+			out.ConmonCgroup = string(in.String())
+		case "logPath":
+			out.LogPath = string(in.String())
+		case "logFullPolicy":
+			out.LogFullPolicy = LogFullPolicy(in.String())
+		case "mountSourcePolicy":
+			out.MountSourcePolicy = MountSourcePolicy(in.String())
+		case "logDriver":
+			out.LogDriver = string(in.String())
+		case "logSizeMax":
+			out.LogSizeMax = int64(in.Int64())
+		case "logMaxFiles":
+			out.LogMaxFiles = int(in.Int())
+		case "splitLogStreams":
+			out.SplitLogStreams = bool(in.Bool())
+		case "logStreamMaxSize":
+			out.LogStreamMaxSize = int64(in.Int64())
+		case "logRateLimitLines":
+			out.LogRateLimitLines = int64(in.Int64())
+		case "logRateLimitBytes":
+			out.LogRateLimitBytes = int64(in.Int64())
+		case "machineIDMode":
+			out.MachineIDMode = MachineIDMode(in.String())
+		case "conmonPidFile":
+			out.ConmonPidFile = string(in.String())
+		case "postConfigureNetNS":
+			out.PostConfigureNetNS = bool(in.Bool())
+		case "exitCommand":
+			if in.IsNull() {
+				in.Skip()
+				out.ExitCommand = nil
+			} else {
+				in.Delim('[')
+				if out.ExitCommand == nil {
+					if !in.IsDelim(']') {
+						out.ExitCommand = make([]string, 0, 4)
+					} else {
+						out.ExitCommand = []string{}
+					}
+				} else {
+					out.ExitCommand = (out.ExitCommand)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v84 string
+					v84 = string(in.String())
+					out.ExitCommand = append(out.ExitCommand, v84)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "LocalVolumes":
+			if in.IsNull() {
+				in.Skip()
+				out.LocalVolumes = nil
+			} else {
+				in.Delim('[')
+				if out.LocalVolumes == nil {
+					if !in.IsDelim(']') {
+						out.LocalVolumes = make([]string, 0, 4)
+					} else {
+						out.LocalVolumes = []string{}
+					}
+				} else {
+					out.LocalVolumes = (out.LocalVolumes)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v85 string
+					v85 = string(in.String())
+					out.LocalVolumes = append(out.LocalVolumes, v85)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "pause":
+			out.IsInfra = bool(in.Bool())
+		case "systemd":
+			out.Systemd = bool(in.Bool())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson1dbef17bEncodeGithubComContainersLibpodLibpod7(out *jwriter.Writer, in ContainerConfig) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"spec\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		if in.Spec == nil {
+			out.RawString("null")
+		} else {
+			easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo1(out, *in.Spec)
+		}
+	}
+	{
+		const prefix string = ",\"id\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.ID))
+	}
+	{
+		const prefix string = ",\"name\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Name))
+	}
+	if in.Pod != "" {
+		const prefix string = ",\"pod\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Pod))
+	}
+	if in.Namespace != "" {
+		const prefix string = ",\"namespace\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Namespace))
+	}
+	if true {
+		const prefix string = ",\"idMappingsOptions\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComContainersStorage(out, in.IDMappings)
+	}
+	if in.RootfsImageID != "" {
+		const prefix string = ",\"rootfsImageID\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.RootfsImageID))
+	}
+	if in.RootfsImageName != "" {
+		const prefix string = ",\"rootfsImageName\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.RootfsImageName))
+	}
+	if in.Rootfs != "" {
+		const prefix string = ",\"rootfs\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Rootfs))
+	}
+	if in.StorageDriver != "" {
+		const prefix string = ",\"storageDriver\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.StorageDriver))
+	}
+	{
+		const prefix string = ",\"imageVolumes\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Bool(bool(in.ImageVolumes))
+	}
+	if in.ShmDir != "" {
+		const prefix string = ",\"ShmDir\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.ShmDir))
+	}
+	{
+		const prefix string = ",\"shmSize\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.ShmSize))
+	}
+	if in.ShmMode != 0 {
+		const prefix string = ",\"shmMode\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Uint32(uint32(in.ShmMode))
+	}
+	if in.UseHostShm {
+		const prefix string = ",\"shmHost\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Bool(bool(in.UseHostShm))
+	}
+	if in.ReadOnlyRootfs {
+		const prefix string = ",\"readOnlyRootfs\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Bool(bool(in.ReadOnlyRootfs))
+	}
+	if in.RunTmpfs {
+		const prefix string = ",\"runTmpfs\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Bool(bool(in.RunTmpfs))
+	}
+	if in.RunTmpfsSize != 0 {
+		const prefix string = ",\"runTmpfsSize\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.RunTmpfsSize))
+	}
+	if in.RunTmpfsMode != 0 {
+		const prefix string = ",\"runTmpfsMode\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Uint32(uint32(in.RunTmpfsMode))
+	}
+	{
+		const prefix string = ",\"staticDir\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.StaticDir))
+	}
+	if len(in.Mounts) != 0 {
+		const prefix string = ",\"mounts\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v86, v87 := range in.Mounts {
+				if v86 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v87))
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.StorageMounts) != 0 {
+		const prefix string = ",\"storageMounts\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('{')
+			v88First := true
+			for v88Name, v88Value := range in.StorageMounts {
+				if v88First {
+					v88First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v88Name))
+				out.RawByte(':')
+				easyjson1dbef17bEncodeGithubComContainersLibpodLibpod8(out, v88Value)
+			}
+			out.RawByte('}')
+		}
+	}
+	if len(in.RootfsMounts) != 0 {
+		const prefix string = ",\"rootfsMounts\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v89, v90 := range in.RootfsMounts {
+				if v89 > 0 {
+					out.RawByte(',')
+				}
+				easyjson1dbef17bEncodeGithubComContainersLibpodLibpod9(out, v90)
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.NamedVolumes) != 0 {
+		const prefix string = ",\"namedVolumes\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v91, v92 := range in.NamedVolumes {
+				if v91 > 0 {
+					out.RawByte(',')
+				}
+				if v92 == nil {
+					out.RawString("null")
+				} else {
+					easyjson1dbef17bEncodeGithubComContainersLibpodLibpod10(out, *v92)
+				}
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"privileged\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Bool(bool(in.Privileged))
+	}
+	if in.ProcessLabel != "" {
+		const prefix string = ",\"ProcessLabel\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.ProcessLabel))
+	}
+	if in.MountLabel != "" {
+		const prefix string = ",\"MountLabel\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.MountLabel))
+	}
+	if len(in.LabelOpts) != 0 {
+		const prefix string = ",\"labelopts\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v93, v94 := range in.LabelOpts {
+				if v93 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v94))
+			}
+			out.RawByte(']')
+		}
+	}
+	if in.User != "" {
+		const prefix string = ",\"user\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.User))
+	}
+	if len(in.Groups) != 0 {
+		const prefix string = ",\"groups\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v95, v96 := range in.Groups {
+				if v95 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v96))
+			}
+			out.RawByte(']')
+		}
+	}
+	if in.IPCNsCtr != "" {
+		const prefix string = ",\"ipcNsCtr\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.IPCNsCtr))
+	}
+	if in.MountNsCtr != "" {
+		const prefix string = ",\"mountNsCtr\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.MountNsCtr))
+	}
+	if in.NetNsCtr != "" {
+		const prefix string = ",\"netNsCtr\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.NetNsCtr))
+	}
+	if in.PIDNsCtr != "" {
+		const prefix string = ",\"pidNsCtr\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.PIDNsCtr))
+	}
+	if in.UserNsCtr != "" {
+		const prefix string = ",\"userNsCtr\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.UserNsCtr))
+	}
+	if in.UTSNsCtr != "" {
+		const prefix string = ",\"utsNsCtr\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.UTSNsCtr))
+	}
+	if in.CgroupNsCtr != "" {
+		const prefix string = ",\"cgroupNsCtr\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.CgroupNsCtr))
+	}
+	{
+		const prefix string = ",\"Dependencies\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		if in.Dependencies == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v97, v98 := range in.Dependencies {
+				if v97 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v98))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"createNetNS\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Bool(bool(in.CreateNetNS))
+	}
+	{
+		const prefix string = ",\"staticIP\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.RawText((in.StaticIP).MarshalText())
+	}
+	if len(in.PortMappings) != 0 {
+		const prefix string = ",\"portMappings\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v99, v100 := range in.PortMappings {
+				if v99 > 0 {
+					out.RawByte(',')
+				}
+				easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComCriOOcicniPkgOcicni(out, v100)
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.DNSServer) != 0 {
+		const prefix string = ",\"dnsServer\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v101, v102 := range in.DNSServer {
+				if v101 > 0 {
+					out.RawByte(',')
+				}
+				out.RawText((v102).MarshalText())
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.DNSSearch) != 0 {
+		const prefix string = ",\"dnsSearch\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v103, v104 := range in.DNSSearch {
+				if v103 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v104))
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.DNSOption) != 0 {
+		const prefix string = ",\"dnsOption\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v105, v106 := range in.DNSOption {
+				if v105 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v106))
+			}
+			out.RawByte(']')
+		}
+	}
+	if in.DNSCache {
+		const prefix string = ",\"dnsCache\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Bool(bool(in.DNSCache))
+	}
+	if in.Timezone != "" {
+		const prefix string = ",\"timezone\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Timezone))
+	}
+	if len(in.HostAdd) != 0 {
+		const prefix string = ",\"hostsAdd\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v107, v108 := range in.HostAdd {
+				if v107 > 0 {
+					out.RawByte(',')
 				}
-				for !in.IsDelim('}') {
-					key := string(in.String())
-					in.WantColon()
-					var v53 string
-					v53 = string(in.String())
-					(out.Labels)[key] = v53
-					in.WantComma()
+				out.String(string(v108))
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.Networks) != 0 {
+		const prefix string = ",\"networks\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v109, v110 := range in.Networks {
+				if v109 > 0 {
+					out.RawByte(',')
 				}
-				in.Delim('}')
+				out.String(string(v110))
 			}
-		case "stopSignal":
-			out.StopSignal = uint(in.Uint())
-		case "stopTimeout":
-			out.StopTimeout = uint(in.Uint())
-		case "createdTime":
-			if data := in.Raw(); in.Ok() {
-				in.AddError((out.CreatedTime).UnmarshalJSON(data))
+			out.RawByte(']')
+		}
+	}
+	if len(in.UserVolumes) != 0 {
+		const prefix string = ",\"userVolumes\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v111, v112 := range in.UserVolumes {
+				if v111 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v112))
 			}
-		case "cgroupParent":
-			out.CgroupParent = string(in.String())
-		case "logPath":
-			out.LogPath = string(in.String())
-		case "conmonPidFile":
-			out.ConmonPidFile = string(in.String())
-		case "postConfigureNetNS":
-			out.PostConfigureNetNS = bool(in.Bool())
-		case "exitCommand":
-			if in.IsNull() {
-				in.Skip()
-				out.ExitCommand = nil
-			} else {
-				in.Delim('[')
-				if out.ExitCommand == nil {
-					if !in.IsDelim(']') {
-						out.ExitCommand = make([]string, 0, 4)
-					} else {
-						out.ExitCommand = []string{}
-					}
-				} else {
-					out.ExitCommand = (out.ExitCommand)[:0]
+			out.RawByte(']')
+		}
+	}
+	if len(in.Entrypoint) != 0 {
+		const prefix string = ",\"entrypoint\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v113, v114 := range in.Entrypoint {
+				if v113 > 0 {
+					out.RawByte(',')
 				}
-				for !in.IsDelim(']') {
-					var v54 string
-					v54 = string(in.String())
-					out.ExitCommand = append(out.ExitCommand, v54)
-					in.WantComma()
+				out.String(string(v114))
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.Command) != 0 {
+		const prefix string = ",\"command\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v115, v116 := range in.Command {
+				if v115 > 0 {
+					out.RawByte(',')
 				}
-				in.Delim(']')
+				out.String(string(v116))
 			}
-		case "LocalVolumes":
-			if in.IsNull() {
-				in.Skip()
-				out.LocalVolumes = nil
-			} else {
-				in.Delim('[')
-				if out.LocalVolumes == nil {
-					if !in.IsDelim(']') {
-						out.LocalVolumes = make([]string, 0, 4)
-					} else {
-						out.LocalVolumes = []string{}
-					}
+			out.RawByte(']')
+		}
+	}
+	if in.Stdin {
+		const prefix string = ",\"stdin\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Bool(bool(in.Stdin))
+	}
+	if len(in.Labels) != 0 {
+		const prefix string = ",\"labels\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('{')
+			v117First := true
+			for v117Name, v117Value := range in.Labels {
+				if v117First {
+					v117First = false
 				} else {
-					out.LocalVolumes = (out.LocalVolumes)[:0]
-				}
-				for !in.IsDelim(']') {
-					var v55 string
-					v55 = string(in.String())
-					out.LocalVolumes = append(out.LocalVolumes, v55)
-					in.WantComma()
+					out.RawByte(',')
 				}
-				in.Delim(']')
+				out.String(string(v117Name))
+				out.RawByte(':')
+				out.String(string(v117Value))
 			}
-		case "pause":
-			out.IsInfra = bool(in.Bool())
-		case "systemd":
-			out.Systemd = bool(in.Bool())
-		default:
-			in.SkipRecursive()
+			out.RawByte('}')
 		}
-		in.WantComma()
 	}
-	in.Delim('}')
-	if isTopLevel {
-		in.Consumed()
+	if in.StopSignal != 0 {
+		const prefix string = ",\"stopSignal\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Uint(uint(in.StopSignal))
 	}
-}
-func easyjson1dbef17bEncodeGithubComContainersLibpodLibpod2(out *jwriter.Writer, in ContainerConfig) {
-	out.RawByte('{')
-	first := true
-	_ = first
-	{
-		const prefix string = ",\"spec\":"
+	if in.StopTimeout != 0 {
+		const prefix string = ",\"stopTimeout\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		if in.Spec == nil {
-			out.RawString("null")
+		out.Uint(uint(in.StopTimeout))
+	}
+	if in.StartupTimeout != 0 {
+		const prefix string = ",\"startupTimeout\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
 		} else {
-			easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo1(out, *in.Spec)
+			out.RawString(prefix)
 		}
+		out.Uint(uint(in.StartupTimeout))
 	}
-	{
-		const prefix string = ",\"id\":"
+	if in.RecordStartupTimings {
+		const prefix string = ",\"recordStartupTimings\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		out.String(string(in.ID))
+		out.Bool(bool(in.RecordStartupTimings))
 	}
-	{
-		const prefix string = ",\"name\":"
+	if in.RestartBackoff != nil {
+		const prefix string = ",\"restartBackoff\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		out.String(string(in.Name))
+		easyjson1dbef17bEncodeGithubComContainersLibpodLibpod11(out, *in.RestartBackoff)
 	}
-	if in.Pod != "" {
-		const prefix string = ",\"pod\":"
+	if in.HealthCheck != nil {
+		const prefix string = ",\"healthcheck\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		out.String(string(in.Pod))
+		easyjson1dbef17bEncodeGithubComContainersLibpodLibpod12(out, *in.HealthCheck)
 	}
-	if in.Namespace != "" {
-		const prefix string = ",\"namespace\":"
+	if in.RestartPolicy != "" {
+		const prefix string = ",\"restartPolicy\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		out.String(string(in.Namespace))
+		out.String(string(in.RestartPolicy))
 	}
-	if true {
-		const prefix string = ",\"idMappingsOptions\":"
+	if in.RestartRetries != 0 {
+		const prefix string = ",\"restartRetries\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComContainersStorage(out, in.IDMappings)
+		out.Int(int(in.RestartRetries))
 	}
-	if in.RootfsImageID != "" {
-		const prefix string = ",\"rootfsImageID\":"
+	if in.StartPaused {
+		const prefix string = ",\"startPaused\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		out.String(string(in.RootfsImageID))
+		out.Bool(bool(in.StartPaused))
 	}
-	if in.RootfsImageName != "" {
-		const prefix string = ",\"rootfsImageName\":"
+	if in.AutoRemove {
+		const prefix string = ",\"autoRemove\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		out.String(string(in.RootfsImageName))
+		out.Bool(bool(in.AutoRemove))
 	}
-	if in.Rootfs != "" {
-		const prefix string = ",\"rootfs\":"
+	if in.CoreDumpMaxSize != 0 {
+		const prefix string = ",\"coreDumpMaxSize\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		out.String(string(in.Rootfs))
+		out.Int64(int64(in.CoreDumpMaxSize))
 	}
-	{
-		const prefix string = ",\"imageVolumes\":"
+	if in.FSAudit {
+		const prefix string = ",\"fsAudit\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		out.Bool(bool(in.ImageVolumes))
+		out.Bool(bool(in.FSAudit))
 	}
-	if in.ShmDir != "" {
-		const prefix string = ",\"ShmDir\":"
+	if len(in.EnvSecrets) != 0 {
+		const prefix string = ",\"envSecrets\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		out.String(string(in.ShmDir))
+		{
+			out.RawByte('{')
+			v118First := true
+			for v118Name, v118Value := range in.EnvSecrets {
+				if v118First {
+					v118First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v118Name))
+				out.RawByte(':')
+				out.String(string(v118Value))
+			}
+			out.RawByte('}')
+		}
 	}
-	{
-		const prefix string = ",\"shmSize\":"
+	if in.FSAuditMaxSize != 0 {
+		const prefix string = ",\"fsAuditMaxSize\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		out.Int64(int64(in.ShmSize))
+		out.Int64(int64(in.FSAuditMaxSize))
 	}
-	{
-		const prefix string = ",\"staticDir\":"
+	if in.FSAuditRate != 0 {
+		const prefix string = ",\"fsAuditRate\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		out.String(string(in.StaticDir))
+		out.Uint(uint(in.FSAuditRate))
 	}
-	if len(in.Mounts) != 0 {
-		const prefix string = ",\"mounts\":"
+	if len(in.CheckpointQuiesceCommand) != 0 {
+		const prefix string = ",\"checkpointQuiesceCommand\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
@@ -1865,244 +3955,216 @@ func easyjson1dbef17bEncodeGithubComContainersLibpodLibpod2(out *jwriter.Writer,
 		}
 		{
 			out.RawByte('[')
-			for v56, v57 := range in.Mounts {
-				if v56 > 0 {
+			for v119, v120 := range in.CheckpointQuiesceCommand {
+				if v119 > 0 {
 					out.RawByte(',')
 				}
-				out.String(string(v57))
+				out.String(string(v120))
 			}
 			out.RawByte(']')
 		}
 	}
-	{
-		const prefix string = ",\"privileged\":"
+	if len(in.CheckpointResumeCommand) != 0 {
+		const prefix string = ",\"checkpointResumeCommand\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		out.Bool(bool(in.Privileged))
+		{
+			out.RawByte('[')
+			for v121, v122 := range in.CheckpointResumeCommand {
+				if v121 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v122))
+			}
+			out.RawByte(']')
+		}
 	}
-	if in.ProcessLabel != "" {
-		const prefix string = ",\"ProcessLabel\":"
+	if in.CheckpointSyncFS {
+		const prefix string = ",\"checkpointSyncFS\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Bool(bool(in.CheckpointSyncFS))
+	}
+	{
+		const prefix string = ",\"createdTime\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		out.String(string(in.ProcessLabel))
+		out.Raw((in.CreatedTime).MarshalJSON())
 	}
-	if in.MountLabel != "" {
-		const prefix string = ",\"MountLabel\":"
+	{
+		const prefix string = ",\"cgroupParent\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		out.String(string(in.MountLabel))
+		out.String(string(in.CgroupParent))
 	}
-	if len(in.LabelOpts) != 0 {
-		const prefix string = ",\"labelopts\":"
+	if in.CgroupMode != "" {
+		const prefix string = ",\"cgroupMode\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		{
-			out.RawByte('[')
-			for v58, v59 := range in.LabelOpts {
-				if v58 > 0 {
-					out.RawByte(',')
-				}
-				out.String(string(v59))
-			}
-			out.RawByte(']')
-		}
+		out.String(string(in.CgroupMode))
 	}
-	if in.User != "" {
-		const prefix string = ",\"user\":"
+	if in.ConmonCgroup != "" {
+		const prefix string = ",\"conmonCgroup\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		out.String(string(in.User))
+		out.String(string(in.ConmonCgroup))
 	}
-	if len(in.Groups) != 0 {
-		const prefix string = ",\"groups\":"
+	{
+		const prefix string = ",\"logPath\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		{
-			out.RawByte('[')
-			for v60, v61 := range in.Groups {
-				if v60 > 0 {
-					out.RawByte(',')
-				}
-				out.String(string(v61))
-			}
-			out.RawByte(']')
-		}
+		out.String(string(in.LogPath))
 	}
-	if in.IPCNsCtr != "" {
-		const prefix string = ",\"ipcNsCtr\":"
+	if in.LogFullPolicy != "" {
+		const prefix string = ",\"logFullPolicy\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		out.String(string(in.IPCNsCtr))
+		out.String(string(in.LogFullPolicy))
 	}
-	if in.MountNsCtr != "" {
-		const prefix string = ",\"mountNsCtr\":"
+	if in.MountSourcePolicy != "" {
+		const prefix string = ",\"mountSourcePolicy\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		out.String(string(in.MountNsCtr))
+		out.String(string(in.MountSourcePolicy))
 	}
-	if in.NetNsCtr != "" {
-		const prefix string = ",\"netNsCtr\":"
+	if in.LogDriver != "" {
+		const prefix string = ",\"logDriver\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		out.String(string(in.NetNsCtr))
+		out.String(string(in.LogDriver))
 	}
-	if in.PIDNsCtr != "" {
-		const prefix string = ",\"pidNsCtr\":"
+	if in.LogSizeMax != 0 {
+		const prefix string = ",\"logSizeMax\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		out.String(string(in.PIDNsCtr))
+		out.Int64(int64(in.LogSizeMax))
 	}
-	if in.UserNsCtr != "" {
-		const prefix string = ",\"userNsCtr\":"
+	if in.LogMaxFiles != 0 {
+		const prefix string = ",\"logMaxFiles\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		out.String(string(in.UserNsCtr))
+		out.Int(int(in.LogMaxFiles))
 	}
-	if in.UTSNsCtr != "" {
-		const prefix string = ",\"utsNsCtr\":"
+	if in.SplitLogStreams {
+		const prefix string = ",\"splitLogStreams\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		out.String(string(in.UTSNsCtr))
+		out.Bool(bool(in.SplitLogStreams))
 	}
-	if in.CgroupNsCtr != "" {
-		const prefix string = ",\"cgroupNsCtr\":"
+	if in.LogStreamMaxSize != 0 {
+		const prefix string = ",\"logStreamMaxSize\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		out.String(string(in.CgroupNsCtr))
+		out.Int64(int64(in.LogStreamMaxSize))
 	}
-	{
-		const prefix string = ",\"Dependencies\":"
+	if in.LogRateLimitLines != 0 {
+		const prefix string = ",\"logRateLimitLines\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		if in.Dependencies == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
-			out.RawString("null")
-		} else {
-			out.RawByte('[')
-			for v62, v63 := range in.Dependencies {
-				if v62 > 0 {
-					out.RawByte(',')
-				}
-				out.String(string(v63))
-			}
-			out.RawByte(']')
-		}
+		out.Int64(int64(in.LogRateLimitLines))
 	}
-	{
-		const prefix string = ",\"createNetNS\":"
+	if in.LogRateLimitBytes != 0 {
+		const prefix string = ",\"logRateLimitBytes\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		out.Bool(bool(in.CreateNetNS))
+		out.Int64(int64(in.LogRateLimitBytes))
 	}
-	{
-		const prefix string = ",\"staticIP\":"
+	if in.MachineIDMode != "" {
+		const prefix string = ",\"machineIDMode\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		out.RawText((in.StaticIP).MarshalText())
+		out.String(string(in.MachineIDMode))
 	}
-	if len(in.PortMappings) != 0 {
-		const prefix string = ",\"portMappings\":"
+	if in.ConmonPidFile != "" {
+		const prefix string = ",\"conmonPidFile\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		{
-			out.RawByte('[')
-			for v64, v65 := range in.PortMappings {
-				if v64 > 0 {
-					out.RawByte(',')
-				}
-				easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComCriOOcicniPkgOcicni(out, v65)
-			}
-			out.RawByte(']')
-		}
+		out.String(string(in.ConmonPidFile))
 	}
-	if len(in.DNSServer) != 0 {
-		const prefix string = ",\"dnsServer\":"
+	{
+		const prefix string = ",\"postConfigureNetNS\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		{
-			out.RawByte('[')
-			for v66, v67 := range in.DNSServer {
-				if v66 > 0 {
-					out.RawByte(',')
-				}
-				out.RawText((v67).MarshalText())
-			}
-			out.RawByte(']')
-		}
+		out.Bool(bool(in.PostConfigureNetNS))
 	}
-	if len(in.DNSSearch) != 0 {
-		const prefix string = ",\"dnsSearch\":"
+	if len(in.ExitCommand) != 0 {
+		const prefix string = ",\"exitCommand\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
@@ -2111,235 +4173,374 @@ func easyjson1dbef17bEncodeGithubComContainersLibpodLibpod2(out *jwriter.Writer,
 		}
 		{
 			out.RawByte('[')
-			for v68, v69 := range in.DNSSearch {
-				if v68 > 0 {
+			for v123, v124 := range in.ExitCommand {
+				if v123 > 0 {
 					out.RawByte(',')
 				}
-				out.String(string(v69))
+				out.String(string(v124))
 			}
 			out.RawByte(']')
 		}
 	}
-	if len(in.DNSOption) != 0 {
-		const prefix string = ",\"dnsOption\":"
+	{
+		const prefix string = ",\"LocalVolumes\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		{
+		if in.LocalVolumes == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
 			out.RawByte('[')
-			for v70, v71 := range in.DNSOption {
-				if v70 > 0 {
+			for v125, v126 := range in.LocalVolumes {
+				if v125 > 0 {
 					out.RawByte(',')
 				}
-				out.String(string(v71))
+				out.String(string(v126))
 			}
 			out.RawByte(']')
 		}
 	}
-	if len(in.HostAdd) != 0 {
-		const prefix string = ",\"hostsAdd\":"
+	{
+		const prefix string = ",\"pause\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		{
-			out.RawByte('[')
-			for v72, v73 := range in.HostAdd {
-				if v72 > 0 {
-					out.RawByte(',')
-				}
-				out.String(string(v73))
-			}
-			out.RawByte(']')
-		}
+		out.Bool(bool(in.IsInfra))
 	}
-	if len(in.Networks) != 0 {
-		const prefix string = ",\"networks\":"
+	{
+		const prefix string = ",\"systemd\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		{
-			out.RawByte('[')
-			for v74, v75 := range in.Networks {
-				if v74 > 0 {
-					out.RawByte(',')
+		out.Bool(bool(in.Systemd))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ContainerConfig) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson1dbef17bEncodeGithubComContainersLibpodLibpod7(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ContainerConfig) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson1dbef17bEncodeGithubComContainersLibpodLibpod7(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ContainerConfig) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson1dbef17bDecodeGithubComContainersLibpodLibpod7(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ContainerConfig) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson1dbef17bDecodeGithubComContainersLibpodLibpod7(l, v)
+}
+func easyjson1dbef17bDecodeGithubComContainersLibpodLibpod12(in *jlexer.Lexer, out *HealthConfig) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "test":
+			if in.IsNull() {
+				in.Skip()
+				out.Test = nil
+			} else {
+				in.Delim('[')
+				if out.Test == nil {
+					if !in.IsDelim(']') {
+						out.Test = make([]string, 0, 4)
+					} else {
+						out.Test = []string{}
+					}
+				} else {
+					out.Test = (out.Test)[:0]
 				}
-				out.String(string(v75))
+				for !in.IsDelim(']') {
+					var v127 string
+					v127 = string(in.String())
+					out.Test = append(out.Test, v127)
+					in.WantComma()
+				}
+				in.Delim(']')
 			}
-			out.RawByte(']')
+		case "interval":
+			out.Interval = time.Duration(in.Int64())
+		case "timeout":
+			out.Timeout = time.Duration(in.Int64())
+		case "startPeriod":
+			out.StartPeriod = time.Duration(in.Int64())
+		case "retries":
+			out.Retries = int(in.Int())
+		default:
+			in.SkipRecursive()
 		}
+		in.WantComma()
 	}
-	if len(in.UserVolumes) != 0 {
-		const prefix string = ",\"userVolumes\":"
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson1dbef17bEncodeGithubComContainersLibpodLibpod12(out *jwriter.Writer, in HealthConfig) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"test\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		{
+		if in.Test == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
 			out.RawByte('[')
-			for v76, v77 := range in.UserVolumes {
-				if v76 > 0 {
+			for v128, v129 := range in.Test {
+				if v128 > 0 {
 					out.RawByte(',')
 				}
-				out.String(string(v77))
+				out.String(string(v129))
 			}
 			out.RawByte(']')
 		}
 	}
-	if len(in.Entrypoint) != 0 {
-		const prefix string = ",\"entrypoint\":"
+	if in.Interval != 0 {
+		const prefix string = ",\"interval\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		{
-			out.RawByte('[')
-			for v78, v79 := range in.Entrypoint {
-				if v78 > 0 {
-					out.RawByte(',')
-				}
-				out.String(string(v79))
-			}
-			out.RawByte(']')
-		}
+		out.Int64(int64(in.Interval))
 	}
-	if len(in.Command) != 0 {
-		const prefix string = ",\"command\":"
+	if in.Timeout != 0 {
+		const prefix string = ",\"timeout\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		{
-			out.RawByte('[')
-			for v80, v81 := range in.Command {
-				if v80 > 0 {
-					out.RawByte(',')
-				}
-				out.String(string(v81))
-			}
-			out.RawByte(']')
-		}
+		out.Int64(int64(in.Timeout))
 	}
-	if in.Stdin {
-		const prefix string = ",\"stdin\":"
+	if in.StartPeriod != 0 {
+		const prefix string = ",\"startPeriod\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		out.Bool(bool(in.Stdin))
+		out.Int64(int64(in.StartPeriod))
 	}
-	if len(in.Labels) != 0 {
-		const prefix string = ",\"labels\":"
+	if in.Retries != 0 {
+		const prefix string = ",\"retries\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		{
-			out.RawByte('{')
-			v82First := true
-			for v82Name, v82Value := range in.Labels {
-				if v82First {
-					v82First = false
-				} else {
-					out.RawByte(',')
-				}
-				out.String(string(v82Name))
-				out.RawByte(':')
-				out.String(string(v82Value))
-			}
-			out.RawByte('}')
+		out.Int(int(in.Retries))
+	}
+	out.RawByte('}')
+}
+func easyjson1dbef17bDecodeGithubComContainersLibpodLibpod11(in *jlexer.Lexer, out *RestartBackoff) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "baseDelay":
+			out.BaseDelay = time.Duration(in.Int64())
+		case "maxDelay":
+			out.MaxDelay = time.Duration(in.Int64())
+		case "multiplier":
+			out.Multiplier = float64(in.Float64())
+		case "jitter":
+			out.Jitter = float64(in.Float64())
+		default:
+			in.SkipRecursive()
 		}
+		in.WantComma()
 	}
-	if in.StopSignal != 0 {
-		const prefix string = ",\"stopSignal\":"
-		if first {
-			first = false
-			out.RawString(prefix[1:])
-		} else {
-			out.RawString(prefix)
-		}
-		out.Uint(uint(in.StopSignal))
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
 	}
-	if in.StopTimeout != 0 {
-		const prefix string = ",\"stopTimeout\":"
+}
+func easyjson1dbef17bEncodeGithubComContainersLibpodLibpod11(out *jwriter.Writer, in RestartBackoff) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"baseDelay\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		out.Uint(uint(in.StopTimeout))
+		out.Int64(int64(in.BaseDelay))
 	}
 	{
-		const prefix string = ",\"createdTime\":"
+		const prefix string = ",\"maxDelay\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		out.Raw((in.CreatedTime).MarshalJSON())
+		out.Int64(int64(in.MaxDelay))
 	}
 	{
-		const prefix string = ",\"cgroupParent\":"
+		const prefix string = ",\"multiplier\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		out.String(string(in.CgroupParent))
+		out.Float64(float64(in.Multiplier))
 	}
-	{
-		const prefix string = ",\"logPath\":"
+	if in.Jitter != 0 {
+		const prefix string = ",\"jitter\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		out.String(string(in.LogPath))
+		out.Float64(float64(in.Jitter))
 	}
-	if in.ConmonPidFile != "" {
-		const prefix string = ",\"conmonPidFile\":"
+	out.RawByte('}')
+}
+func easyjson1dbef17bDecodeGithubComContainersLibpodLibpod10(in *jlexer.Lexer, out *ContainerNamedVolume) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "volumeName":
+			out.Name = string(in.String())
+		case "dest":
+			out.Dest = string(in.String())
+		case "options":
+			if in.IsNull() {
+				in.Skip()
+				out.Options = nil
+			} else {
+				in.Delim('[')
+				if out.Options == nil {
+					if !in.IsDelim(']') {
+						out.Options = make([]string, 0, 4)
+					} else {
+						out.Options = []string{}
+					}
+				} else {
+					out.Options = (out.Options)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v130 string
+					v130 = string(in.String())
+					out.Options = append(out.Options, v130)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson1dbef17bEncodeGithubComContainersLibpodLibpod10(out *jwriter.Writer, in ContainerNamedVolume) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"volumeName\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		out.String(string(in.ConmonPidFile))
+		out.String(string(in.Name))
 	}
 	{
-		const prefix string = ",\"postConfigureNetNS\":"
+		const prefix string = ",\"dest\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		out.Bool(bool(in.PostConfigureNetNS))
+		out.String(string(in.Dest))
 	}
-	if len(in.ExitCommand) != 0 {
-		const prefix string = ",\"exitCommand\":"
+	if len(in.Options) != 0 {
+		const prefix string = ",\"options\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
@@ -2348,83 +4549,89 @@ func easyjson1dbef17bEncodeGithubComContainersLibpodLibpod2(out *jwriter.Writer,
 		}
 		{
 			out.RawByte('[')
-			for v83, v84 := range in.ExitCommand {
-				if v83 > 0 {
+			for v131, v132 := range in.Options {
+				if v131 > 0 {
 					out.RawByte(',')
 				}
-				out.String(string(v84))
+				out.String(string(v132))
 			}
 			out.RawByte(']')
 		}
 	}
+	out.RawByte('}')
+}
+func easyjson1dbef17bDecodeGithubComContainersLibpodLibpod9(in *jlexer.Lexer, out *RootfsMount) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "container":
+			out.Container = string(in.String())
+		case "destination":
+			out.Destination = string(in.String())
+		case "readOnly":
+			out.ReadOnly = bool(in.Bool())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson1dbef17bEncodeGithubComContainersLibpodLibpod9(out *jwriter.Writer, in RootfsMount) {
+	out.RawByte('{')
+	first := true
+	_ = first
 	{
-		const prefix string = ",\"LocalVolumes\":"
+		const prefix string = ",\"container\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		if in.LocalVolumes == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
-			out.RawString("null")
-		} else {
-			out.RawByte('[')
-			for v85, v86 := range in.LocalVolumes {
-				if v85 > 0 {
-					out.RawByte(',')
-				}
-				out.String(string(v86))
-			}
-			out.RawByte(']')
-		}
+		out.String(string(in.Container))
 	}
 	{
-		const prefix string = ",\"pause\":"
+		const prefix string = ",\"destination\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		out.Bool(bool(in.IsInfra))
+		out.String(string(in.Destination))
 	}
-	{
-		const prefix string = ",\"systemd\":"
+	if in.ReadOnly {
+		const prefix string = ",\"readOnly\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		out.Bool(bool(in.Systemd))
+		out.Bool(bool(in.ReadOnly))
 	}
 	out.RawByte('}')
 }
-
-// MarshalJSON supports json.Marshaler interface
-func (v ContainerConfig) MarshalJSON() ([]byte, error) {
-	w := jwriter.Writer{}
-	easyjson1dbef17bEncodeGithubComContainersLibpodLibpod2(&w, v)
-	return w.Buffer.BuildBytes(), w.Error
-}
-
-// MarshalEasyJSON supports easyjson.Marshaler interface
-func (v ContainerConfig) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson1dbef17bEncodeGithubComContainersLibpodLibpod2(w, v)
-}
-
-// UnmarshalJSON supports json.Unmarshaler interface
-func (v *ContainerConfig) UnmarshalJSON(data []byte) error {
-	r := jlexer.Lexer{Data: data}
-	easyjson1dbef17bDecodeGithubComContainersLibpodLibpod2(&r, v)
-	return r.Error()
-}
-
-// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *ContainerConfig) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson1dbef17bDecodeGithubComContainersLibpodLibpod2(l, v)
-}
-func easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComCriOOcicniPkgOcicni(in *jlexer.Lexer, out *ocicni.PortMapping) {
+func easyjson1dbef17bDecodeGithubComContainersLibpodLibpod8(in *jlexer.Lexer, out *StorageMount) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -2443,14 +4650,33 @@ func easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComCriOOcicniPkg
 			continue
 		}
 		switch key {
-		case "hostPort":
-			out.HostPort = int32(in.Int32())
-		case "containerPort":
-			out.ContainerPort = int32(in.Int32())
-		case "protocol":
-			out.Protocol = string(in.String())
-		case "hostIP":
-			out.HostIP = string(in.String())
+		case "source":
+			out.Source = string(in.String())
+		case "type":
+			out.Type = string(in.String())
+		case "options":
+			if in.IsNull() {
+				in.Skip()
+				out.Options = nil
+			} else {
+				in.Delim('[')
+				if out.Options == nil {
+					if !in.IsDelim(']') {
+						out.Options = make([]string, 0, 4)
+					} else {
+						out.Options = []string{}
+					}
+				} else {
+					out.Options = (out.Options)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v133 string
+					v133 = string(in.String())
+					out.Options = append(out.Options, v133)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -2461,49 +4687,48 @@ func easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComCriOOcicniPkg
 		in.Consumed()
 	}
 }
-func easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComCriOOcicniPkgOcicni(out *jwriter.Writer, in ocicni.PortMapping) {
+func easyjson1dbef17bEncodeGithubComContainersLibpodLibpod8(out *jwriter.Writer, in StorageMount) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"hostPort\":"
+		const prefix string = ",\"source\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		out.Int32(int32(in.HostPort))
+		out.String(string(in.Source))
 	}
 	{
-		const prefix string = ",\"containerPort\":"
+		const prefix string = ",\"type\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		out.Int32(int32(in.ContainerPort))
+		out.String(string(in.Type))
 	}
-	{
-		const prefix string = ",\"protocol\":"
+	if len(in.Options) != 0 {
+		const prefix string = ",\"options\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		out.String(string(in.Protocol))
-	}
-	{
-		const prefix string = ",\"hostIP\":"
-		if first {
-			first = false
-			out.RawString(prefix[1:])
-		} else {
-			out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v134, v135 := range in.Options {
+				if v134 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v135))
+			}
+			out.RawByte(']')
 		}
-		out.String(string(in.HostIP))
 	}
 	out.RawByte('}')
 }
@@ -2546,9 +4771,9 @@ func easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComContainersSto
 					out.UIDMap = (out.UIDMap)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v87 idtools.IDMap
-					easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComContainersStoragePkgIdtools(in, &v87)
-					out.UIDMap = append(out.UIDMap, v87)
+					var v136 idtools.IDMap
+					easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComContainersStoragePkgIdtools(in, &v136)
+					out.UIDMap = append(out.UIDMap, v136)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -2569,9 +4794,9 @@ func easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComContainersSto
 					out.GIDMap = (out.GIDMap)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v88 idtools.IDMap
-					easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComContainersStoragePkgIdtools(in, &v88)
-					out.GIDMap = append(out.GIDMap, v88)
+					var v137 idtools.IDMap
+					easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComContainersStoragePkgIdtools(in, &v137)
+					out.GIDMap = append(out.GIDMap, v137)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -2622,11 +4847,11 @@ func easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComContainersSto
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v89, v90 := range in.UIDMap {
-				if v89 > 0 {
+			for v138, v139 := range in.UIDMap {
+				if v138 > 0 {
 					out.RawByte(',')
 				}
-				easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComContainersStoragePkgIdtools(out, v90)
+				easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComContainersStoragePkgIdtools(out, v139)
 			}
 			out.RawByte(']')
 		}
@@ -2643,11 +4868,11 @@ func easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComContainersSto
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v91, v92 := range in.GIDMap {
-				if v91 > 0 {
+			for v140, v141 := range in.GIDMap {
+				if v140 > 0 {
 					out.RawByte(',')
 				}
-				easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComContainersStoragePkgIdtools(out, v92)
+				easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComContainersStoragePkgIdtools(out, v141)
 			}
 			out.RawByte(']')
 		}
@@ -2784,9 +5009,9 @@ func easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComOpencontainer
 					out.Mounts = (out.Mounts)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v93 specs_go.Mount
-					easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo4(in, &v93)
-					out.Mounts = append(out.Mounts, v93)
+					var v142 specs_go.Mount
+					easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo4(in, &v142)
+					out.Mounts = append(out.Mounts, v142)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -2814,9 +5039,9 @@ func easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComOpencontainer
 				for !in.IsDelim('}') {
 					key := string(in.String())
 					in.WantColon()
-					var v94 string
-					v94 = string(in.String())
-					(out.Annotations)[key] = v94
+					var v143 string
+					v143 = string(in.String())
+					(out.Annotations)[key] = v143
 					in.WantComma()
 				}
 				in.Delim('}')
@@ -2925,11 +5150,11 @@ func easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComOpencontainer
 		}
 		{
 			out.RawByte('[')
-			for v95, v96 := range in.Mounts {
-				if v95 > 0 {
+			for v144, v145 := range in.Mounts {
+				if v144 > 0 {
 					out.RawByte(',')
 				}
-				easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo4(out, v96)
+				easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo4(out, v145)
 			}
 			out.RawByte(']')
 		}
@@ -2954,16 +5179,16 @@ func easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComOpencontainer
 		}
 		{
 			out.RawByte('{')
-			v97First := true
-			for v97Name, v97Value := range in.Annotations {
-				if v97First {
-					v97First = false
+			v146First := true
+			for v146Name, v146Value := range in.Annotations {
+				if v146First {
+					v146First = false
 				} else {
 					out.RawByte(',')
 				}
-				out.String(string(v97Name))
+				out.String(string(v146Name))
 				out.RawByte(':')
-				out.String(string(v97Value))
+				out.String(string(v146Value))
 			}
 			out.RawByte('}')
 		}
@@ -3305,9 +5530,9 @@ func easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComOpencontainer
 					out.LayerFolders = (out.LayerFolders)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v98 string
-					v98 = string(in.String())
-					out.LayerFolders = append(out.LayerFolders, v98)
+					var v147 string
+					v147 = string(in.String())
+					out.LayerFolders = append(out.LayerFolders, v147)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -3328,9 +5553,9 @@ func easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComOpencontainer
 					out.Devices = (out.Devices)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v99 specs_go.WindowsDevice
-					easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo13(in, &v99)
-					out.Devices = append(out.Devices, v99)
+					var v148 specs_go.WindowsDevice
+					easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo13(in, &v148)
+					out.Devices = append(out.Devices, v148)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -3403,11 +5628,11 @@ func easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComOpencontainer
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v100, v101 := range in.LayerFolders {
-				if v100 > 0 {
+			for v149, v150 := range in.LayerFolders {
+				if v149 > 0 {
 					out.RawByte(',')
 				}
-				out.String(string(v101))
+				out.String(string(v150))
 			}
 			out.RawByte(']')
 		}
@@ -3422,11 +5647,11 @@ func easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComOpencontainer
 		}
 		{
 			out.RawByte('[')
-			for v102, v103 := range in.Devices {
-				if v102 > 0 {
+			for v151, v152 := range in.Devices {
+				if v151 > 0 {
 					out.RawByte(',')
 				}
-				easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo13(out, v103)
+				easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo13(out, v152)
 			}
 			out.RawByte(']')
 		}
@@ -3534,9 +5759,9 @@ func easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComOpencontainer
 					out.EndpointList = (out.EndpointList)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v104 string
-					v104 = string(in.String())
-					out.EndpointList = append(out.EndpointList, v104)
+					var v153 string
+					v153 = string(in.String())
+					out.EndpointList = append(out.EndpointList, v153)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -3559,9 +5784,9 @@ func easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComOpencontainer
 					out.DNSSearchList = (out.DNSSearchList)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v105 string
-					v105 = string(in.String())
-					out.DNSSearchList = append(out.DNSSearchList, v105)
+					var v154 string
+					v154 = string(in.String())
+					out.DNSSearchList = append(out.DNSSearchList, v154)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -3592,11 +5817,11 @@ func easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComOpencontainer
 		}
 		{
 			out.RawByte('[')
-			for v106, v107 := range in.EndpointList {
-				if v106 > 0 {
+			for v155, v156 := range in.EndpointList {
+				if v155 > 0 {
 					out.RawByte(',')
 				}
-				out.String(string(v107))
+				out.String(string(v156))
 			}
 			out.RawByte(']')
 		}
@@ -3621,11 +5846,11 @@ func easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComOpencontainer
 		}
 		{
 			out.RawByte('[')
-			for v108, v109 := range in.DNSSearchList {
-				if v108 > 0 {
+			for v157, v158 := range in.DNSSearchList {
+				if v157 > 0 {
 					out.RawByte(',')
 				}
-				out.String(string(v109))
+				out.String(string(v158))
 			}
 			out.RawByte(']')
 		}
@@ -4129,9 +6354,9 @@ func easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComOpencontainer
 					out.Anet = (out.Anet)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v110 specs_go.SolarisAnet
-					easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo20(in, &v110)
-					out.Anet = append(out.Anet, v110)
+					var v159 specs_go.SolarisAnet
+					easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo20(in, &v159)
+					out.Anet = append(out.Anet, v159)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -4210,11 +6435,11 @@ func easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComOpencontainer
 		}
 		{
 			out.RawByte('[')
-			for v111, v112 := range in.Anet {
-				if v111 > 0 {
+			for v160, v161 := range in.Anet {
+				if v160 > 0 {
 					out.RawByte(',')
 				}
-				easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo20(out, v112)
+				easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo20(out, v161)
 			}
 			out.RawByte(']')
 		}
@@ -4501,9 +6726,9 @@ func easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComOpencontainer
 					out.UIDMappings = (out.UIDMappings)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v113 specs_go.LinuxIDMapping
-					easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo23(in, &v113)
-					out.UIDMappings = append(out.UIDMappings, v113)
+					var v162 specs_go.LinuxIDMapping
+					easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo23(in, &v162)
+					out.UIDMappings = append(out.UIDMappings, v162)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -4524,9 +6749,9 @@ func easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComOpencontainer
 					out.GIDMappings = (out.GIDMappings)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v114 specs_go.LinuxIDMapping
-					easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo23(in, &v114)
-					out.GIDMappings = append(out.GIDMappings, v114)
+					var v163 specs_go.LinuxIDMapping
+					easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo23(in, &v163)
+					out.GIDMappings = append(out.GIDMappings, v163)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -4544,9 +6769,9 @@ func easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComOpencontainer
 				for !in.IsDelim('}') {
 					key := string(in.String())
 					in.WantColon()
-					var v115 string
-					v115 = string(in.String())
-					(out.Sysctl)[key] = v115
+					var v164 string
+					v164 = string(in.String())
+					(out.Sysctl)[key] = v164
 					in.WantComma()
 				}
 				in.Delim('}')
@@ -4579,9 +6804,9 @@ func easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComOpencontainer
 					out.Namespaces = (out.Namespaces)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v116 specs_go.LinuxNamespace
-					easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo25(in, &v116)
-					out.Namespaces = append(out.Namespaces, v116)
+					var v165 specs_go.LinuxNamespace
+					easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo25(in, &v165)
+					out.Namespaces = append(out.Namespaces, v165)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -4602,9 +6827,9 @@ func easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComOpencontainer
 					out.Devices = (out.Devices)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v117 specs_go.LinuxDevice
-					easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo26(in, &v117)
-					out.Devices = append(out.Devices, v117)
+					var v166 specs_go.LinuxDevice
+					easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo26(in, &v166)
+					out.Devices = append(out.Devices, v166)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -4637,9 +6862,9 @@ func easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComOpencontainer
 					out.MaskedPaths = (out.MaskedPaths)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v118 string
-					v118 = string(in.String())
-					out.MaskedPaths = append(out.MaskedPaths, v118)
+					var v167 string
+					v167 = string(in.String())
+					out.MaskedPaths = append(out.MaskedPaths, v167)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -4660,9 +6885,9 @@ func easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComOpencontainer
 					out.ReadonlyPaths = (out.ReadonlyPaths)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v119 string
-					v119 = string(in.String())
-					out.ReadonlyPaths = append(out.ReadonlyPaths, v119)
+					var v168 string
+					v168 = string(in.String())
+					out.ReadonlyPaths = append(out.ReadonlyPaths, v168)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -4703,11 +6928,11 @@ func easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComOpencontainer
 		}
 		{
 			out.RawByte('[')
-			for v120, v121 := range in.UIDMappings {
-				if v120 > 0 {
+			for v169, v170 := range in.UIDMappings {
+				if v169 > 0 {
 					out.RawByte(',')
 				}
-				easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo23(out, v121)
+				easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo23(out, v170)
 			}
 			out.RawByte(']')
 		}
@@ -4722,11 +6947,11 @@ func easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComOpencontainer
 		}
 		{
 			out.RawByte('[')
-			for v122, v123 := range in.GIDMappings {
-				if v122 > 0 {
+			for v171, v172 := range in.GIDMappings {
+				if v171 > 0 {
 					out.RawByte(',')
 				}
-				easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo23(out, v123)
+				easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo23(out, v172)
 			}
 			out.RawByte(']')
 		}
@@ -4741,16 +6966,16 @@ func easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComOpencontainer
 		}
 		{
 			out.RawByte('{')
-			v124First := true
-			for v124Name, v124Value := range in.Sysctl {
-				if v124First {
-					v124First = false
+			v173First := true
+			for v173Name, v173Value := range in.Sysctl {
+				if v173First {
+					v173First = false
 				} else {
 					out.RawByte(',')
 				}
-				out.String(string(v124Name))
+				out.String(string(v173Name))
 				out.RawByte(':')
-				out.String(string(v124Value))
+				out.String(string(v173Value))
 			}
 			out.RawByte('}')
 		}
@@ -4785,11 +7010,11 @@ func easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComOpencontainer
 		}
 		{
 			out.RawByte('[')
-			for v125, v126 := range in.Namespaces {
-				if v125 > 0 {
+			for v174, v175 := range in.Namespaces {
+				if v174 > 0 {
 					out.RawByte(',')
 				}
-				easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo25(out, v126)
+				easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo25(out, v175)
 			}
 			out.RawByte(']')
 		}
@@ -4804,11 +7029,11 @@ func easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComOpencontainer
 		}
 		{
 			out.RawByte('[')
-			for v127, v128 := range in.Devices {
-				if v127 > 0 {
+			for v176, v177 := range in.Devices {
+				if v176 > 0 {
 					out.RawByte(',')
 				}
-				easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo26(out, v128)
+				easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo26(out, v177)
 			}
 			out.RawByte(']')
 		}
@@ -4843,11 +7068,11 @@ func easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComOpencontainer
 		}
 		{
 			out.RawByte('[')
-			for v129, v130 := range in.MaskedPaths {
-				if v129 > 0 {
+			for v178, v179 := range in.MaskedPaths {
+				if v178 > 0 {
 					out.RawByte(',')
 				}
-				out.String(string(v130))
+				out.String(string(v179))
 			}
 			out.RawByte(']')
 		}
@@ -4862,11 +7087,11 @@ func easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComOpencontainer
 		}
 		{
 			out.RawByte('[')
-			for v131, v132 := range in.ReadonlyPaths {
-				if v131 > 0 {
+			for v180, v181 := range in.ReadonlyPaths {
+				if v180 > 0 {
 					out.RawByte(',')
 				}
-				out.String(string(v132))
+				out.String(string(v181))
 			}
 			out.RawByte(']')
 		}
@@ -4977,9 +7202,9 @@ func easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComOpencontainer
 					out.Architectures = (out.Architectures)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v133 specs_go.Arch
-					v133 = specs_go.Arch(in.String())
-					out.Architectures = append(out.Architectures, v133)
+					var v182 specs_go.Arch
+					v182 = specs_go.Arch(in.String())
+					out.Architectures = append(out.Architectures, v182)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -5000,9 +7225,9 @@ func easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComOpencontainer
 					out.Syscalls = (out.Syscalls)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v134 specs_go.LinuxSyscall
-					easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo29(in, &v134)
-					out.Syscalls = append(out.Syscalls, v134)
+					var v183 specs_go.LinuxSyscall
+					easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo29(in, &v183)
+					out.Syscalls = append(out.Syscalls, v183)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -5041,11 +7266,11 @@ func easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComOpencontainer
 		}
 		{
 			out.RawByte('[')
-			for v135, v136 := range in.Architectures {
-				if v135 > 0 {
+			for v184, v185 := range in.Architectures {
+				if v184 > 0 {
 					out.RawByte(',')
 				}
-				out.String(string(v136))
+				out.String(string(v185))
 			}
 			out.RawByte(']')
 		}
@@ -5060,11 +7285,11 @@ func easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComOpencontainer
 		}
 		{
 			out.RawByte('[')
-			for v137, v138 := range in.Syscalls {
-				if v137 > 0 {
+			for v186, v187 := range in.Syscalls {
+				if v186 > 0 {
 					out.RawByte(',')
 				}
-				easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo29(out, v138)
+				easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo29(out, v187)
 			}
 			out.RawByte(']')
 		}
@@ -5106,9 +7331,9 @@ func easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComOpencontainer
 					out.Names = (out.Names)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v139 string
-					v139 = string(in.String())
-					out.Names = append(out.Names, v139)
+					var v188 string
+					v188 = string(in.String())
+					out.Names = append(out.Names, v188)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -5131,9 +7356,9 @@ func easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComOpencontainer
 					out.Args = (out.Args)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v140 specs_go.LinuxSeccompArg
-					easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo30(in, &v140)
-					out.Args = append(out.Args, v140)
+					var v189 specs_go.LinuxSeccompArg
+					easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo30(in, &v189)
+					out.Args = append(out.Args, v189)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -5164,11 +7389,11 @@ func easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComOpencontainer
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v141, v142 := range in.Names {
-				if v141 > 0 {
+			for v190, v191 := range in.Names {
+				if v190 > 0 {
 					out.RawByte(',')
 				}
-				out.String(string(v142))
+				out.String(string(v191))
 			}
 			out.RawByte(']')
 		}
@@ -5193,11 +7418,11 @@ func easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComOpencontainer
 		}
 		{
 			out.RawByte('[')
-			for v143, v144 := range in.Args {
-				if v143 > 0 {
+			for v192, v193 := range in.Args {
+				if v192 > 0 {
 					out.RawByte(',')
 				}
-				easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo30(out, v144)
+				easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo30(out, v193)
 			}
 			out.RawByte(']')
 		}
@@ -5524,9 +7749,9 @@ func easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComOpencontainer
 					out.Devices = (out.Devices)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v145 specs_go.LinuxDeviceCgroup
-					easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo31(in, &v145)
-					out.Devices = append(out.Devices, v145)
+					var v194 specs_go.LinuxDeviceCgroup
+					easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo31(in, &v194)
+					out.Devices = append(out.Devices, v194)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -5587,9 +7812,9 @@ func easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComOpencontainer
 					out.HugepageLimits = (out.HugepageLimits)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v146 specs_go.LinuxHugepageLimit
-					easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo36(in, &v146)
-					out.HugepageLimits = append(out.HugepageLimits, v146)
+					var v195 specs_go.LinuxHugepageLimit
+					easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo36(in, &v195)
+					out.HugepageLimits = append(out.HugepageLimits, v195)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -5617,9 +7842,9 @@ func easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComOpencontainer
 				for !in.IsDelim('}') {
 					key := string(in.String())
 					in.WantColon()
-					var v147 specs_go.LinuxRdma
-					easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo38(in, &v147)
-					(out.Rdma)[key] = v147
+					var v196 specs_go.LinuxRdma
+					easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo38(in, &v196)
+					(out.Rdma)[key] = v196
 					in.WantComma()
 				}
 				in.Delim('}')
@@ -5648,11 +7873,11 @@ func easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComOpencontainer
 		}
 		{
 			out.RawByte('[')
-			for v148, v149 := range in.Devices {
-				if v148 > 0 {
+			for v197, v198 := range in.Devices {
+				if v197 > 0 {
 					out.RawByte(',')
 				}
-				easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo31(out, v149)
+				easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo31(out, v198)
 			}
 			out.RawByte(']')
 		}
@@ -5707,11 +7932,11 @@ func easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComOpencontainer
 		}
 		{
 			out.RawByte('[')
-			for v150, v151 := range in.HugepageLimits {
-				if v150 > 0 {
+			for v199, v200 := range in.HugepageLimits {
+				if v199 > 0 {
 					out.RawByte(',')
 				}
-				easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo36(out, v151)
+				easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo36(out, v200)
 			}
 			out.RawByte(']')
 		}
@@ -5736,16 +7961,16 @@ func easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComOpencontainer
 		}
 		{
 			out.RawByte('{')
-			v152First := true
-			for v152Name, v152Value := range in.Rdma {
-				if v152First {
-					v152First = false
+			v201First := true
+			for v201Name, v201Value := range in.Rdma {
+				if v201First {
+					v201First = false
 				} else {
 					out.RawByte(',')
 				}
-				out.String(string(v152Name))
+				out.String(string(v201Name))
 				out.RawByte(':')
-				easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo38(out, v152Value)
+				easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo38(out, v201Value)
 			}
 			out.RawByte('}')
 		}
@@ -5872,9 +8097,9 @@ func easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComOpencontainer
 					out.Priorities = (out.Priorities)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v153 specs_go.LinuxInterfacePriority
-					easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo39(in, &v153)
-					out.Priorities = append(out.Priorities, v153)
+					var v202 specs_go.LinuxInterfacePriority
+					easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo39(in, &v202)
+					out.Priorities = append(out.Priorities, v202)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -5913,11 +8138,11 @@ func easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComOpencontainer
 		}
 		{
 			out.RawByte('[')
-			for v154, v155 := range in.Priorities {
-				if v154 > 0 {
+			for v203, v204 := range in.Priorities {
+				if v203 > 0 {
 					out.RawByte(',')
 				}
-				easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo39(out, v155)
+				easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo39(out, v204)
 			}
 			out.RawByte(']')
 		}
@@ -6097,9 +8322,9 @@ func easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComOpencontainer
 					out.WeightDevice = (out.WeightDevice)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v156 specs_go.LinuxWeightDevice
-					easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo40(in, &v156)
-					out.WeightDevice = append(out.WeightDevice, v156)
+					var v205 specs_go.LinuxWeightDevice
+					easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo40(in, &v205)
+					out.WeightDevice = append(out.WeightDevice, v205)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -6120,9 +8345,9 @@ func easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComOpencontainer
 					out.ThrottleReadBpsDevice = (out.ThrottleReadBpsDevice)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v157 specs_go.LinuxThrottleDevice
-					easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo41(in, &v157)
-					out.ThrottleReadBpsDevice = append(out.ThrottleReadBpsDevice, v157)
+					var v206 specs_go.LinuxThrottleDevice
+					easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo41(in, &v206)
+					out.ThrottleReadBpsDevice = append(out.ThrottleReadBpsDevice, v206)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -6143,9 +8368,9 @@ func easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComOpencontainer
 					out.ThrottleWriteBpsDevice = (out.ThrottleWriteBpsDevice)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v158 specs_go.LinuxThrottleDevice
-					easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo41(in, &v158)
-					out.ThrottleWriteBpsDevice = append(out.ThrottleWriteBpsDevice, v158)
+					var v207 specs_go.LinuxThrottleDevice
+					easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo41(in, &v207)
+					out.ThrottleWriteBpsDevice = append(out.ThrottleWriteBpsDevice, v207)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -6166,9 +8391,9 @@ func easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComOpencontainer
 					out.ThrottleReadIOPSDevice = (out.ThrottleReadIOPSDevice)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v159 specs_go.LinuxThrottleDevice
-					easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo41(in, &v159)
-					out.ThrottleReadIOPSDevice = append(out.ThrottleReadIOPSDevice, v159)
+					var v208 specs_go.LinuxThrottleDevice
+					easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo41(in, &v208)
+					out.ThrottleReadIOPSDevice = append(out.ThrottleReadIOPSDevice, v208)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -6189,9 +8414,9 @@ func easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComOpencontainer
 					out.ThrottleWriteIOPSDevice = (out.ThrottleWriteIOPSDevice)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v160 specs_go.LinuxThrottleDevice
-					easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo41(in, &v160)
-					out.ThrottleWriteIOPSDevice = append(out.ThrottleWriteIOPSDevice, v160)
+					var v209 specs_go.LinuxThrottleDevice
+					easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo41(in, &v209)
+					out.ThrottleWriteIOPSDevice = append(out.ThrottleWriteIOPSDevice, v209)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -6240,11 +8465,11 @@ func easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComOpencontainer
 		}
 		{
 			out.RawByte('[')
-			for v161, v162 := range in.WeightDevice {
-				if v161 > 0 {
+			for v210, v211 := range in.WeightDevice {
+				if v210 > 0 {
 					out.RawByte(',')
 				}
-				easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo40(out, v162)
+				easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo40(out, v211)
 			}
 			out.RawByte(']')
 		}
@@ -6259,11 +8484,11 @@ func easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComOpencontainer
 		}
 		{
 			out.RawByte('[')
-			for v163, v164 := range in.ThrottleReadBpsDevice {
-				if v163 > 0 {
+			for v212, v213 := range in.ThrottleReadBpsDevice {
+				if v212 > 0 {
 					out.RawByte(',')
 				}
-				easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo41(out, v164)
+				easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo41(out, v213)
 			}
 			out.RawByte(']')
 		}
@@ -6278,11 +8503,11 @@ func easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComOpencontainer
 		}
 		{
 			out.RawByte('[')
-			for v165, v166 := range in.ThrottleWriteBpsDevice {
-				if v165 > 0 {
+			for v214, v215 := range in.ThrottleWriteBpsDevice {
+				if v214 > 0 {
 					out.RawByte(',')
 				}
-				easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo41(out, v166)
+				easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo41(out, v215)
 			}
 			out.RawByte(']')
 		}
@@ -6297,11 +8522,11 @@ func easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComOpencontainer
 		}
 		{
 			out.RawByte('[')
-			for v167, v168 := range in.ThrottleReadIOPSDevice {
-				if v167 > 0 {
+			for v216, v217 := range in.ThrottleReadIOPSDevice {
+				if v216 > 0 {
 					out.RawByte(',')
 				}
-				easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo41(out, v168)
+				easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo41(out, v217)
 			}
 			out.RawByte(']')
 		}
@@ -6316,11 +8541,11 @@ func easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComOpencontainer
 		}
 		{
 			out.RawByte('[')
-			for v169, v170 := range in.ThrottleWriteIOPSDevice {
-				if v169 > 0 {
+			for v218, v219 := range in.ThrottleWriteIOPSDevice {
+				if v218 > 0 {
 					out.RawByte(',')
 				}
-				easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo41(out, v170)
+				easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo41(out, v219)
 			}
 			out.RawByte(']')
 		}
@@ -7095,9 +9320,9 @@ func easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComOpencontainer
 					out.Prestart = (out.Prestart)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v171 specs_go.Hook
-					easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo(in, &v171)
-					out.Prestart = append(out.Prestart, v171)
+					var v220 specs_go.Hook
+					easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo(in, &v220)
+					out.Prestart = append(out.Prestart, v220)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -7118,9 +9343,9 @@ func easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComOpencontainer
 					out.Poststart = (out.Poststart)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v172 specs_go.Hook
-					easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo(in, &v172)
-					out.Poststart = append(out.Poststart, v172)
+					var v221 specs_go.Hook
+					easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo(in, &v221)
+					out.Poststart = append(out.Poststart, v221)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -7141,9 +9366,9 @@ func easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComOpencontainer
 					out.Poststop = (out.Poststop)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v173 specs_go.Hook
-					easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo(in, &v173)
-					out.Poststop = append(out.Poststop, v173)
+					var v222 specs_go.Hook
+					easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo(in, &v222)
+					out.Poststop = append(out.Poststop, v222)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -7172,11 +9397,11 @@ func easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComOpencontainer
 		}
 		{
 			out.RawByte('[')
-			for v174, v175 := range in.Prestart {
-				if v174 > 0 {
+			for v223, v224 := range in.Prestart {
+				if v223 > 0 {
 					out.RawByte(',')
 				}
-				easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo(out, v175)
+				easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo(out, v224)
 			}
 			out.RawByte(']')
 		}
@@ -7191,11 +9416,11 @@ func easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComOpencontainer
 		}
 		{
 			out.RawByte('[')
-			for v176, v177 := range in.Poststart {
-				if v176 > 0 {
+			for v225, v226 := range in.Poststart {
+				if v225 > 0 {
 					out.RawByte(',')
 				}
-				easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo(out, v177)
+				easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo(out, v226)
 			}
 			out.RawByte(']')
 		}
@@ -7210,11 +9435,11 @@ func easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComOpencontainer
 		}
 		{
 			out.RawByte('[')
-			for v178, v179 := range in.Poststop {
-				if v178 > 0 {
+			for v227, v228 := range in.Poststop {
+				if v227 > 0 {
 					out.RawByte(',')
 				}
-				easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo(out, v179)
+				easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo(out, v228)
 			}
 			out.RawByte(']')
 		}
@@ -7262,9 +9487,9 @@ func easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComOpencontainer
 					out.Options = (out.Options)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v180 string
-					v180 = string(in.String())
-					out.Options = append(out.Options, v180)
+					var v229 string
+					v229 = string(in.String())
+					out.Options = append(out.Options, v229)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -7323,11 +9548,11 @@ func easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComOpencontainer
 		}
 		{
 			out.RawByte('[')
-			for v181, v182 := range in.Options {
-				if v181 > 0 {
+			for v230, v231 := range in.Options {
+				if v230 > 0 {
 					out.RawByte(',')
 				}
-				out.String(string(v182))
+				out.String(string(v231))
 			}
 			out.RawByte(']')
 		}
@@ -7442,9 +9667,9 @@ func easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComOpencontainer
 					out.Args = (out.Args)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v183 string
-					v183 = string(in.String())
-					out.Args = append(out.Args, v183)
+					var v232 string
+					v232 = string(in.String())
+					out.Args = append(out.Args, v232)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -7465,9 +9690,9 @@ func easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComOpencontainer
 					out.Env = (out.Env)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v184 string
-					v184 = string(in.String())
-					out.Env = append(out.Env, v184)
+					var v233 string
+					v233 = string(in.String())
+					out.Env = append(out.Env, v233)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -7500,9 +9725,9 @@ func easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComOpencontainer
 					out.Rlimits = (out.Rlimits)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v185 specs_go.POSIXRlimit
-					easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo45(in, &v185)
-					out.Rlimits = append(out.Rlimits, v185)
+					var v234 specs_go.POSIXRlimit
+					easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo45(in, &v234)
+					out.Rlimits = append(out.Rlimits, v234)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -7579,11 +9804,11 @@ func easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComOpencontainer
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v186, v187 := range in.Args {
-				if v186 > 0 {
+			for v235, v236 := range in.Args {
+				if v235 > 0 {
 					out.RawByte(',')
 				}
-				out.String(string(v187))
+				out.String(string(v236))
 			}
 			out.RawByte(']')
 		}
@@ -7598,11 +9823,11 @@ func easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComOpencontainer
 		}
 		{
 			out.RawByte('[')
-			for v188, v189 := range in.Env {
-				if v188 > 0 {
+			for v237, v238 := range in.Env {
+				if v237 > 0 {
 					out.RawByte(',')
 				}
-				out.String(string(v189))
+				out.String(string(v238))
 			}
 			out.RawByte(']')
 		}
@@ -7637,11 +9862,11 @@ func easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComOpencontainer
 		}
 		{
 			out.RawByte('[')
-			for v190, v191 := range in.Rlimits {
-				if v190 > 0 {
+			for v239, v240 := range in.Rlimits {
+				if v239 > 0 {
 					out.RawByte(',')
 				}
-				easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo45(out, v191)
+				easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComOpencontainersRuntimeSpecSpecsGo45(out, v240)
 			}
 			out.RawByte(']')
 		}
@@ -7794,9 +10019,9 @@ func easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComOpencontainer
 					out.Bounding = (out.Bounding)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v192 string
-					v192 = string(in.String())
-					out.Bounding = append(out.Bounding, v192)
+					var v241 string
+					v241 = string(in.String())
+					out.Bounding = append(out.Bounding, v241)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -7817,9 +10042,9 @@ func easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComOpencontainer
 					out.Effective = (out.Effective)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v193 string
-					v193 = string(in.String())
-					out.Effective = append(out.Effective, v193)
+					var v242 string
+					v242 = string(in.String())
+					out.Effective = append(out.Effective, v242)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -7840,9 +10065,9 @@ func easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComOpencontainer
 					out.Inheritable = (out.Inheritable)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v194 string
-					v194 = string(in.String())
-					out.Inheritable = append(out.Inheritable, v194)
+					var v243 string
+					v243 = string(in.String())
+					out.Inheritable = append(out.Inheritable, v243)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -7863,9 +10088,9 @@ func easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComOpencontainer
 					out.Permitted = (out.Permitted)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v195 string
-					v195 = string(in.String())
-					out.Permitted = append(out.Permitted, v195)
+					var v244 string
+					v244 = string(in.String())
+					out.Permitted = append(out.Permitted, v244)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -7886,9 +10111,9 @@ func easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComOpencontainer
 					out.Ambient = (out.Ambient)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v196 string
-					v196 = string(in.String())
-					out.Ambient = append(out.Ambient, v196)
+					var v245 string
+					v245 = string(in.String())
+					out.Ambient = append(out.Ambient, v245)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -7917,11 +10142,11 @@ func easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComOpencontainer
 		}
 		{
 			out.RawByte('[')
-			for v197, v198 := range in.Bounding {
-				if v197 > 0 {
+			for v246, v247 := range in.Bounding {
+				if v246 > 0 {
 					out.RawByte(',')
 				}
-				out.String(string(v198))
+				out.String(string(v247))
 			}
 			out.RawByte(']')
 		}
@@ -7936,11 +10161,11 @@ func easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComOpencontainer
 		}
 		{
 			out.RawByte('[')
-			for v199, v200 := range in.Effective {
-				if v199 > 0 {
+			for v248, v249 := range in.Effective {
+				if v248 > 0 {
 					out.RawByte(',')
 				}
-				out.String(string(v200))
+				out.String(string(v249))
 			}
 			out.RawByte(']')
 		}
@@ -7955,11 +10180,11 @@ func easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComOpencontainer
 		}
 		{
 			out.RawByte('[')
-			for v201, v202 := range in.Inheritable {
-				if v201 > 0 {
+			for v250, v251 := range in.Inheritable {
+				if v250 > 0 {
 					out.RawByte(',')
 				}
-				out.String(string(v202))
+				out.String(string(v251))
 			}
 			out.RawByte(']')
 		}
@@ -7974,11 +10199,11 @@ func easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComOpencontainer
 		}
 		{
 			out.RawByte('[')
-			for v203, v204 := range in.Permitted {
-				if v203 > 0 {
+			for v252, v253 := range in.Permitted {
+				if v252 > 0 {
 					out.RawByte(',')
 				}
-				out.String(string(v204))
+				out.String(string(v253))
 			}
 			out.RawByte(']')
 		}
@@ -7993,11 +10218,11 @@ func easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComOpencontainer
 		}
 		{
 			out.RawByte('[')
-			for v205, v206 := range in.Ambient {
-				if v205 > 0 {
+			for v254, v255 := range in.Ambient {
+				if v254 > 0 {
 					out.RawByte(',')
 				}
-				out.String(string(v206))
+				out.String(string(v255))
 			}
 			out.RawByte(']')
 		}
@@ -8043,9 +10268,9 @@ func easyjson1dbef17bDecodeGithubComContainersLibpodVendorGithubComOpencontainer
 					out.AdditionalGids = (out.AdditionalGids)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v207 uint32
-					v207 = uint32(in.Uint32())
-					out.AdditionalGids = append(out.AdditionalGids, v207)
+					var v256 uint32
+					v256 = uint32(in.Uint32())
+					out.AdditionalGids = append(out.AdditionalGids, v256)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -8096,11 +10321,11 @@ func easyjson1dbef17bEncodeGithubComContainersLibpodVendorGithubComOpencontainer
 		}
 		{
 			out.RawByte('[')
-			for v208, v209 := range in.AdditionalGids {
-				if v208 > 0 {
+			for v257, v258 := range in.AdditionalGids {
+				if v257 > 0 {
 					out.RawByte(',')
 				}
-				out.Uint32(uint32(v209))
+				out.Uint32(uint32(v258))
 			}
 			out.RawByte(']')
 		}
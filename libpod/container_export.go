@@ -0,0 +1,751 @@
+package libpod
+
+import (
+	"archive/tar"
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/containers/libpod/libpod/layers"
+	"github.com/containers/storage"
+	"github.com/containers/storage/pkg/archive"
+	"github.com/containers/storage/pkg/fileutils"
+	"github.com/cyphar/filepath-securejoin"
+	"github.com/opencontainers/go-digest"
+	spec "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sys/unix"
+)
+
+// DefaultExportExcludes are the paths left out of filtered exports when no
+// patterns are given: package manager caches, documentation and logs
+var DefaultExportExcludes = []string{
+	"/var/cache/*",
+	"/var/lib/apt/lists/*",
+	"/var/log/*",
+	"/usr/share/doc/*",
+	"/usr/share/info/*",
+	"/usr/share/man/*",
+	"/tmp/*",
+	"/var/tmp/*",
+}
+
+// squashfsCompressors are the compression algorithms mksquashfs supports
+var squashfsCompressors = map[string]bool{
+	"gzip": true,
+	"lzma": true,
+	"lzo":  true,
+	"lz4":  true,
+	"xz":   true,
+	"zstd": true,
+}
+
+// exportTemplateData is what export filename templates are expanded with
+type exportTemplateData struct {
+	// Name is the container's name
+	Name string
+	// ShortID is the container's ID, truncated to 12 characters
+	ShortID string
+	// Timestamp is the time of the export in UTC, as 20060102T150405Z
+	Timestamp string
+	// Ext is the extension of the export's format, such as tar.gz
+	Ext string
+}
+
+// exportWithTemplate exports the container's root filesystem as a tar archive
+// with the given compression to a file in dir, named by expanding tmpl.
+// The path of the export is returned.
+func (c *Container) exportWithTemplate(dir, tmpl string, compression archive.Compression) (string, error) {
+	ext := (&compression).Extension()
+	if ext == "" {
+		return "", errors.Wrapf(ErrInvalidArg, "unsupported export compression %d", compression)
+	}
+
+	shortID := c.ID()
+	if len(shortID) > 12 {
+		shortID = shortID[:12]
+	}
+	name, err := expandExportTemplate(tmpl, exportTemplateData{
+		Name:      c.Name(),
+		ShortID:   shortID,
+		Timestamp: time.Now().UTC().Format("20060102T150405Z"),
+		Ext:       ext,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	info, err := os.Stat(dir)
+	if err != nil {
+		return "", errors.Wrapf(err, "error accessing export directory %s", dir)
+	}
+	if !info.IsDir() {
+		return "", errors.Wrapf(ErrInvalidArg, "export directory %s is not a directory", dir)
+	}
+	if err := unix.Access(dir, unix.W_OK); err != nil {
+		return "", errors.Wrapf(err, "export directory %s is not writable", dir)
+	}
+
+	path := filepath.Join(dir, name)
+	if err := c.exportCompressed(path, compression); err != nil {
+		return "", err
+	}
+
+	return path, nil
+}
+
+// ExportOptions are options for exporting a container's root filesystem
+type ExportOptions struct {
+	// Compression is the compression of the exported tar archive, or of
+	// the layers of an exported OCI image layout
+	Compression archive.Compression
+	// Format is the format of the export; a flat tar archive by default
+	Format ExportFormat
+}
+
+// exportWithOptions exports the container's root filesystem as a tar archive
+// at path, with the given options, and returns the path written
+func (c *Container) exportWithOptions(path string, opts ExportOptions) (string, error) {
+	switch opts.Format {
+	case ExportFormatTar:
+	case ExportFormatOCILayout:
+		if err := c.exportOCILayout(path, opts.Compression); err != nil {
+			return "", err
+		}
+		return path, nil
+	default:
+		return "", errors.Wrapf(ErrInvalidArg, "unsupported export format %d", opts.Format)
+	}
+
+	path, err := exportPath(path, opts.Compression)
+	if err != nil {
+		return "", err
+	}
+
+	if err := c.exportCompressed(path, opts.Compression); err != nil {
+		return "", err
+	}
+
+	return path, nil
+}
+
+// exportPath returns the path to export an archive with the given compression
+// to. Paths ending in .tar, or in the extension of another compression, have
+// their extension replaced with the one of the given compression; other paths
+// are used as is.
+func exportPath(path string, compression archive.Compression) (string, error) {
+	ext := (&compression).Extension()
+	if ext == "" {
+		return "", errors.Wrapf(ErrInvalidArg, "unsupported export compression %d", compression)
+	}
+
+	for _, known := range []archive.Compression{archive.Gzip, archive.Bzip2, archive.Xz, archive.Uncompressed} {
+		suffix := "." + (&known).Extension()
+		if strings.HasSuffix(path, suffix) && len(path) > len(suffix) {
+			return strings.TrimSuffix(path, suffix) + "." + ext, nil
+		}
+	}
+	for suffix, known := range map[string]archive.Compression{".tgz": archive.Gzip, ".tbz2": archive.Bzip2, ".txz": archive.Xz} {
+		if strings.HasSuffix(path, suffix) && len(path) > len(suffix) {
+			if known == compression {
+				return path, nil
+			}
+			return strings.TrimSuffix(path, suffix) + "." + ext, nil
+		}
+	}
+
+	return path, nil
+}
+
+// expandExportTemplate expands an export filename template
+// The result must be a single path component, so exports cannot be written
+// outside of the export directory.
+func expandExportTemplate(tmpl string, data exportTemplateData) (string, error) {
+	t, err := template.New("export").Option("missingkey=error").Parse(tmpl)
+	if err != nil {
+		return "", errors.Wrapf(ErrInvalidArg, "invalid export filename template %q: %v", tmpl, err)
+	}
+
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, data); err != nil {
+		return "", errors.Wrapf(ErrInvalidArg, "invalid export filename template %q: %v", tmpl, err)
+	}
+
+	name := buf.String()
+	if name == "" || name == "." || name == ".." || strings.ContainsRune(name, filepath.Separator) || strings.ContainsRune(name, 0) {
+		return "", errors.Wrapf(ErrInvalidArg, "export filename template %q expands to invalid filename %q", tmpl, name)
+	}
+
+	return name, nil
+}
+
+// exportSquashfs writes the container's root filesystem to a squashfs image at
+// path using mksquashfs
+func (c *Container) exportSquashfs(path, compression string) (err error) {
+	args, err := mksquashfsArgs(compression)
+	if err != nil {
+		return err
+	}
+
+	mksquashfs, err := exec.LookPath("mksquashfs")
+	if err != nil {
+		return errors.Wrapf(err, "mksquashfs is required to export container %s as a squashfs image", c.ID())
+	}
+
+	mountPoint := c.state.Mountpoint
+	if !c.state.Mounted {
+		mount, err := c.runtime.store.Mount(c.ID(), c.config.MountLabel)
+		if err != nil {
+			return errors.Wrapf(err, "error mounting container %q", c.ID())
+		}
+		mountPoint = mount
+		defer func() {
+			if _, err := c.runtime.store.Unmount(c.ID(), false); err != nil {
+				logrus.Errorf("error unmounting container %q: %v", c.ID(), err)
+			}
+		}()
+	}
+
+	defer func() {
+		// Don't leave a partial image behind
+		if err != nil {
+			if err2 := os.Remove(path); err2 != nil && !os.IsNotExist(err2) {
+				logrus.Errorf("error removing partial squashfs image %q: %v", path, err2)
+			}
+		}
+	}()
+
+	cmd := exec.Command(mksquashfs, append([]string{mountPoint, path}, args...)...)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return errors.Wrapf(err, "error creating squashfs image of container %s: %s", c.ID(), strings.TrimSpace(string(output)))
+	}
+
+	return nil
+}
+
+// mksquashfsArgs returns the mksquashfs options used to create a squashfs image
+// with the given compression, which defaults to gzip
+func mksquashfsArgs(compression string) ([]string, error) {
+	if compression == "" {
+		compression = "gzip"
+	}
+	if !squashfsCompressors[compression] {
+		return nil, errors.Wrapf(ErrInvalidArg, "unsupported squashfs compression %q", compression)
+	}
+
+	// Overwrite any existing image rather than appending to it, and keep
+	// ownership, permissions and extended attributes as they are in the
+	// root filesystem
+	return []string{"-noappend", "-no-progress", "-xattrs", "-comp", compression}, nil
+}
+
+// exportChanges writes a tar archive of the files in the container's root
+// filesystem that were added or modified since the baseline image, layer, or
+// container to w, and the paths of files that were deleted since the baseline
+// to deletions, one per line.
+// If baseline is "", changes are computed against the container's image.
+func (c *Container) exportChanges(baseline string, w, deletions io.Writer) error {
+	storageCtr, err := c.runtime.store.Container(c.ID())
+	if err != nil {
+		return errors.Wrapf(err, "error retrieving storage for container %s", c.ID())
+	}
+
+	baseLayer := ""
+	if baseline != "" {
+		baseLayer, err = c.runtime.getLayerID(baseline)
+		if err != nil {
+			return errors.Wrapf(err, "error resolving baseline %q of container %s", baseline, c.ID())
+		}
+	}
+
+	changes, err := c.runtime.store.Changes(baseLayer, storageCtr.LayerID)
+	if err != nil {
+		return errors.Wrapf(err, "error computing changes of container %s", c.ID())
+	}
+	exported, deleted := splitChanges(changes)
+
+	mountPoint := c.state.Mountpoint
+	if !c.state.Mounted {
+		mount, err := c.runtime.store.Mount(c.ID(), c.config.MountLabel)
+		if err != nil {
+			return errors.Wrapf(err, "error mounting container %q", c.ID())
+		}
+		mountPoint = mount
+		defer func() {
+			if _, err := c.runtime.store.Unmount(c.ID(), false); err != nil {
+				logrus.Errorf("error unmounting container %q: %v", c.ID(), err)
+			}
+		}()
+	}
+
+	input, err := archive.ExportChanges(mountPoint, exported, nil, nil)
+	if err != nil {
+		return errors.Wrapf(err, "error reading changes of container %s", c.ID())
+	}
+	defer input.Close()
+
+	if _, err := io.Copy(w, input); err != nil {
+		return errors.Wrapf(err, "error writing changes of container %s", c.ID())
+	}
+
+	for _, path := range deleted {
+		if _, err := io.WriteString(deletions, path+"\n"); err != nil {
+			return errors.Wrapf(err, "error writing deletions of container %s", c.ID())
+		}
+	}
+
+	return nil
+}
+
+// splitChanges separates the paths that were added or modified from those that
+// were deleted, leaving out the files libpod mounts into every container
+func splitChanges(changes []archive.Change) ([]archive.Change, []string) {
+	exported := []archive.Change{}
+	deleted := []string{}
+	for _, change := range changes {
+		if containerMounts[change.Path] {
+			continue
+		}
+		if change.Kind == archive.ChangeDelete {
+			deleted = append(deleted, change.Path)
+			continue
+		}
+		exported = append(exported, change)
+	}
+
+	return exported, deleted
+}
+
+// exportPaths writes a tar archive of the given paths in the container's root
+// filesystem to w. Paths are resolved inside the root filesystem, so symlinks
+// cannot point outside of it, and are archived relative to the root of the
+// container. Paths that escape the root filesystem are rejected.
+// The caller is responsible for closing w.
+func (c *Container) exportPaths(paths []string, w io.Writer) error {
+	if len(paths) == 0 {
+		return errors.Wrapf(ErrInvalidArg, "must provide at least one path to export")
+	}
+
+	mountPoint := c.state.Mountpoint
+	if !c.state.Mounted {
+		mount, err := c.runtime.store.Mount(c.ID(), c.config.MountLabel)
+		if err != nil {
+			return errors.Wrapf(err, "error mounting container %q", c.ID())
+		}
+		mountPoint = mount
+		defer func() {
+			if _, err := c.runtime.store.Unmount(c.ID(), false); err != nil {
+				logrus.Errorf("error unmounting container %q: %v", c.ID(), err)
+			}
+		}()
+	}
+
+	includeFiles := make([]string, 0, len(paths))
+	for _, path := range paths {
+		relPath, err := resolveRootfsPath(mountPoint, path)
+		if err != nil {
+			return errors.Wrapf(err, "error exporting path %q of container %s", path, c.ID())
+		}
+		if _, err := os.Lstat(filepath.Join(mountPoint, relPath)); err != nil {
+			return errors.Wrapf(err, "error exporting path %q of container %s", path, c.ID())
+		}
+		includeFiles = append(includeFiles, relPath)
+	}
+
+	input, err := archive.TarWithOptions(mountPoint, &archive.TarOptions{
+		Compression:  archive.Uncompressed,
+		IncludeFiles: includeFiles,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "error reading container directory %q", c.ID())
+	}
+	defer input.Close()
+
+	_, err = io.Copy(w, input)
+	return err
+}
+
+// resolveRootfsPath resolves a path inside a container's root filesystem,
+// following symlinks without leaving the root filesystem, and returns it
+// relative to the root filesystem.
+// Paths that lexically escape the root filesystem are rejected.
+func resolveRootfsPath(mountPoint, path string) (string, error) {
+	cleanPath := filepath.Clean(path)
+	if cleanPath == ".." || strings.HasPrefix(cleanPath, "../") {
+		return "", errors.Wrapf(ErrInvalidArg, "path %q escapes the container root filesystem", path)
+	}
+
+	resolved, err := securejoin.SecureJoin(mountPoint, cleanPath)
+	if err != nil {
+		return "", errors.Wrapf(err, "error resolving path %q", path)
+	}
+
+	relPath, err := filepath.Rel(mountPoint, resolved)
+	if err != nil {
+		return "", errors.Wrapf(err, "error resolving path %q", path)
+	}
+	if relPath == ".." || strings.HasPrefix(relPath, "../") {
+		return "", errors.Wrapf(ErrInvalidArg, "path %q escapes the container root filesystem", path)
+	}
+
+	return relPath, nil
+}
+
+const (
+	// exportRootfsEntry is the name of the root filesystem archive in an
+	// export with mounts
+	exportRootfsEntry = "rootfs.tar"
+	// exportMountsEntry is the name of the mount manifest in an export with
+	// mounts
+	exportMountsEntry = "mounts.json"
+)
+
+// ExportMount describes a mount of an exported container
+type ExportMount struct {
+	// Destination is the path of the mount in the container
+	Destination string `json:"destination"`
+	// Source is the path of the mount on the host, or the device or
+	// filesystem for mounts that are not bind mounts
+	Source string `json:"source,omitempty"`
+	// Type is the filesystem type of the mount
+	Type string `json:"type,omitempty"`
+	// Options are the mount options
+	Options []string `json:"options,omitempty"`
+	// Volume is whether the mount is a volume added by the user
+	Volume bool `json:"volume,omitempty"`
+}
+
+// ExportMountManifest lists the mounts of an exported container
+type ExportMountManifest struct {
+	// ID is the ID of the exported container
+	ID string `json:"id"`
+	// Name is the name of the exported container
+	Name string `json:"name"`
+	// Mounts are the mounts of the container, excluding the mounts libpod
+	// creates itself and secret mounts
+	Mounts []ExportMount `json:"mounts"`
+}
+
+// exportMounts returns the mounts of an exported container from its spec's
+// mounts and user volumes.
+// Mounts libpod makes itself are not included, and neither are mounts of
+// secrets, so their sources are not disclosed to whoever imports the export.
+func exportMounts(mounts []spec.Mount, userVolumes []string) []ExportMount {
+	volumes := make(map[string]bool, len(userVolumes))
+	for _, vol := range userVolumes {
+		volumes[filepath.Clean(vol)] = true
+	}
+
+	exported := []ExportMount{}
+	for _, m := range mounts {
+		dest := filepath.Clean(m.Destination)
+		if containerMounts[dest] || strings.HasPrefix(dest, "/run/secrets/") {
+			continue
+		}
+		exported = append(exported, ExportMount{
+			Destination: dest,
+			Source:      m.Source,
+			Type:        m.Type,
+			Options:     m.Options,
+			Volume:      volumes[dest],
+		})
+	}
+	return exported
+}
+
+// exportWithMounts writes a tar archive to path containing the container's
+// root filesystem as a tar archive and a manifest of the container's mounts
+func (c *Container) exportWithMounts(path string) (err error) {
+	var specMounts []spec.Mount
+	if c.config.Spec != nil {
+		specMounts = c.config.Spec.Mounts
+	}
+	manifest, err := json.MarshalIndent(ExportMountManifest{
+		ID:     c.ID(),
+		Name:   c.Name(),
+		Mounts: exportMounts(specMounts, c.config.UserVolumes),
+	}, "", "  ")
+	if err != nil {
+		return errors.Wrapf(err, "error encoding mount manifest of container %s", c.ID())
+	}
+
+	// The size of the root filesystem archive must be known before it is
+	// added to the export, so write it out first
+	rootfs, err := ioutil.TempFile(filepath.Dir(path), ".export-rootfs-")
+	if err != nil {
+		return errors.Wrapf(err, "error creating temporary file for export of container %s", c.ID())
+	}
+	defer func() {
+		rootfs.Close()
+		if err2 := os.Remove(rootfs.Name()); err2 != nil && !os.IsNotExist(err2) {
+			logrus.Errorf("error removing temporary file %q: %v", rootfs.Name(), err2)
+		}
+	}()
+	if err := c.exportCompressed(rootfs.Name(), archive.Uncompressed); err != nil {
+		return err
+	}
+	info, err := rootfs.Stat()
+	if err != nil {
+		return errors.Wrapf(err, "error accessing temporary file %q", rootfs.Name())
+	}
+
+	outFile, err := os.Create(path)
+	if err != nil {
+		return errors.Wrapf(err, "error creating file %q", path)
+	}
+	defer func() {
+		outFile.Close()
+		// Don't leave a partial export behind
+		if err != nil {
+			if err2 := os.Remove(path); err2 != nil && !os.IsNotExist(err2) {
+				logrus.Errorf("error removing partial export %q: %v", path, err2)
+			}
+		}
+	}()
+
+	now := time.Now()
+	tw := tar.NewWriter(outFile)
+	if err := tw.WriteHeader(&tar.Header{
+		Name:    exportMountsEntry,
+		Mode:    0644,
+		Size:    int64(len(manifest)),
+		ModTime: now,
+	}); err != nil {
+		return errors.Wrapf(err, "error writing export %q", path)
+	}
+	if _, err := tw.Write(manifest); err != nil {
+		return errors.Wrapf(err, "error writing export %q", path)
+	}
+
+	if err := tw.WriteHeader(&tar.Header{
+		Name:    exportRootfsEntry,
+		Mode:    0644,
+		Size:    info.Size(),
+		ModTime: now,
+	}); err != nil {
+		return errors.Wrapf(err, "error writing export %q", path)
+	}
+	if _, err := io.Copy(tw, rootfs); err != nil {
+		return errors.Wrapf(err, "error writing export %q", path)
+	}
+
+	if err := tw.Close(); err != nil {
+		return errors.Wrapf(err, "error writing export %q", path)
+	}
+	return outFile.Sync()
+}
+
+// exportFiltered exports the container's root filesystem as a tar archive at
+// path, leaving out the paths matching patterns, and returns the total size of
+// the regular files that were left out
+func (c *Container) exportFiltered(path string, patterns []string) (int64, error) {
+	excludes := exportExcludePatterns(patterns)
+	matcher, err := fileutils.NewPatternMatcher(excludes)
+	if err != nil {
+		return 0, errors.Wrapf(ErrInvalidArg, "invalid export exclusion patterns: %v", err)
+	}
+
+	mountPoint := c.state.Mountpoint
+	if !c.state.Mounted {
+		mount, err := c.runtime.store.Mount(c.ID(), c.config.MountLabel)
+		if err != nil {
+			return 0, errors.Wrapf(err, "error mounting container %q", c.ID())
+		}
+		mountPoint = mount
+		defer func() {
+			if _, err := c.runtime.store.Unmount(c.ID(), false); err != nil {
+				logrus.Errorf("error unmounting container %q: %v", c.ID(), err)
+			}
+		}()
+	}
+
+	saved, err := excludedSize(mountPoint, matcher)
+	if err != nil {
+		return 0, errors.Wrapf(err, "error computing size of excluded files of container %s", c.ID())
+	}
+
+	input, err := archive.TarWithOptions(mountPoint, &archive.TarOptions{
+		Compression:     archive.Uncompressed,
+		ExcludePatterns: excludes,
+	})
+	if err != nil {
+		return 0, errors.Wrapf(err, "error reading container directory %q", c.ID())
+	}
+	defer input.Close()
+
+	outFile, err := os.Create(path)
+	if err != nil {
+		return 0, errors.Wrapf(err, "error creating file %q", path)
+	}
+	defer outFile.Close()
+
+	if _, err := io.Copy(outFile, input); err != nil {
+		return 0, err
+	}
+
+	return saved, nil
+}
+
+// exportExcludePatterns converts export exclusion patterns, which are absolute
+// paths in the container, to patterns relative to the root filesystem, as the
+// archive package expects. If no patterns are given, DefaultExportExcludes are
+// used.
+func exportExcludePatterns(patterns []string) []string {
+	if len(patterns) == 0 {
+		patterns = DefaultExportExcludes
+	}
+
+	excludes := make([]string, 0, len(patterns))
+	for _, pattern := range patterns {
+		pattern = strings.TrimSpace(pattern)
+		prefix := ""
+		if strings.HasPrefix(pattern, "!") {
+			prefix = "!"
+			pattern = pattern[1:]
+		}
+		pattern = strings.TrimLeft(pattern, "/")
+		if pattern == "" {
+			continue
+		}
+		excludes = append(excludes, prefix+pattern)
+	}
+	return excludes
+}
+
+// excludedSize returns the total size of the regular files under root whose
+// paths relative to root match matcher
+func excludedSize(root string, matcher *fileutils.PatternMatcher) (int64, error) {
+	var size int64
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		excluded, err := matcher.Matches(rel)
+		if err != nil {
+			return err
+		}
+		if excluded {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size, err
+}
+
+// exportLayersManifest is the name of the manifest of an export of a
+// container's layers
+const exportLayersManifest = "layers.json"
+
+// ExportLayer describes a layer of a container exported as a tar archive
+type ExportLayer struct {
+	// ID is the ID of the layer in containers/storage
+	ID string `json:"id"`
+	// Digest is the digest of the layer's tar archive, which is named
+	// after its hex part with a .tar extension
+	Digest digest.Digest `json:"digest"`
+	// Size is the size of the layer's tar archive
+	Size int64 `json:"size"`
+}
+
+// ExportLayerManifest lists the layers of an exported container
+type ExportLayerManifest struct {
+	// Layers are the layers of the container, from the base layer of its
+	// image to the container's own layer. Applying them in order
+	// reconstructs the container's root filesystem.
+	Layers []ExportLayer `json:"layers"`
+}
+
+// exportLayers writes each layer of the container, and the manifest listing
+// them, to dir
+func (c *Container) exportLayers(dir string) (*ExportLayerManifest, error) {
+	if c.config.Rootfs != "" {
+		return nil, errors.Wrapf(ErrInvalidArg, "container %s does not use an image, so it has no layers", c.ID())
+	}
+
+	storageCtr, err := c.runtime.store.Container(c.ID())
+	if err != nil {
+		return nil, errors.Wrapf(err, "error retrieving storage for container %s", c.ID())
+	}
+	chain, err := layers.LayerChain(c.runtime.store, storageCtr.LayerID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error retrieving layers of container %s", c.ID())
+	}
+
+	manifest := &ExportLayerManifest{
+		Layers: make([]ExportLayer, 0, len(chain)),
+	}
+	for _, layer := range chain {
+		exported, err := c.exportLayer(dir, layer.Parent, layer.ID)
+		if err != nil {
+			return nil, err
+		}
+		manifest.Layers = append(manifest.Layers, exported)
+	}
+
+	manifestJSON, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, errors.Wrapf(err, "error encoding layer manifest of container %s", c.ID())
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, exportLayersManifest), manifestJSON, 0644); err != nil {
+		return nil, errors.Wrapf(err, "error writing layer manifest of container %s", c.ID())
+	}
+
+	return manifest, nil
+}
+
+// exportLayer writes the uncompressed diff between the layer id and its parent
+// to dir, named after its digest
+func (c *Container) exportLayer(dir, parent, id string) (ExportLayer, error) {
+	uncompressed := archive.Uncompressed
+	diff, err := c.runtime.store.Diff(parent, id, &storage.DiffOptions{Compression: &uncompressed})
+	if err != nil {
+		return ExportLayer{}, errors.Wrapf(err, "error reading layer %s", id)
+	}
+	defer diff.Close()
+
+	tmpFile, err := ioutil.TempFile(dir, ".layer-")
+	if err != nil {
+		return ExportLayer{}, errors.Wrapf(err, "error creating file for layer %s", id)
+	}
+	defer func() {
+		tmpFile.Close()
+		if err := os.Remove(tmpFile.Name()); err != nil && !os.IsNotExist(err) {
+			logrus.Errorf("error removing temporary file %q: %v", tmpFile.Name(), err)
+		}
+	}()
+
+	digester := digest.Canonical.Digester()
+	size, err := io.Copy(io.MultiWriter(tmpFile, digester.Hash()), diff)
+	if err != nil {
+		return ExportLayer{}, errors.Wrapf(err, "error writing layer %s", id)
+	}
+	if err := tmpFile.Close(); err != nil {
+		return ExportLayer{}, errors.Wrapf(err, "error writing layer %s", id)
+	}
+
+	layerDigest := digester.Digest()
+	if err := os.Rename(tmpFile.Name(), filepath.Join(dir, layerDigest.Hex()+".tar")); err != nil {
+		return ExportLayer{}, errors.Wrapf(err, "error writing layer %s", id)
+	}
+
+	return ExportLayer{
+		ID:     id,
+		Digest: layerDigest,
+		Size:   size,
+	}, nil
+}
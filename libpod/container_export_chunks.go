@@ -0,0 +1,173 @@
+package libpod
+
+import (
+	"bufio"
+	"io"
+	"math/rand"
+
+	"github.com/containers/storage/pkg/archive"
+	digest "github.com/opencontainers/go-digest"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// Chunk sizes used when exporting containers as content-defined chunks
+	exportChunkMinSize = 256 * 1024
+	exportChunkAvgSize = 1024 * 1024
+	exportChunkMaxSize = 4 * 1024 * 1024
+)
+
+// gearTable holds the random values the rolling hash used to find chunk
+// boundaries adds for each byte. It must never change, or chunks exported
+// before the change will not be reused.
+var gearTable = func() [256]uint64 {
+	var table [256]uint64
+	r := rand.New(rand.NewSource(0x6c6962706f64))
+	for i := range table {
+		table[i] = r.Uint64()
+	}
+	return table
+}()
+
+// ExportChunk is a content-defined chunk of a container's exported root
+// filesystem
+type ExportChunk struct {
+	// Digest is the digest of the chunk's data
+	Digest digest.Digest `json:"digest"`
+	// Size is the size of the chunk's data in bytes
+	Size int64 `json:"size"`
+}
+
+// ExportChunkManifest lists the chunks a tar archive of a container's root
+// filesystem was split into. Concatenating the chunks' data in order yields
+// the archive.
+type ExportChunkManifest struct {
+	// Chunks are the archive's chunks, in order
+	Chunks []ExportChunk `json:"chunks"`
+	// Size is the size of the archive in bytes
+	Size int64 `json:"size"`
+}
+
+// ExportChunkFunc is called with the digest and data of each chunk of an
+// export that the caller does not have yet.
+// The data is only valid until the function returns.
+type ExportChunkFunc func(dgst digest.Digest, data []byte) error
+
+// exportChunks exports the container's root filesystem as a tar archive split
+// into content-defined chunks. Chunks whose digest is in known, or that were
+// already emitted, are not passed to emit.
+func (c *Container) exportChunks(known map[digest.Digest]bool, emit ExportChunkFunc) (*ExportChunkManifest, error) {
+	mountPoint := c.state.Mountpoint
+	if !c.state.Mounted {
+		mount, err := c.runtime.store.Mount(c.ID(), c.config.MountLabel)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error mounting container %q", c.ID())
+		}
+		mountPoint = mount
+		defer func() {
+			if _, err := c.runtime.store.Unmount(c.ID(), false); err != nil {
+				logrus.Errorf("error unmounting container %q: %v", c.ID(), err)
+			}
+		}()
+	}
+
+	input, err := archive.Tar(mountPoint, archive.Uncompressed)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error reading container directory %q", c.ID())
+	}
+	defer input.Close()
+
+	manifest, err := splitChunks(newChunker(input, exportChunkMinSize, exportChunkAvgSize, exportChunkMaxSize), known, emit)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error exporting chunks of container %s", c.ID())
+	}
+
+	return manifest, nil
+}
+
+// splitChunks reads all chunks from ch, passing the ones not in known to emit,
+// and returns the manifest of the chunks
+func splitChunks(ch *chunker, known map[digest.Digest]bool, emit ExportChunkFunc) (*ExportChunkManifest, error) {
+	emitted := make(map[digest.Digest]bool)
+	manifest := &ExportChunkManifest{
+		Chunks: []ExportChunk{},
+	}
+
+	for {
+		data, err := ch.next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		dgst := digest.FromBytes(data)
+		manifest.Chunks = append(manifest.Chunks, ExportChunk{
+			Digest: dgst,
+			Size:   int64(len(data)),
+		})
+		manifest.Size += int64(len(data))
+
+		if known[dgst] || emitted[dgst] {
+			continue
+		}
+		if err := emit(dgst, data); err != nil {
+			return nil, errors.Wrapf(err, "error emitting chunk %s", dgst)
+		}
+		emitted[dgst] = true
+	}
+
+	return manifest, nil
+}
+
+// chunker splits a stream into content-defined chunks, cutting where a rolling
+// hash of the last bytes read matches a mask. As boundaries depend only on
+// nearby content, an insertion or deletion only changes the chunks around it.
+type chunker struct {
+	reader  *bufio.Reader
+	minSize int
+	maxSize int
+	mask    uint64
+	buf     []byte
+}
+
+// newChunker returns a chunker producing chunks of minSize to maxSize bytes,
+// of avgSize bytes on average. avgSize must be a power of 2.
+func newChunker(r io.Reader, minSize, avgSize, maxSize int) *chunker {
+	return &chunker{
+		reader:  bufio.NewReader(r),
+		minSize: minSize,
+		maxSize: maxSize,
+		mask:    uint64(avgSize - 1),
+		buf:     make([]byte, 0, maxSize),
+	}
+}
+
+// next returns the next chunk of the stream, or io.EOF once it is exhausted
+// The chunk is only valid until next is called again
+func (ch *chunker) next() ([]byte, error) {
+	ch.buf = ch.buf[:0]
+	var hash uint64
+	for len(ch.buf) < ch.maxSize {
+		b, err := ch.reader.ReadByte()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		ch.buf = append(ch.buf, b)
+
+		hash = (hash << 1) + gearTable[b]
+		if len(ch.buf) >= ch.minSize && hash&ch.mask == 0 {
+			break
+		}
+	}
+
+	if len(ch.buf) == 0 {
+		return nil, io.EOF
+	}
+	return ch.buf, nil
+}
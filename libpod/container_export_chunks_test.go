@@ -0,0 +1,50 @@
+package libpod
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+
+	digest "github.com/opencontainers/go-digest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitChunks(t *testing.T) {
+	data := make([]byte, 256*1024)
+	rand.New(rand.NewSource(1)).Read(data)
+
+	var out bytes.Buffer
+	split := func(input []byte, known map[digest.Digest]bool) (*ExportChunkManifest, []digest.Digest) {
+		emitted := []digest.Digest{}
+		manifest, err := splitChunks(newChunker(bytes.NewReader(input), 1024, 4096, 16384), known, func(dgst digest.Digest, chunk []byte) error {
+			assert.Equal(t, digest.FromBytes(chunk), dgst)
+			out.Write(chunk)
+			emitted = append(emitted, dgst)
+			return nil
+		})
+		require.NoError(t, err)
+		return manifest, emitted
+	}
+
+	manifest, emitted := split(data, nil)
+	assert.Equal(t, data, out.Bytes())
+	assert.Equal(t, int64(len(data)), manifest.Size)
+	assert.Len(t, emitted, len(manifest.Chunks))
+	for _, chunk := range manifest.Chunks[:len(manifest.Chunks)-1] {
+		assert.True(t, chunk.Size >= 1024 && chunk.Size <= 16384, "chunk size %d", chunk.Size)
+	}
+
+	// Inserting data at the start only changes the first chunks
+	known := make(map[digest.Digest]bool)
+	for _, chunk := range manifest.Chunks {
+		known[chunk.Digest] = true
+	}
+	changed, emitted := split(append([]byte("inserted"), data...), known)
+	assert.Len(t, changed.Chunks, len(manifest.Chunks))
+	assert.True(t, len(emitted) <= 2, "%d chunks emitted", len(emitted))
+
+	empty, emitted := split(nil, nil)
+	assert.Empty(t, empty.Chunks)
+	assert.Empty(t, emitted)
+}
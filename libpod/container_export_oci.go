@@ -0,0 +1,283 @@
+package libpod
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/containers/storage"
+	"github.com/containers/storage/pkg/archive"
+	"github.com/opencontainers/go-digest"
+	"github.com/opencontainers/image-spec/specs-go"
+	ociv1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// ExportFormat is the format a container is exported in
+type ExportFormat int
+
+const (
+	// ExportFormatTar exports the container's root filesystem as a flat
+	// tar archive, like `docker export`
+	ExportFormatTar ExportFormat = iota
+	// ExportFormatOCILayout exports the container's layers and an image
+	// configuration generated from the container as an OCI image layout
+	// directory, which can be copied with `skopeo copy oci:...`. No image
+	// is added to the container's storage.
+	ExportFormatOCILayout
+)
+
+// exportOCILayout writes the container's layers and an image configuration
+// generated from the container to an OCI image layout at dir, with layers
+// compressed as given. dir must not exist or be empty; if the export fails,
+// what was written to it is removed.
+func (c *Container) exportOCILayout(dir string, compression archive.Compression) (err error) {
+	if c.config.Rootfs != "" {
+		return errors.Wrapf(ErrInvalidArg, "container %s uses an external root filesystem, which has no layers to export", c.ID())
+	}
+
+	layerMediaType := ""
+	switch compression {
+	case archive.Uncompressed:
+		layerMediaType = ociv1.MediaTypeImageLayer
+	case archive.Gzip:
+		layerMediaType = ociv1.MediaTypeImageLayerGzip
+	default:
+		return errors.Wrapf(ErrInvalidArg, "OCI image layouts support uncompressed and gzip compressed layers only")
+	}
+
+	if contents, err := ioutil.ReadDir(dir); err == nil && len(contents) > 0 {
+		return errors.Wrapf(ErrInvalidArg, "export directory %s is not empty", dir)
+	} else if err != nil && !os.IsNotExist(err) {
+		return errors.Wrapf(err, "error accessing export directory %s", dir)
+	}
+	blobsDir := filepath.Join(dir, "blobs", string(digest.Canonical))
+	if err := os.MkdirAll(blobsDir, 0755); err != nil {
+		return errors.Wrapf(err, "error creating export directory %s", dir)
+	}
+	defer func() {
+		if err != nil {
+			for _, name := range []string{"blobs", ociv1.ImageLayoutFile, "index.json"} {
+				if err2 := os.RemoveAll(filepath.Join(dir, name)); err2 != nil {
+					logrus.Errorf("error removing partial export %q: %v", dir, err2)
+				}
+			}
+		}
+	}()
+
+	if !c.state.Mounted {
+		if _, err := c.runtime.store.Mount(c.ID(), c.config.MountLabel); err != nil {
+			return errors.Wrapf(err, "error mounting container %q", c.ID())
+		}
+		defer func() {
+			if _, err := c.runtime.store.Unmount(c.ID(), false); err != nil {
+				logrus.Errorf("error unmounting container %q: %v", c.ID(), err)
+			}
+		}()
+	}
+
+	storageCtr, err := c.runtime.store.Container(c.ID())
+	if err != nil {
+		return errors.Wrapf(err, "error retrieving storage for container %s", c.ID())
+	}
+	layers, err := c.layerChain(storageCtr.LayerID)
+	if err != nil {
+		return err
+	}
+
+	manifest := ociv1.Manifest{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+	}
+	config := c.ociImageConfig()
+	for _, layer := range layers {
+		diffID, desc, err := c.writeOCILayer(blobsDir, layer, compression)
+		if err != nil {
+			return err
+		}
+		desc.MediaType = layerMediaType
+		manifest.Layers = append(manifest.Layers, desc)
+		config.RootFS.DiffIDs = append(config.RootFS.DiffIDs, diffID)
+	}
+
+	manifest.Config, err = writeOCIBlob(blobsDir, config)
+	if err != nil {
+		return errors.Wrapf(err, "error writing image configuration of container %s", c.ID())
+	}
+	manifest.Config.MediaType = ociv1.MediaTypeImageConfig
+
+	manifestDesc, err := writeOCIBlob(blobsDir, manifest)
+	if err != nil {
+		return errors.Wrapf(err, "error writing image manifest of container %s", c.ID())
+	}
+	manifestDesc.MediaType = ociv1.MediaTypeImageManifest
+	manifestDesc.Platform = &ociv1.Platform{
+		Architecture: config.Architecture,
+		OS:           config.OS,
+	}
+	manifestDesc.Annotations = map[string]string{
+		ociv1.AnnotationRefName: c.Name(),
+	}
+
+	index := ociv1.Index{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		Manifests: []ociv1.Descriptor{manifestDesc},
+	}
+	if err := writeJSONFile(filepath.Join(dir, "index.json"), index); err != nil {
+		return errors.Wrapf(err, "error writing image index of container %s", c.ID())
+	}
+	layout := ociv1.ImageLayout{Version: ociv1.ImageLayoutVersion}
+	if err := writeJSONFile(filepath.Join(dir, ociv1.ImageLayoutFile), layout); err != nil {
+		return errors.Wrapf(err, "error writing image layout of container %s", c.ID())
+	}
+
+	return nil
+}
+
+// layerChain returns the layer with the given ID and its parents, from the
+// base layer up
+func (c *Container) layerChain(layerID string) ([]*storage.Layer, error) {
+	var layers []*storage.Layer
+	for id := layerID; id != ""; {
+		layer, err := c.runtime.store.Layer(id)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error retrieving layer %s of container %s", id, c.ID())
+		}
+		layers = append([]*storage.Layer{layer}, layers...)
+		id = layer.Parent
+	}
+	return layers, nil
+}
+
+// writeOCILayer writes the diff of the given layer, compressed as given, to a
+// blob in blobsDir. The digest of the uncompressed diff and the descriptor of
+// the blob are returned.
+func (c *Container) writeOCILayer(blobsDir string, layer *storage.Layer, compression archive.Compression) (digest.Digest, ociv1.Descriptor, error) {
+	uncompressed := archive.Uncompressed
+	diff, err := c.runtime.store.Diff(layer.Parent, layer.ID, &storage.DiffOptions{Compression: &uncompressed})
+	if err != nil {
+		return "", ociv1.Descriptor{}, errors.Wrapf(err, "error reading layer %s of container %s", layer.ID, c.ID())
+	}
+	defer diff.Close()
+
+	blob, err := ioutil.TempFile(blobsDir, "layer")
+	if err != nil {
+		return "", ociv1.Descriptor{}, errors.Wrapf(err, "error creating blob for layer %s", layer.ID)
+	}
+	defer func() {
+		blob.Close()
+		os.Remove(blob.Name())
+	}()
+
+	blobDigester := digest.Canonical.Digester()
+	counter := &byteCounter{}
+	compressor, err := archive.CompressStream(io.MultiWriter(blob, blobDigester.Hash(), counter), compression)
+	if err != nil {
+		return "", ociv1.Descriptor{}, errors.Wrapf(err, "error compressing layer %s", layer.ID)
+	}
+	diffDigester := digest.Canonical.Digester()
+	if _, err := io.Copy(io.MultiWriter(compressor, diffDigester.Hash()), diff); err != nil {
+		compressor.Close()
+		return "", ociv1.Descriptor{}, errors.Wrapf(err, "error writing layer %s of container %s", layer.ID, c.ID())
+	}
+	if err := compressor.Close(); err != nil {
+		return "", ociv1.Descriptor{}, errors.Wrapf(err, "error compressing layer %s", layer.ID)
+	}
+	if err := blob.Close(); err != nil {
+		return "", ociv1.Descriptor{}, errors.Wrapf(err, "error writing blob for layer %s", layer.ID)
+	}
+
+	blobDigest := blobDigester.Digest()
+	if err := os.Rename(blob.Name(), filepath.Join(blobsDir, blobDigest.Hex())); err != nil {
+		return "", ociv1.Descriptor{}, errors.Wrapf(err, "error storing blob for layer %s", layer.ID)
+	}
+
+	return diffDigester.Digest(), ociv1.Descriptor{Digest: blobDigest, Size: counter.n}, nil
+}
+
+// ociImageConfig returns an image configuration generated from the container,
+// without its layers
+func (c *Container) ociImageConfig() *ociv1.Image {
+	created := time.Now().UTC()
+	config := &ociv1.Image{
+		Created:      &created,
+		Architecture: runtime.GOARCH,
+		OS:           runtime.GOOS,
+		Config: ociv1.ImageConfig{
+			User:       c.User(),
+			Entrypoint: c.config.Entrypoint,
+			Cmd:        c.config.Command,
+			Labels:     c.Labels(),
+		},
+		RootFS: ociv1.RootFS{
+			Type:    "layers",
+			DiffIDs: []digest.Digest{},
+		},
+		History: []ociv1.History{{
+			Created:   &created,
+			CreatedBy: fmt.Sprintf("libpod export of container %s", c.ID()),
+		}},
+	}
+	if c.config.Spec.Process != nil {
+		config.Config.Env = c.config.Spec.Process.Env
+		config.Config.WorkingDir = c.config.Spec.Process.Cwd
+	}
+	if len(c.config.PortMappings) > 0 {
+		config.Config.ExposedPorts = make(map[string]struct{})
+		for _, port := range c.config.PortMappings {
+			protocol := strings.ToLower(port.Protocol)
+			if protocol == "" {
+				protocol = "tcp"
+			}
+			config.Config.ExposedPorts[fmt.Sprintf("%d/%s", port.ContainerPort, protocol)] = struct{}{}
+		}
+	}
+	if len(c.config.UserVolumes) > 0 {
+		config.Config.Volumes = make(map[string]struct{})
+		for _, volume := range c.config.UserVolumes {
+			if volume != "" {
+				config.Config.Volumes[volume] = struct{}{}
+			}
+		}
+	}
+	return config
+}
+
+// writeOCIBlob writes v as JSON to a blob in blobsDir and returns its
+// descriptor
+func writeOCIBlob(blobsDir string, v interface{}) (ociv1.Descriptor, error) {
+	contents, err := json.Marshal(v)
+	if err != nil {
+		return ociv1.Descriptor{}, err
+	}
+	blobDigest := digest.FromBytes(contents)
+	if err := ioutil.WriteFile(filepath.Join(blobsDir, blobDigest.Hex()), contents, 0644); err != nil {
+		return ociv1.Descriptor{}, err
+	}
+	return ociv1.Descriptor{Digest: blobDigest, Size: int64(len(contents))}, nil
+}
+
+// writeJSONFile writes v as JSON to path
+func writeJSONFile(path string, v interface{}) error {
+	contents, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, contents, 0644)
+}
+
+// byteCounter counts the bytes written to it
+type byteCounter struct {
+	n int64
+}
+
+func (b *byteCounter) Write(p []byte) (int, error) {
+	b.n += int64(len(p))
+	return len(p), nil
+}
@@ -0,0 +1,176 @@
+package libpod
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/containers/storage/pkg/archive"
+	"github.com/containers/storage/pkg/fileutils"
+	spec "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveRootfsPath(t *testing.T) {
+	dir, err := ioutil.TempDir("", "libpod_test_")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "data", "app"), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "var", "lib"), 0755))
+	require.NoError(t, os.Symlink("/data/app", filepath.Join(dir, "var", "lib", "app")))
+	require.NoError(t, os.Symlink("../../../../..", filepath.Join(dir, "var", "lib", "up")))
+
+	for _, tc := range []struct {
+		path     string
+		expected string
+	}{
+		{"/", "."},
+		{"/data/app", "data/app"},
+		{"data/app/", "data/app"},
+		{"/var/lib/app", "data/app"},
+		{"/var/lib/up/data", "data"},
+		{"/../data", "data"},
+	} {
+		relPath, err := resolveRootfsPath(dir, tc.path)
+		assert.NoError(t, err, tc.path)
+		assert.Equal(t, tc.expected, relPath, tc.path)
+	}
+
+	for _, path := range []string{"..", "../etc", "data/../../etc"} {
+		_, err := resolveRootfsPath(dir, path)
+		assert.Error(t, err, path)
+	}
+}
+
+func TestMksquashfsArgs(t *testing.T) {
+	args, err := mksquashfsArgs("")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"-noappend", "-no-progress", "-xattrs", "-comp", "gzip"}, args)
+
+	args, err = mksquashfsArgs("zstd")
+	assert.NoError(t, err)
+	assert.Equal(t, "zstd", args[len(args)-1])
+
+	_, err = mksquashfsArgs("bzip2")
+	assert.Error(t, err)
+}
+
+func TestSplitChanges(t *testing.T) {
+	exported, deleted := splitChanges([]archive.Change{
+		{Path: "/etc", Kind: archive.ChangeModify},
+		{Path: "/etc/hosts", Kind: archive.ChangeModify},
+		{Path: "/etc/motd", Kind: archive.ChangeAdd},
+		{Path: "/usr/bin/vi", Kind: archive.ChangeDelete},
+		{Path: "/run", Kind: archive.ChangeAdd},
+	})
+	assert.Equal(t, []archive.Change{
+		{Path: "/etc", Kind: archive.ChangeModify},
+		{Path: "/etc/motd", Kind: archive.ChangeAdd},
+	}, exported)
+	assert.Equal(t, []string{"/usr/bin/vi"}, deleted)
+}
+
+func TestExpandExportTemplate(t *testing.T) {
+	data := exportTemplateData{
+		Name:      "web",
+		ShortID:   "0123456789ab",
+		Timestamp: "20181014T120000Z",
+		Ext:       "tar.gz",
+	}
+
+	name, err := expandExportTemplate("{{.Name}}-{{.ShortID}}-{{.Timestamp}}.{{.Ext}}", data)
+	require.NoError(t, err)
+	assert.Equal(t, "web-0123456789ab-20181014T120000Z.tar.gz", name)
+
+	for _, tmpl := range []string{
+		"",
+		"..",
+		"../{{.Name}}.{{.Ext}}",
+		"/tmp/{{.Name}}",
+		"{{.Name}}/{{.Ext}}",
+		"{{.Missing}}",
+		"{{.Name",
+	} {
+		_, err := expandExportTemplate(tmpl, data)
+		assert.Error(t, err, tmpl)
+	}
+}
+
+func TestExportMounts(t *testing.T) {
+	mounts := exportMounts([]spec.Mount{
+		{Destination: "/proc", Type: "proc", Source: "proc"},
+		{Destination: "/data/", Type: "bind", Source: "/srv/data", Options: []string{"rbind", "ro"}},
+		{Destination: "/run/secrets", Type: "bind", Source: "/usr/share/rhel/secrets"},
+		{Destination: "/run/secrets/token", Type: "bind", Source: "/etc/token"},
+		{Destination: "/cache", Type: "bind", Source: "/var/lib/cache"},
+	}, []string{"/cache"})
+	assert.Equal(t, []ExportMount{
+		{Destination: "/data", Type: "bind", Source: "/srv/data", Options: []string{"rbind", "ro"}},
+		{Destination: "/cache", Type: "bind", Source: "/var/lib/cache", Volume: true},
+	}, mounts)
+}
+
+func TestExportExcludePatterns(t *testing.T) {
+	assert.Equal(t, []string{"var/cache/*", "!var/cache/keep", "usr/share/doc"},
+		exportExcludePatterns([]string{"/var/cache/*", "!/var/cache/keep", " /usr/share/doc", "/"}))
+	assert.Equal(t, len(DefaultExportExcludes), len(exportExcludePatterns(nil)))
+}
+
+func TestExcludedSize(t *testing.T) {
+	root, err := ioutil.TempDir("", "libpod_test_")
+	require.NoError(t, err)
+	defer os.RemoveAll(root)
+
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "var/cache/dnf"), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "etc"), 0755))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(root, "var/cache/dnf/pkgs"), make([]byte, 100), 0644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(root, "var/cache/keep"), make([]byte, 10), 0644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(root, "etc/motd"), make([]byte, 1000), 0644))
+
+	matcher, err := fileutils.NewPatternMatcher(exportExcludePatterns([]string{"/var/cache/*", "!/var/cache/keep"}))
+	require.NoError(t, err)
+	size, err := excludedSize(root, matcher)
+	require.NoError(t, err)
+	assert.Equal(t, int64(100), size)
+}
+
+func TestExportPath(t *testing.T) {
+	for _, tc := range []struct {
+		path        string
+		compression archive.Compression
+		expected    string
+	}{
+		{"/tmp/rootfs.tar", archive.Gzip, "/tmp/rootfs.tar.gz"},
+		{"/tmp/rootfs.tar.gz", archive.Xz, "/tmp/rootfs.tar.xz"},
+		{"/tmp/rootfs.tar.bz2", archive.Uncompressed, "/tmp/rootfs.tar"},
+		{"/tmp/rootfs.tgz", archive.Gzip, "/tmp/rootfs.tgz"},
+		{"/tmp/rootfs.tgz", archive.Bzip2, "/tmp/rootfs.tar.bz2"},
+		{"/tmp/rootfs", archive.Gzip, "/tmp/rootfs"},
+		{"/tmp/.tar", archive.Gzip, "/tmp/.tar.gz"},
+	} {
+		path, err := exportPath(tc.path, tc.compression)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.expected, path)
+	}
+
+	_, err := exportPath("/tmp/rootfs.tar", archive.Compression(42))
+	assert.Error(t, err)
+}
+
+func TestWriteOCIBlob(t *testing.T) {
+	dir, err := ioutil.TempDir("", "libpod-oci")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	desc, err := writeOCIBlob(dir, map[string]string{"a": "b"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(`{"a":"b"}`)), desc.Size)
+
+	contents, err := ioutil.ReadFile(filepath.Join(dir, desc.Digest.Hex()))
+	require.NoError(t, err)
+	assert.Equal(t, `{"a":"b"}`, string(contents))
+	assert.NoError(t, desc.Digest.Validate())
+}
@@ -0,0 +1,82 @@
+package libpod
+
+import (
+	"time"
+)
+
+const (
+	// DefaultFSAuditMaxSize is the default size, in bytes, a container's
+	// filesystem audit log may grow to
+	DefaultFSAuditMaxSize = 16 * 1024 * 1024
+	// DefaultFSAuditRate is the default number of filesystem changes per
+	// second recorded in a container's audit log
+	DefaultFSAuditRate = 100
+
+	// fsAuditArtifact is the name of the artifact filesystem changes are
+	// recorded in
+	fsAuditArtifact = "fsaudit.json"
+	// fsAuditCommand is the name of the reexec command that audits a
+	// container's filesystem
+	fsAuditCommand = "libpod-fsaudit"
+)
+
+// fsAuditRecord is a line of a container's filesystem audit log
+type fsAuditRecord struct {
+	// Time is when the change was seen
+	Time time.Time `json:"time"`
+	// Event is "modify" when a file was written to and "close_write" when
+	// a file that was opened for writing was closed.
+	// "dropped" records the number of changes that were not recorded
+	// because of the rate limit, and "limit" that the log reached its
+	// size limit and auditing stopped.
+	Event string `json:"event"`
+	// Path is the path of the file in the container
+	Path string `json:"path,omitempty"`
+	// PID is the host PID of the process that changed the file
+	PID int `json:"pid,omitempty"`
+	// Count is the number of changes that were dropped
+	Count uint64 `json:"count,omitempty"`
+}
+
+// fsAuditLimiter limits the rate of recorded filesystem changes with a token
+// bucket holding up to a second's worth of changes
+type fsAuditLimiter struct {
+	rate    float64
+	tokens  float64
+	last    time.Time
+	dropped uint64
+}
+
+// newFSAuditLimiter returns a limiter allowing rate changes per second
+func newFSAuditLimiter(rate uint, now time.Time) *fsAuditLimiter {
+	return &fsAuditLimiter{
+		rate:   float64(rate),
+		tokens: float64(rate),
+		last:   now,
+	}
+}
+
+// allow returns whether a change seen at now may be recorded, and counts it as
+// dropped if not
+func (l *fsAuditLimiter) allow(now time.Time) bool {
+	if elapsed := now.Sub(l.last).Seconds(); elapsed > 0 {
+		l.tokens += elapsed * l.rate
+		if l.tokens > l.rate {
+			l.tokens = l.rate
+		}
+		l.last = now
+	}
+	if l.tokens < 1 {
+		l.dropped++
+		return false
+	}
+	l.tokens--
+	return true
+}
+
+// takeDropped returns the number of changes dropped since it was last called
+func (l *fsAuditLimiter) takeDropped() uint64 {
+	dropped := l.dropped
+	l.dropped = 0
+	return dropped
+}
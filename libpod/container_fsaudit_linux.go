@@ -0,0 +1,356 @@
+// +build linux
+
+package libpod
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"runtime"
+	"strconv"
+	"syscall"
+	"time"
+	"unsafe"
+
+	"github.com/containers/libpod/pkg/rootless"
+	"github.com/containers/storage/pkg/reexec"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sys/unix"
+)
+
+// fanotify is not in our vendored x/sys yet
+const (
+	fanClassNotif  = 0x0
+	fanCloexec     = 0x1
+	fanNonblock    = 0x2
+	fanMarkAdd     = 0x1
+	fanMarkMount   = 0x10
+	fanModify      = 0x2
+	fanCloseWrite  = 0x8
+	fanQOverflow   = 0x4000
+	fanotifyMetaV3 = 3
+)
+
+// fanotifyEventMetadata is struct fanotify_event_metadata
+type fanotifyEventMetadata struct {
+	EventLen    uint32
+	Vers        uint8
+	Reserved    uint8
+	MetadataLen uint16
+	Mask        uint64
+	Fd          int32
+	Pid         int32
+}
+
+// errFSAuditLimit is returned once the audit log reaches its size limit
+var errFSAuditLimit = errors.New("filesystem audit log size limit reached")
+
+func init() {
+	reexec.Register(fsAuditCommand, fsAuditMain)
+}
+
+// fsAuditMain is the entry point of the process auditing a container's
+// filesystem. Its arguments are the PID of the container's init process, the
+// path of the audit log, its maximum size and the rate of recorded changes.
+func fsAuditMain() {
+	if len(os.Args) != 5 {
+		fmt.Fprintf(os.Stderr, "usage: %s PID LOG MAXSIZE RATE\n", os.Args[0])
+		os.Exit(1)
+	}
+	pid, err := strconv.Atoi(os.Args[1])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid PID %q: %v\n", os.Args[1], err)
+		os.Exit(1)
+	}
+	maxSize, err := strconv.ParseInt(os.Args[3], 10, 64)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid maximum size %q: %v\n", os.Args[3], err)
+		os.Exit(1)
+	}
+	rate, err := strconv.ParseUint(os.Args[4], 10, 32)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid rate %q: %v\n", os.Args[4], err)
+		os.Exit(1)
+	}
+
+	if err := runFSAudit(pid, os.Args[2], maxSize, uint(rate)); err != nil && err != errFSAuditLimit {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
+
+// runFSAudit records changes to files on the root mount of the mount namespace
+// of process pid in the log at logPath, until the process exits or the log
+// reaches maxSize
+func runFSAudit(pid int, logPath string, maxSize int64, rate uint) error {
+	if strconv.IntSize != 64 {
+		return errors.Wrapf(ErrOSNotSupported, "filesystem auditing is only supported on 64-bit systems")
+	}
+
+	// We move the thread between mount namespaces, so keep it to ourselves
+	runtime.LockOSThread()
+
+	hostNS, err := os.Open("/proc/self/ns/mnt")
+	if err != nil {
+		return errors.Wrapf(err, "error opening our mount namespace")
+	}
+	defer hostNS.Close()
+	ctrNS, err := os.Open(fmt.Sprintf("/proc/%d/ns/mnt", pid))
+	if err != nil {
+		return errors.Wrapf(err, "error opening mount namespace of process %d", pid)
+	}
+	defer ctrNS.Close()
+
+	fd, _, errno := unix.Syscall(unix.SYS_FANOTIFY_INIT, fanClassNotif|fanCloexec|fanNonblock, unix.O_RDONLY|unix.O_LARGEFILE|unix.O_CLOEXEC, 0)
+	if errno != 0 {
+		return errors.Wrapf(errno, "error initializing fanotify")
+	}
+	events := int(fd)
+	defer unix.Close(events)
+
+	// Mark the container's root mount from inside its mount namespace, as
+	// the container does not use the mount in ours
+	if err := unix.Unshare(unix.CLONE_FS); err != nil {
+		return errors.Wrapf(err, "error unsharing filesystem attributes")
+	}
+	if err := unix.Setns(int(ctrNS.Fd()), unix.CLONE_NEWNS); err != nil {
+		return errors.Wrapf(err, "error joining mount namespace of process %d", pid)
+	}
+	markErr := fanotifyMarkRoot(events, fanModify|fanCloseWrite)
+	if err := unix.Setns(int(hostNS.Fd()), unix.CLONE_NEWNS); err != nil {
+		return errors.Wrapf(err, "error returning to our mount namespace")
+	}
+	if markErr != nil {
+		return errors.Wrapf(markErr, "error watching root mount of process %d", pid)
+	}
+
+	logFile, err := os.OpenFile(logPath, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return errors.Wrapf(err, "error opening audit log %s", logPath)
+	}
+	defer logFile.Close()
+	info, err := logFile.Stat()
+	if err != nil {
+		return errors.Wrapf(err, "error accessing audit log %s", logPath)
+	}
+	log := &fsAuditLog{
+		file:    logFile,
+		size:    info.Size(),
+		maxSize: maxSize,
+		limiter: newFSAuditLimiter(rate, time.Now()),
+	}
+	if log.size >= maxSize {
+		return errFSAuditLimit
+	}
+
+	buf := make([]byte, 64*1024)
+	for {
+		pollFds := []unix.PollFd{{Fd: int32(events), Events: unix.POLLIN}}
+		if _, err := unix.Poll(pollFds, 1000); err != nil && err != unix.EINTR {
+			return errors.Wrapf(err, "error waiting for fanotify events")
+		}
+
+		n, err := unix.Read(events, buf)
+		if err != nil && err != unix.EAGAIN && err != unix.EINTR {
+			return errors.Wrapf(err, "error reading fanotify events")
+		}
+		if n > 0 {
+			if err := log.recordEvents(buf[:n]); err != nil {
+				return err
+			}
+		}
+
+		if err := log.flushDropped(time.Now()); err != nil {
+			return err
+		}
+
+		// Stop once the container is gone
+		if err := unix.Kill(pid, 0); err == unix.ESRCH {
+			return nil
+		}
+	}
+}
+
+// fanotifyMarkRoot adds a mark for mask to the root mount of our mount
+// namespace
+func fanotifyMarkRoot(events int, mask uint64) error {
+	root, err := unix.BytePtrFromString("/")
+	if err != nil {
+		return err
+	}
+	atFdcwd := unix.AT_FDCWD
+	_, _, errno := unix.Syscall6(unix.SYS_FANOTIFY_MARK, uintptr(events), fanMarkAdd|fanMarkMount, uintptr(mask), uintptr(atFdcwd), uintptr(unsafe.Pointer(root)), 0)
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+// fsAuditLog is a size-capped, rate-limited audit log of filesystem changes
+type fsAuditLog struct {
+	file    *os.File
+	size    int64
+	maxSize int64
+	limiter *fsAuditLimiter
+}
+
+// recordEvents records the fanotify events in buf
+func (l *fsAuditLog) recordEvents(buf []byte) error {
+	metaSize := int(unsafe.Sizeof(fanotifyEventMetadata{}))
+	for len(buf) >= metaSize {
+		meta := (*fanotifyEventMetadata)(unsafe.Pointer(&buf[0]))
+		if meta.Vers != fanotifyMetaV3 {
+			return errors.Errorf("unsupported fanotify metadata version %d", meta.Vers)
+		}
+		if int(meta.EventLen) < metaSize || int(meta.EventLen) > len(buf) {
+			return errors.Errorf("invalid fanotify event length %d", meta.EventLen)
+		}
+		buf = buf[meta.EventLen:]
+
+		now := time.Now()
+		if meta.Mask&fanQOverflow != 0 {
+			// The kernel dropped events; we do not know how many
+			l.limiter.dropped++
+			continue
+		}
+		if meta.Fd < 0 {
+			continue
+		}
+		path, err := os.Readlink(fmt.Sprintf("/proc/self/fd/%d", meta.Fd))
+		unix.Close(int(meta.Fd))
+		if err != nil {
+			continue
+		}
+
+		for _, event := range []struct {
+			mask uint64
+			name string
+		}{{fanModify, "modify"}, {fanCloseWrite, "close_write"}} {
+			if meta.Mask&event.mask == 0 {
+				continue
+			}
+			if !l.limiter.allow(now) {
+				continue
+			}
+			if err := l.flushDropped(now); err != nil {
+				return err
+			}
+			if err := l.write(fsAuditRecord{Time: now, Event: event.name, Path: path, PID: int(meta.Pid)}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// flushDropped records how many changes were dropped since it was last called,
+// if any were
+func (l *fsAuditLog) flushDropped(now time.Time) error {
+	if dropped := l.limiter.takeDropped(); dropped > 0 {
+		return l.write(fsAuditRecord{Time: now, Event: "dropped", Count: dropped})
+	}
+	return nil
+}
+
+// write appends a record to the log, or a record that the log is full and
+// errFSAuditLimit if there is no room for it
+func (l *fsAuditLog) write(record fsAuditRecord) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	full := l.size+int64(len(line)) > l.maxSize
+	if full {
+		line, err = json.Marshal(fsAuditRecord{Time: record.Time, Event: "limit"})
+		if err != nil {
+			return err
+		}
+		line = append(line, '\n')
+	}
+
+	n, err := l.file.Write(line)
+	l.size += int64(n)
+	if err != nil {
+		return errors.Wrapf(err, "error writing audit log")
+	}
+	if full {
+		return errFSAuditLimit
+	}
+	return nil
+}
+
+// fsAuditRunning returns whether the container's filesystem audit process is
+// running
+func (c *Container) fsAuditRunning() bool {
+	if c.state.FSAuditPID == 0 {
+		return false
+	}
+	// Make sure the PID was not reused by an unrelated process
+	cmdline, err := ioutil.ReadFile(fmt.Sprintf("/proc/%d/cmdline", c.state.FSAuditPID))
+	if err != nil {
+		return false
+	}
+	argv0 := cmdline
+	if i := bytes.IndexByte(cmdline, 0); i >= 0 {
+		argv0 = cmdline[:i]
+	}
+	return string(argv0) == fsAuditCommand
+}
+
+// startFSAudit starts auditing the container's filesystem changes, if it is
+// configured to and they are not already being audited
+// The container's state is not saved.
+func (c *Container) startFSAudit() error {
+	if !c.config.FSAudit || c.fsAuditRunning() {
+		return nil
+	}
+	c.state.FSAuditPID = 0
+
+	if rootless.IsRootless() {
+		return errors.Wrapf(ErrOSNotSupported, "filesystem auditing requires root")
+	}
+
+	maxSize := c.config.FSAuditMaxSize
+	if maxSize == 0 {
+		maxSize = DefaultFSAuditMaxSize
+	}
+	rate := c.config.FSAuditRate
+	if rate == 0 {
+		rate = DefaultFSAuditRate
+	}
+
+	cmd := reexec.Command(fsAuditCommand, strconv.Itoa(c.state.PID), c.getArtifactPath(fsAuditArtifact),
+		strconv.FormatInt(maxSize, 10), strconv.FormatUint(uint64(rate), 10))
+	// Outlive us, as the container does
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+	if err := cmd.Start(); err != nil {
+		return errors.Wrapf(err, "error starting filesystem audit of container %s", c.ID())
+	}
+	c.state.FSAuditPID = cmd.Process.Pid
+	go func() {
+		if err := cmd.Wait(); err != nil {
+			logrus.Debugf("Filesystem audit of container %s exited: %v", c.ID(), err)
+		}
+	}()
+
+	logrus.Debugf("Auditing filesystem changes of container %s in process %d", c.ID(), c.state.FSAuditPID)
+
+	return nil
+}
+
+// stopFSAudit stops auditing the container's filesystem changes
+// The container's state is not saved.
+func (c *Container) stopFSAudit() {
+	if c.fsAuditRunning() {
+		if err := unix.Kill(c.state.FSAuditPID, unix.SIGTERM); err != nil && err != unix.ESRCH {
+			logrus.Warnf("Error stopping filesystem audit of container %s: %v", c.ID(), err)
+		}
+	}
+	c.state.FSAuditPID = 0
+}
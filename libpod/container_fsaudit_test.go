@@ -0,0 +1,31 @@
+package libpod
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFSAuditLimiter(t *testing.T) {
+	now := time.Now()
+	limiter := newFSAuditLimiter(2, now)
+
+	assert.True(t, limiter.allow(now))
+	assert.True(t, limiter.allow(now))
+	assert.False(t, limiter.allow(now))
+	assert.False(t, limiter.allow(now))
+	assert.Equal(t, uint64(2), limiter.takeDropped())
+	assert.Equal(t, uint64(0), limiter.takeDropped())
+
+	// Half a second refills one token
+	now = now.Add(500 * time.Millisecond)
+	assert.True(t, limiter.allow(now))
+	assert.False(t, limiter.allow(now))
+
+	// Tokens never exceed a second's worth
+	now = now.Add(time.Minute)
+	assert.True(t, limiter.allow(now))
+	assert.True(t, limiter.allow(now))
+	assert.False(t, limiter.allow(now))
+}
@@ -0,0 +1,14 @@
+// +build !linux
+
+package libpod
+
+func (c *Container) startFSAudit() error {
+	if !c.config.FSAudit {
+		return nil
+	}
+	return ErrOSNotSupported
+}
+
+func (c *Container) stopFSAudit() {
+	c.state.FSAuditPID = 0
+}
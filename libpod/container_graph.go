@@ -1,6 +1,9 @@
 package libpod
 
 import (
+	"sort"
+	"strings"
+
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 )
@@ -19,6 +22,41 @@ type containerGraph struct {
 }
 
 func buildContainerGraph(ctrs []*Container) (*containerGraph, error) {
+	graph, err := newContainerGraph(ctrs)
+	if err != nil {
+		return nil, err
+	}
+
+	// Need to do cycle detection
+	// We cannot start or stop if there are cyclic dependencies
+	cycle, err := detectCycles(graph)
+	if err != nil {
+		return nil, err
+	} else if cycle {
+		return nil, errors.Wrapf(ErrInternal, "cycle found in container dependency graph")
+	}
+
+	return graph, nil
+}
+
+// validateContainerGraph checks that the given containers do not depend on each
+// other in a cycle, and returns the cycle if they do
+func validateContainerGraph(ctrs []*Container) error {
+	graph, err := newContainerGraph(ctrs)
+	if err != nil {
+		return err
+	}
+
+	if cycle := findDependencyCycle(graph); cycle != nil {
+		return errors.Wrapf(ErrCtrDependencyCycle, "containers depend on each other: %s", strings.Join(cycle, " -> "))
+	}
+
+	return nil
+}
+
+// newContainerGraph builds the dependency graph of the given containers,
+// without checking it for cycles
+func newContainerGraph(ctrs []*Container) (*containerGraph, error) {
 	graph := new(containerGraph)
 	graph.nodes = make(map[string]*containerNode)
 	graph.notDependedOnNodes = make(map[string]*containerNode)
@@ -59,16 +97,66 @@ func buildContainerGraph(ctrs []*Container) (*containerGraph, error) {
 		}
 	}
 
-	// Need to do cycle detection
-	// We cannot start or stop if there are cyclic dependencies
-	cycle, err := detectCycles(graph)
-	if err != nil {
-		return nil, err
-	} else if cycle {
-		return nil, errors.Wrapf(ErrInternal, "cycle found in container dependency graph")
+	return graph, nil
+}
+
+// findDependencyCycle returns the IDs of the containers of a cycle in a
+// container graph, starting and ending with the same container, or nil if the
+// graph has no cycles
+func findDependencyCycle(graph *containerGraph) []string {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	states := make(map[string]int, len(graph.nodes))
+	path := make([]string, 0, len(graph.nodes))
+
+	var visit func(*containerNode) []string
+	visit = func(node *containerNode) []string {
+		states[node.id] = visiting
+		path = append(path, node.id)
+
+		for _, dep := range node.dependsOn {
+			switch states[dep.id] {
+			case visiting:
+				// dep is on the path to us, so we found a cycle
+				for i, id := range path {
+					if id == dep.id {
+						cycle := append([]string{}, path[i:]...)
+						return append(cycle, dep.id)
+					}
+				}
+			case unvisited:
+				if cycle := visit(dep); cycle != nil {
+					return cycle
+				}
+			}
+		}
+
+		path = path[:len(path)-1]
+		states[node.id] = visited
+		return nil
 	}
 
-	return graph, nil
+	// Visit nodes in a stable order so the same cycle is reported each time
+	ids := make([]string, 0, len(graph.nodes))
+	for id := range graph.nodes {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		if states[id] != unvisited {
+			continue
+		}
+		if cycle := visit(graph.nodes[id]); cycle != nil {
+			return cycle
+		}
+	}
+
+	return nil
 }
 
 // Detect cycles in a container graph using Tarjan's strongly connected
@@ -5,6 +5,7 @@ import (
 	"os"
 	"testing"
 
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -291,3 +292,43 @@ func TestBuildContainerGraphFourContainersNoneInCycle(t *testing.T) {
 	assert.Equal(t, 2, len(graph.noDepNodes))
 	assert.Equal(t, 2, len(graph.notDependedOnNodes))
 }
+
+func TestValidateContainerGraphReturnsCycle(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", tmpDirPrefix)
+	assert.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	ctr1, err := getTestCtr1(tmpDir)
+	assert.NoError(t, err)
+	ctr2, err := getTestCtr2(tmpDir)
+	assert.NoError(t, err)
+	ctr3, err := getTestCtrN("3", tmpDir)
+	assert.NoError(t, err)
+	ctr1.config.IPCNsCtr = ctr2.config.ID
+	ctr2.config.UserNsCtr = ctr3.config.ID
+	ctr3.config.NetNsCtr = ctr2.config.ID
+
+	graph, err := newContainerGraph([]*Container{ctr1, ctr2, ctr3})
+	assert.NoError(t, err)
+	cycle := findDependencyCycle(graph)
+	assert.Equal(t, 3, len(cycle))
+	assert.Equal(t, cycle[0], cycle[2])
+	assert.NotContains(t, cycle, ctr1.ID())
+
+	err = validateContainerGraph([]*Container{ctr1, ctr2, ctr3})
+	assert.Equal(t, ErrCtrDependencyCycle, errors.Cause(err))
+}
+
+func TestValidateContainerGraphNoCycle(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", tmpDirPrefix)
+	assert.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	ctr1, err := getTestCtr1(tmpDir)
+	assert.NoError(t, err)
+	ctr2, err := getTestCtr2(tmpDir)
+	assert.NoError(t, err)
+	ctr1.config.IPCNsCtr = ctr2.config.ID
+
+	assert.NoError(t, validateContainerGraph([]*Container{ctr1, ctr2}))
+}
@@ -0,0 +1,259 @@
+package libpod
+
+import (
+	"os/exec"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// DefaultHealthInterval is the default time between health checks
+	DefaultHealthInterval = 30 * time.Second
+	// DefaultHealthTimeout is the default time a health check may run
+	DefaultHealthTimeout = 30 * time.Second
+	// DefaultHealthRetries is the default number of failed health checks
+	// in a row after which a container is unhealthy
+	DefaultHealthRetries = 3
+
+	// healthLogMax is the number of health check results kept in a
+	// container's state
+	healthLogMax = 5
+	// healthOutputMax is the number of bytes of a health check's output
+	// kept in its result
+	healthOutputMax = 4096
+)
+
+// healthCheckRegistry tracks the goroutines running the health checks of
+// containers started by this runtime
+type healthCheckRegistry struct {
+	lock sync.Mutex
+	// stops maps the IDs of containers to the channels that stop their
+	// health checks when closed
+	stops map[string]chan struct{}
+}
+
+// healthCheckCommand returns the command a health check runs in the container,
+// or nil if the health check is disabled
+func healthCheckCommand(test []string) ([]string, error) {
+	if len(test) == 0 {
+		return nil, nil
+	}
+	switch test[0] {
+	case "NONE":
+		return nil, nil
+	case "CMD":
+		if len(test) < 2 {
+			return nil, errors.Wrapf(ErrInvalidArg, "health check command must not be empty")
+		}
+		return test[1:], nil
+	case "CMD-SHELL":
+		if len(test) != 2 || test[1] == "" {
+			return nil, errors.Wrapf(ErrInvalidArg, "CMD-SHELL health check takes a single command")
+		}
+		return []string{"/bin/sh", "-c", test[1]}, nil
+	}
+	return nil, errors.Wrapf(ErrInvalidArg, "health check test must start with NONE, CMD or CMD-SHELL, not %q", test[0])
+}
+
+// hasHealthCheck returns whether the container has a health check that is not
+// disabled
+func (c *Container) hasHealthCheck() bool {
+	if c.config.HealthCheck == nil {
+		return false
+	}
+	cmd, err := healthCheckCommand(c.config.HealthCheck.Test)
+	return err == nil && cmd != nil
+}
+
+// healthCheckSettings returns the interval, timeout and retries of the
+// container's health check, with defaults applied
+func (c *Container) healthCheckSettings() (time.Duration, time.Duration, int) {
+	interval, timeout, retries := DefaultHealthInterval, DefaultHealthTimeout, DefaultHealthRetries
+	if c.config.HealthCheck.Interval > 0 {
+		interval = c.config.HealthCheck.Interval
+	}
+	if c.config.HealthCheck.Timeout > 0 {
+		timeout = c.config.HealthCheck.Timeout
+	}
+	if c.config.HealthCheck.Retries > 0 {
+		retries = c.config.HealthCheck.Retries
+	}
+	return interval, timeout, retries
+}
+
+// record adds the result of a health check, updating the container's health
+// Failed checks during the start period do not count towards retries, unless
+// a check already succeeded.
+func (h *HealthState) record(result HealthCheckResult, retries int, inStartPeriod bool) {
+	h.Log = append(h.Log, result)
+	if len(h.Log) > healthLogMax {
+		h.Log = h.Log[len(h.Log)-healthLogMax:]
+	}
+
+	if result.ExitCode == 0 {
+		h.Status = HealthStatusHealthy
+		h.FailingStreak = 0
+		return
+	}
+	if inStartPeriod && h.Status == HealthStatusStarting {
+		return
+	}
+	h.FailingStreak++
+	if h.FailingStreak >= retries {
+		h.Status = HealthStatusUnhealthy
+	}
+}
+
+// startHealthCheck starts running the container's health check periodically,
+// if it has one and it is not already running
+// The checks stop once the container is no longer running.
+func (r *Runtime) startHealthCheck(ctr *Container) {
+	if !ctr.hasHealthCheck() {
+		return
+	}
+
+	r.healthChecks.lock.Lock()
+	defer r.healthChecks.lock.Unlock()
+
+	if _, ok := r.healthChecks.stops[ctr.ID()]; ok {
+		return
+	}
+	if r.healthChecks.stops == nil {
+		r.healthChecks.stops = make(map[string]chan struct{})
+	}
+	stop := make(chan struct{})
+	r.healthChecks.stops[ctr.ID()] = stop
+
+	interval, _, _ := ctr.healthCheckSettings()
+	go r.runHealthChecks(ctr.ID(), interval, stop)
+}
+
+// stopHealthCheck stops running the health check of the container with the
+// given ID, if this runtime runs it
+func (r *Runtime) stopHealthCheck(id string) {
+	r.healthChecks.lock.Lock()
+	defer r.healthChecks.lock.Unlock()
+
+	if stop, ok := r.healthChecks.stops[id]; ok {
+		close(stop)
+		delete(r.healthChecks.stops, id)
+	}
+}
+
+// runHealthChecks runs the health check of the container with the given ID
+// every interval until stop is closed or the container is no longer running
+func (r *Runtime) runHealthChecks(id string, interval time.Duration, stop chan struct{}) {
+	defer func() {
+		r.healthChecks.lock.Lock()
+		defer r.healthChecks.lock.Unlock()
+		if r.healthChecks.stops[id] == stop {
+			delete(r.healthChecks.stops, id)
+		}
+	}()
+
+	// Use our own copy of the container, as its state is changed by the
+	// checks concurrently with the caller's copy
+	ctr, err := r.state.Container(id)
+	if err != nil {
+		logrus.Errorf("Error retrieving container %s to check its health: %v", id, err)
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+
+		running, err := ctr.runHealthCheck()
+		if err != nil {
+			logrus.Errorf("Error checking health of container %s: %v", id, err)
+		}
+		if !running {
+			logrus.Debugf("Container %s is no longer running, stopping its health check", id)
+			return
+		}
+	}
+}
+
+// runHealthCheck runs the container's health check once and records its result
+// in the container's state. It returns whether the container is still
+// running.
+// The container is not locked while the check runs.
+func (c *Container) runHealthCheck() (bool, error) {
+	running, err := c.lockedRunning()
+	if err != nil || !running {
+		return false, err
+	}
+
+	cmd, err := healthCheckCommand(c.config.HealthCheck.Test)
+	if err != nil {
+		return true, err
+	}
+	_, timeout, retries := c.healthCheckSettings()
+
+	result := HealthCheckResult{Start: time.Now()}
+	output, err := c.runtime.ociRuntime.execContainerSyncWithTimeout(c, cmd, timeout)
+	result.End = time.Now()
+	result.ExitCode = healthCheckExitCode(err)
+	result.Output = output
+	if errors.Cause(err) == ErrExecTimeout {
+		result.Output = err.Error()
+	}
+	if len(result.Output) > healthOutputMax {
+		result.Output = result.Output[:healthOutputMax]
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if err := c.syncContainer(); err != nil {
+		return false, err
+	}
+	// Do not record checks that failed because the container stopped
+	if c.state.State != ContainerStateRunning {
+		return false, nil
+	}
+
+	if c.state.Health == nil {
+		c.state.Health = &HealthState{Status: HealthStatusStarting}
+	}
+	inStartPeriod := result.Start.Before(c.state.StartedTime.Add(c.config.HealthCheck.StartPeriod))
+	c.state.Health.record(result, retries, inStartPeriod)
+	if err := c.save(); err != nil {
+		return true, err
+	}
+
+	return true, nil
+}
+
+// lockedRunning syncs the container and returns whether it is running
+func (c *Container) lockedRunning() (bool, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if err := c.syncContainer(); err != nil {
+		return false, err
+	}
+	return c.state.State == ContainerStateRunning, nil
+}
+
+// healthCheckExitCode returns the exit code of a health check command that
+// exited with err
+func healthCheckExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	if exitErr, ok := errors.Cause(err).(*exec.ExitError); ok {
+		if status, ok := exitErr.Sys().(syscall.WaitStatus); ok && status.Exited() {
+			return status.ExitStatus()
+		}
+	}
+	return -1
+}
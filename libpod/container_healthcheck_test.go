@@ -0,0 +1,50 @@
+package libpod
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthCheckCommand(t *testing.T) {
+	cmd, err := healthCheckCommand([]string{"CMD", "curl", "-f", "http://localhost"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"curl", "-f", "http://localhost"}, cmd)
+
+	cmd, err = healthCheckCommand([]string{"CMD-SHELL", "curl -f http://localhost || exit 1"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"/bin/sh", "-c", "curl -f http://localhost || exit 1"}, cmd)
+
+	cmd, err = healthCheckCommand([]string{"NONE"})
+	assert.NoError(t, err)
+	assert.Nil(t, cmd)
+
+	_, err = healthCheckCommand([]string{"CMD"})
+	assert.Equal(t, ErrInvalidArg, errors.Cause(err))
+	_, err = healthCheckCommand([]string{"curl"})
+	assert.Equal(t, ErrInvalidArg, errors.Cause(err))
+}
+
+func TestHealthStateRecord(t *testing.T) {
+	health := &HealthState{Status: HealthStatusStarting}
+
+	// Failures during the start period do not count
+	health.record(HealthCheckResult{ExitCode: 1}, 2, true)
+	assert.Equal(t, HealthStatusStarting, health.Status)
+	assert.Equal(t, 0, health.FailingStreak)
+
+	health.record(HealthCheckResult{ExitCode: 1}, 2, false)
+	assert.Equal(t, HealthStatusStarting, health.Status)
+	health.record(HealthCheckResult{ExitCode: 1}, 2, false)
+	assert.Equal(t, HealthStatusUnhealthy, health.Status)
+	assert.Equal(t, 2, health.FailingStreak)
+
+	for i := 0; i < healthLogMax; i++ {
+		health.record(HealthCheckResult{ExitCode: 0}, 2, false)
+	}
+	assert.Equal(t, HealthStatusHealthy, health.Status)
+	assert.Equal(t, 0, health.FailingStreak)
+	assert.Len(t, health.Log, healthLogMax)
+	assert.Equal(t, 0, health.Log[0].ExitCode)
+}
@@ -0,0 +1,265 @@
+// +build linux
+
+package libpod
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"unsafe"
+
+	"github.com/containers/libpod/pkg/rootless"
+	"github.com/cyphar/filepath-securejoin"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sys/unix"
+)
+
+// The new mount API is not in our vendored x/sys yet. The syscall numbers are
+// the same on all architectures.
+const (
+	sysOpenTree  = 428
+	sysMoveMount = 429
+
+	openTreeClone       = 0x1
+	atRecursive         = 0x8000
+	moveMountFEmptyPath = 0x4
+)
+
+// hotMountOptions are the parsed options of a bind mount added to a running
+// container
+type hotMountOptions struct {
+	recursive bool
+	readOnly  bool
+}
+
+// parseHotMountOptions parses the options of a bind mount added to a running
+// container. Mounts are recursive and read-write by default.
+func parseHotMountOptions(opts []string) (hotMountOptions, error) {
+	options := hotMountOptions{recursive: true}
+	for _, opt := range opts {
+		switch opt {
+		case "bind":
+			options.recursive = false
+		case "rbind":
+			options.recursive = true
+		case "ro":
+			options.readOnly = true
+		case "rw":
+			options.readOnly = false
+		default:
+			return options, errors.Wrapf(ErrInvalidArg, "unsupported mount option %q", opt)
+		}
+	}
+	return options, nil
+}
+
+// validateHotMountDest checks that a bind mount can safely be added at dest in
+// a running container
+func validateHotMountDest(dest string) error {
+	if !filepath.IsAbs(dest) {
+		return errors.Wrapf(ErrInvalidArg, "mount destination %q must be an absolute path", dest)
+	}
+	clean := filepath.Clean(dest)
+	if clean != strings.TrimSuffix(dest, "/") {
+		return errors.Wrapf(ErrInvalidArg, "mount destination %q must be a clean path", dest)
+	}
+	if clean == "/" {
+		return errors.Wrapf(ErrInvalidArg, "cannot mount over the root filesystem of the container")
+	}
+	if containerMounts[clean] {
+		return errors.Wrapf(ErrInvalidArg, "cannot mount over %s, which is managed by libpod", clean)
+	}
+	for _, dir := range []string{"/dev", "/proc", "/sys"} {
+		if strings.HasPrefix(clean, dir+"/") {
+			return errors.Wrapf(ErrInvalidArg, "cannot mount under %s", dir)
+		}
+	}
+	return nil
+}
+
+// addMount bind mounts source from the host onto dest in the mount namespace
+// of the running container, and records it so it is removed with the
+// container's storage
+func (c *Container) addMount(source, dest string, opts []string) error {
+	if c.state.State != ContainerStateRunning && c.state.State != ContainerStatePaused {
+		return errors.Wrapf(ErrCtrStateInvalid, "container %s must be running to add mounts", c.ID())
+	}
+	if rootless.IsRootless() {
+		return errors.Wrapf(ErrOSNotSupported, "cannot add mounts to containers when running rootless")
+	}
+	if c.config.UserNsCtr != "" || len(c.config.IDMappings.UIDMap) > 0 {
+		return errors.Wrapf(ErrNotImplemented, "cannot add mounts to container %s, which uses a user namespace", c.ID())
+	}
+
+	options, err := parseHotMountOptions(opts)
+	if err != nil {
+		return err
+	}
+	if err := validateHotMountDest(dest); err != nil {
+		return err
+	}
+	dest = filepath.Clean(dest)
+	for _, mount := range c.state.HotMounts {
+		if mount == dest {
+			return errors.Wrapf(ErrCtrExists, "a mount was already added on %s in container %s", dest, c.ID())
+		}
+	}
+
+	srcInfo, err := os.Stat(source)
+	if err != nil {
+		return errors.Wrapf(err, "error accessing mount source %s", source)
+	}
+
+	// Resolve the destination inside the container's root filesystem, so
+	// symlinks in the container cannot redirect it
+	root := fmt.Sprintf("/proc/%d/root", c.state.PID)
+	resolved, err := securejoin.SecureJoin(root, dest)
+	if err != nil {
+		return errors.Wrapf(err, "error resolving mount destination %s in container %s", dest, c.ID())
+	}
+	destInfo, err := os.Stat(resolved)
+	if err != nil {
+		return errors.Wrapf(err, "mount destination %s does not exist in container %s", dest, c.ID())
+	}
+	if srcInfo.IsDir() != destInfo.IsDir() {
+		return errors.Wrapf(ErrInvalidArg, "mount source %s and destination %s must both be directories or both be files", source, dest)
+	}
+	rel, err := filepath.Rel(root, resolved)
+	if err != nil {
+		return errors.Wrapf(err, "error resolving mount destination %s in container %s", dest, c.ID())
+	}
+	target := filepath.Join("/", rel)
+
+	// Bind mounts cannot be made across mount namespaces, so clone the
+	// source while we are still in ours and attach the clone in the
+	// container's
+	tree, err := openTree(source, options.recursive)
+	if err != nil {
+		return errors.Wrapf(err, "error cloning mount source %s", source)
+	}
+	defer unix.Close(tree)
+
+	if err := c.inMountNamespace(func() error {
+		if err := moveMount(tree, target); err != nil {
+			return err
+		}
+		if options.readOnly {
+			if err := unix.Mount("", target, "", unix.MS_BIND|unix.MS_REMOUNT|unix.MS_RDONLY, ""); err != nil {
+				if err2 := unix.Unmount(target, unix.MNT_DETACH); err2 != nil {
+					logrus.Errorf("Error unmounting %s: %v", target, err2)
+				}
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return errors.Wrapf(err, "error mounting %s on %s in container %s", source, dest, c.ID())
+	}
+
+	logrus.Debugf("Mounted %s on %s in container %s", source, dest, c.ID())
+
+	c.state.HotMounts = append(c.state.HotMounts, target)
+	return c.save()
+}
+
+// removeHotMounts unmounts the mounts added to the container while it was
+// running. Once the container has stopped, they went away with its mount
+// namespace and are only forgotten.
+func (c *Container) removeHotMounts() {
+	if len(c.state.HotMounts) == 0 {
+		return
+	}
+
+	if c.state.State == ContainerStateRunning || c.state.State == ContainerStatePaused {
+		mounts := c.state.HotMounts
+		if err := c.inMountNamespace(func() error {
+			for i := len(mounts) - 1; i >= 0; i-- {
+				if err := unix.Unmount(mounts[i], unix.MNT_DETACH); err != nil && err != unix.EINVAL && err != unix.ENOENT {
+					logrus.Warnf("container %s failed to unmount %s: %v", c.ID(), mounts[i], err)
+				}
+			}
+			return nil
+		}); err != nil {
+			logrus.Warnf("container %s failed to remove added mounts: %v", c.ID(), err)
+		}
+	}
+
+	c.state.HotMounts = nil
+}
+
+// inMountNamespace runs fn in the mount namespace of the container's init
+// process
+func (c *Container) inMountNamespace(fn func() error) error {
+	nsFile, err := os.Open(fmt.Sprintf("/proc/%d/ns/mnt", c.state.PID))
+	if err != nil {
+		return errors.Wrapf(err, "error opening mount namespace of container %s", c.ID())
+	}
+	defer nsFile.Close()
+
+	errChan := make(chan error, 1)
+	go func() {
+		// The thread is left in the container's mount namespace, so
+		// never unlock it. It is destroyed when this goroutine exits.
+		runtime.LockOSThread()
+
+		// setns into a mount namespace requires a filesystem context
+		// not shared with the rest of the process
+		if err := unix.Unshare(unix.CLONE_FS); err != nil {
+			errChan <- errors.Wrapf(err, "error unsharing filesystem attributes")
+			return
+		}
+		if err := unix.Setns(int(nsFile.Fd()), unix.CLONE_NEWNS); err != nil {
+			errChan <- errors.Wrapf(err, "error joining mount namespace of container %s", c.ID())
+			return
+		}
+		errChan <- fn()
+	}()
+
+	return <-errChan
+}
+
+// openTree returns a file descriptor for a detached copy of the mount tree at
+// path, which can be attached in another mount namespace with moveMount
+func openTree(path string, recursive bool) (int, error) {
+	atFdcwd := unix.AT_FDCWD
+	pathPtr, err := unix.BytePtrFromString(path)
+	if err != nil {
+		return -1, err
+	}
+	flags := openTreeClone | unix.O_CLOEXEC
+	if recursive {
+		flags |= atRecursive
+	}
+	fd, _, errno := unix.Syscall(sysOpenTree, uintptr(atFdcwd), uintptr(unsafe.Pointer(pathPtr)), uintptr(flags))
+	if errno != 0 {
+		if errno == unix.ENOSYS {
+			return -1, errors.Wrapf(ErrOSNotSupported, "adding mounts to running containers requires Linux 5.2 or later")
+		}
+		return -1, errno
+	}
+	return int(fd), nil
+}
+
+// moveMount attaches the detached mount tree tree onto target
+func moveMount(tree int, target string) error {
+	atFdcwd := unix.AT_FDCWD
+	emptyPtr, err := unix.BytePtrFromString("")
+	if err != nil {
+		return err
+	}
+	targetPtr, err := unix.BytePtrFromString(target)
+	if err != nil {
+		return err
+	}
+	_, _, errno := unix.Syscall6(sysMoveMount, uintptr(tree), uintptr(unsafe.Pointer(emptyPtr)), uintptr(atFdcwd), uintptr(unsafe.Pointer(targetPtr)), moveMountFEmptyPath, 0)
+	if errno != 0 {
+		if errno == unix.ENOSYS {
+			return errors.Wrapf(ErrOSNotSupported, "adding mounts to running containers requires Linux 5.2 or later")
+		}
+		return errno
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+package libpod
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseHotMountOptions(t *testing.T) {
+	options, err := parseHotMountOptions(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, hotMountOptions{recursive: true}, options)
+
+	options, err = parseHotMountOptions([]string{"bind", "ro"})
+	assert.NoError(t, err)
+	assert.Equal(t, hotMountOptions{readOnly: true}, options)
+
+	_, err = parseHotMountOptions([]string{"suid"})
+	assert.Error(t, err)
+}
+
+func TestValidateHotMountDest(t *testing.T) {
+	for _, dest := range []string{"/data", "/data/", "/var/lib/app", "/run/app"} {
+		assert.NoError(t, validateHotMountDest(dest), dest)
+	}
+	for _, dest := range []string{"data", "/", "/data/../etc", "/etc/resolv.conf", "/proc/sys", "/dev/sda", "/run/secrets"} {
+		assert.Error(t, validateHotMountDest(dest), dest)
+	}
+}
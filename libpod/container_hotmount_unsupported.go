@@ -0,0 +1,11 @@
+// +build !linux
+
+package libpod
+
+func (c *Container) addMount(source, dest string, opts []string) error {
+	return ErrOSNotSupported
+}
+
+func (c *Container) removeHotMounts() {
+	c.state.HotMounts = nil
+}
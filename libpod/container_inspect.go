@@ -48,6 +48,11 @@ func (c *Container) getContainerInspectData(size bool, driverData *inspect.Data)
 		hostnamePath = getPath
 	}
 
+	startError := ""
+	if c.state.StartError != nil {
+		startError = c.state.StartError.Message
+	}
+
 	data := &inspect.ContainerInspectData{
 		ID:      config.ID,
 		Created: config.CreatedTime,
@@ -62,9 +67,10 @@ func (c *Container) getContainerInspectData(size bool, driverData *inspect.Data)
 			Dead:       runtimeInfo.State.String() == "bad state",
 			Pid:        runtimeInfo.PID,
 			ExitCode:   runtimeInfo.ExitCode,
-			Error:      "", // can't get yet
+			Error:      startError,
 			StartedAt:  runtimeInfo.StartedTime,
 			FinishedAt: runtimeInfo.FinishedTime,
+			PeakMemory: runtimeInfo.PeakMemory,
 		},
 		ImageID:         config.RootfsImageID,
 		ImageName:       config.RootfsImageName,
@@ -107,12 +113,16 @@ func (c *Container) getContainerInspectData(size bool, driverData *inspect.Data)
 			IPv6Gateway:            "",
 			MacAddress:             "", // TODO
 		},
-		IsInfra: c.IsInfra(),
+		IsInfra:    c.IsInfra(),
+		CgroupMode: string(c.CgroupMode()),
+		Timezone:   config.Timezone,
+		// Only the names of the secrets, never their values
+		EnvSecrets: c.EnvSecrets(),
 	}
 
 	// Copy port mappings into network settings
-	if config.PortMappings != nil {
-		data.NetworkSettings.Ports = config.PortMappings
+	if ports := c.portMappings(); ports != nil {
+		data.NetworkSettings.Ports = ports
 	}
 
 	// Get information on the container's network namespace (if present)
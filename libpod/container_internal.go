@@ -8,11 +8,14 @@ import (
 	"github.com/opencontainers/runc/libcontainer/user"
 	"io"
 	"io/ioutil"
+	"math/rand"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"syscall"
+	"time"
 
 	"github.com/containers/buildah/imagebuildah"
 	"github.com/containers/libpod/pkg/hooks"
@@ -25,6 +28,9 @@ import (
 	"github.com/containers/storage/pkg/archive"
 	"github.com/containers/storage/pkg/chrootarchive"
 	"github.com/containers/storage/pkg/mount"
+	"github.com/containers/storage/pkg/stringid"
+	"github.com/cyphar/filepath-securejoin"
+	"github.com/fsnotify/fsnotify"
 	spec "github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/opencontainers/runtime-tools/generate"
 	"github.com/opencontainers/selinux/go-selinux/label"
@@ -36,6 +42,8 @@ import (
 const (
 	// name of the directory holding the artifacts
 	artifactsDir = "artifacts"
+	// name of the artifact directory holding collected core dumps
+	coreDumpsDir = "cores"
 )
 
 var (
@@ -51,7 +59,14 @@ var (
 // A container FS is split into two parts.  The first is the top layer, a
 // mutable layer, and the rest is the RootFS: the set of immutable layers
 // that make up the image on which the container is based.
+// The size of the immutable layers is cached by the runtime.
 func (c *Container) rootFsSize() (int64, error) {
+	return c.rootFsSizeCached(false)
+}
+
+// rootFsSizeCached gets the size of the container's root filesystem, from the
+// runtime's cache unless forceRecompute is set
+func (c *Container) rootFsSizeCached(forceRecompute bool) (int64, error) {
 	if c.config.Rootfs != "" {
 		return 0, nil
 	}
@@ -67,6 +82,13 @@ func (c *Container) rootFsSize() (int64, error) {
 	if err != nil {
 		return 0, err
 	}
+
+	if !forceRecompute {
+		if size, ok := c.runtime.cachedLowerLayersSize(rwLayer.Parent); ok {
+			return size, nil
+		}
+	}
+
 	layer, err := c.runtime.store.Layer(rwLayer.Parent)
 	if err != nil {
 		return 0, err
@@ -88,7 +110,14 @@ func (c *Container) rootFsSize() (int64, error) {
 	// because the parent of the last layer is "", and lstore.Get("")
 	// will return an error.
 	layerSize, err := c.runtime.store.DiffSize(layer.Parent, layer.ID)
-	return size + layerSize, err
+	if err != nil {
+		return size + layerSize, err
+	}
+	size += layerSize
+
+	c.runtime.cacheLowerLayersSize(rwLayer.Parent, size)
+
+	return size, nil
 }
 
 // rwSize Gets the size of the mutable top layer of the container.
@@ -120,6 +149,36 @@ func (c *Container) rwSize() (int64, error) {
 	return c.runtime.store.DiffSize(layer.Parent, layer.ID)
 }
 
+// changes returns the changes to the container's RW layer, sorted by path
+func (c *Container) changes() ([]archive.Change, error) {
+	if c.config.Rootfs != "" {
+		return nil, errors.Wrapf(ErrNotImplemented, "container %s uses an external root filesystem, changes cannot be computed", c.ID())
+	}
+
+	container, err := c.runtime.store.Container(c.ID())
+	if err != nil {
+		return nil, errors.Wrapf(err, "error retrieving storage for container %s", c.ID())
+	}
+	layer, err := c.runtime.store.Layer(container.LayerID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error retrieving layer of container %s", c.ID())
+	}
+
+	changes, err := c.runtime.store.Changes(layer.Parent, layer.ID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error computing changes of container %s", c.ID())
+	}
+	sortChanges(changes)
+	return changes, nil
+}
+
+// sortChanges sorts changes by path
+func sortChanges(changes []archive.Change) {
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Path < changes[j].Path
+	})
+}
+
 // bundlePath returns the path to the container's root filesystem - where the OCI spec will be
 // placed, amongst other things
 func (c *Container) bundlePath() string {
@@ -152,6 +211,7 @@ func (c *Container) execPidPath(sessionID string) string {
 // This function should suffice to ensure a container's state is accurate and
 // it is valid for use.
 func (c *Container) syncContainer() error {
+	c.restartedByPolicy = false
 	if err := c.runtime.state.UpdateContainer(c); err != nil {
 		return err
 	}
@@ -161,27 +221,236 @@ func (c *Container) syncContainer() error {
 		(c.state.State != ContainerStateConfigured) &&
 		(c.state.State != ContainerStateExited) {
 		oldState := c.state.State
+		oldPID := c.state.PID
+		oldDivergentState := c.state.DivergentState
 		// TODO: optionally replace this with a stat for the exit file
 		if err := c.runtime.ociRuntime.updateContainerStatus(c); err != nil {
 			return err
 		}
+		c.handleStateDivergence(oldState, oldPID)
+		// Consult the restart policy if the container just exited
+		oldNextRestart := c.state.NextRestart
+		exited := oldState == ContainerStateRunning &&
+			(c.state.State == ContainerStateStopped || c.state.State == ContainerStateExited)
+		if exited {
+			c.applyRestartPolicy()
+		}
+		// Apply the container's policy if its log can no longer be
+		// written
+		if c.state.State == ContainerStateRunning {
+			if err := c.handleLogFilesystemFull(); err != nil {
+				logrus.Errorf("Error handling full log filesystem of container %s: %v", c.ID(), err)
+			}
+		}
+		// Apply the container's policy if a bind mount source
+		// disappeared
+		oldMissingMountSources := len(c.state.MissingMountSources)
+		if c.state.State == ContainerStateRunning {
+			if err := c.checkMountSources(); err != nil {
+				logrus.Errorf("Error checking mount sources of container %s: %v", c.ID(), err)
+			}
+		}
+		oldLogSplitOffset := c.state.LogSplitOffset
+		if err := c.splitLogStreams(); err != nil {
+			logrus.Errorf("Error splitting log streams of container %s: %v", c.ID(), err)
+		}
+		oldLogJournalOffset := c.state.LogJournalOffset
+		if err := c.forwardLogToJournal(); err != nil {
+			logrus.Errorf("Error sending log of container %s to the journal: %v", c.ID(), err)
+		}
+		rotatedLog, err := c.rotateLog()
+		if err != nil {
+			logrus.Errorf("Error rotating log of container %s: %v", c.ID(), err)
+		}
 		// Only save back to DB if state changed
-		if c.state.State != oldState {
+		if c.state.State != oldState || c.state.LogSplitOffset != oldLogSplitOffset || rotatedLog ||
+			c.state.LogJournalOffset != oldLogJournalOffset ||
+			!c.state.NextRestart.Equal(oldNextRestart) ||
+			len(c.state.MissingMountSources) != oldMissingMountSources ||
+			c.state.DivergentState != oldDivergentState {
 			if err := c.save(); err != nil {
 				return err
 			}
 		}
+		// Remove the container without waiting for its exit command, which
+		// may not be set
+		if exited && c.config.AutoRemove && len(c.state.ExecSessions) == 0 {
+			go c.runtime.autoRemoveExited(c.ID())
+		}
 	}
 
 	if !c.valid {
 		return errors.Wrapf(ErrCtrRemoved, "container %s is not valid", c.ID())
 	}
 
+	if err := c.restartIfDue(); err != nil {
+		logrus.Errorf("Error restarting container %s: %v", c.ID(), err)
+	}
+
+	return nil
+}
+
+// recordStartupTiming records the duration of a phase of the container's setup
+// and start, which began at start, if the container records them
+// The container's state is not saved.
+func (c *Container) recordStartupTiming(phase StartupPhase, start time.Time) {
+	if !c.config.RecordStartupTimings {
+		return
+	}
+	if c.state.StartupTimings == nil {
+		c.state.StartupTimings = make(map[StartupPhase]time.Duration)
+	}
+	c.state.StartupTimings[phase] = time.Since(start)
+}
+
+// nextDelay returns the delay before the restart following one that was delayed
+// by prev, before jitter. A prev of 0 means there was no previous restart.
+func (b *RestartBackoff) nextDelay(prev time.Duration) time.Duration {
+	if prev <= 0 {
+		return b.BaseDelay
+	}
+	next := time.Duration(float64(prev) * b.Multiplier)
+	// Also guards against overflow of the multiplication
+	if next > b.MaxDelay || next < prev {
+		return b.MaxDelay
+	}
+	return next
+}
+
+// jitter shortens delay by the fraction r, between 0 and 1, of the largest
+// jitter the backoff allows
+func (b *RestartBackoff) jitter(delay time.Duration, r float64) time.Duration {
+	return delay - time.Duration(float64(delay)*b.Jitter*r)
+}
+
+// scheduleRestart sets when the container's next restart is due, growing the
+// delay from that of the previous restart
+// The container's state is not saved.
+func (c *Container) scheduleRestart() {
+	backoff := c.config.RestartBackoff
+	if backoff == nil {
+		c.state.RestartDelay = 0
+		c.state.NextRestart = time.Now()
+		return
+	}
+
+	c.state.RestartDelay = backoff.nextDelay(c.state.RestartDelay)
+	c.state.NextRestart = time.Now().Add(backoff.jitter(c.state.RestartDelay, rand.Float64()))
+}
+
+// applyRestartPolicy schedules a restart of the container, which just exited,
+// if its restart policy asks for one
+// A successful exit resets the count of restarts and the restart backoff.
+// The container's state is not saved.
+func (c *Container) applyRestartPolicy() {
+	if c.state.ExitCode == 0 {
+		c.state.RestartCount = 0
+		c.resetRestartBackoff()
+	}
+	if c.state.StoppedByUser {
+		return
+	}
+
+	switch c.RestartPolicy() {
+	case RestartPolicyAlways, RestartPolicyUnlessStopped:
+	case RestartPolicyOnFailure:
+		if c.state.ExitCode == 0 {
+			return
+		}
+		if c.config.RestartRetries > 0 && c.state.RestartCount >= c.config.RestartRetries {
+			logrus.Infof("Container %s failed %d times in a row, not restarting it", c.ID(), c.state.RestartCount+1)
+			return
+		}
+	default:
+		return
+	}
+
+	c.scheduleRestart()
+	logrus.Debugf("Container %s exited with code %d, restart due at %s", c.ID(), c.state.ExitCode, c.state.NextRestart)
+}
+
+// restartIfDue restarts the container under its restart policy if a restart is
+// pending and due
+func (c *Container) restartIfDue() error {
+	if c.state.NextRestart.IsZero() || time.Now().Before(c.state.NextRestart) {
+		return nil
+	}
+	if c.state.State != ContainerStateStopped && c.state.State != ContainerStateExited {
+		return nil
+	}
+
+	logrus.Debugf("Restarting container %s under restart policy %s", c.ID(), c.RestartPolicy())
+
+	ctx := context.Background()
+	c.state.NextRestart = time.Time{}
+	c.state.RestartCount++
+	if err := c.cleanup(ctx); err != nil {
+		return err
+	}
+	if err := c.initAndStart(ctx); err != nil {
+		return err
+	}
+	c.restartedByPolicy = true
+
 	return nil
 }
 
+// resetRestartBackoff forgets the delays of previous restarts, so the next
+// restart is delayed by the base delay again
+// The container's state is not saved.
+func (c *Container) resetRestartBackoff() {
+	c.state.RestartDelay = 0
+	c.state.NextRestart = time.Time{}
+}
+
+// unexpectedStateChange returns whether libpod would not expect the OCI runtime
+// to have moved a container from oldState to newState on its own
+// Containers exit by themselves, but only libpod should start, pause or unpause
+// them.
+func unexpectedStateChange(oldState, newState ContainerStatus) bool {
+	if oldState == newState {
+		return false
+	}
+	return newState != ContainerStateStopped && newState != ContainerStateExited
+}
+
+// handleStateDivergence applies the runtime's state divergence policy if the
+// OCI runtime moved the container from oldState, with oldPID, to a state libpod
+// did not expect
+func (c *Container) handleStateDivergence(oldState ContainerStatus, oldPID int) {
+	newState := c.state.State
+	if !unexpectedStateChange(oldState, newState) {
+		// The divergence resolved itself
+		c.state.DivergentState = ContainerStateUnknown
+		c.state.DivergentPID = 0
+		return
+	}
+
+	switch c.runtime.config.StateDivergencePolicy {
+	case StateDivergencePolicyLog:
+		logrus.Warnf("OCI runtime reports container %s as %s, but it was %s; adopting the OCI runtime's state", c.ID(), newState, oldState)
+	case StateDivergencePolicyEvent:
+		logrus.WithFields(logrus.Fields{
+			"event":     "state-divergence",
+			"container": c.ID(),
+			"expected":  oldState.String(),
+			"actual":    newState.String(),
+		}).Warnf("Container state diverged from OCI runtime")
+	case StateDivergencePolicyRefuse:
+		if c.state.DivergentState != newState {
+			logrus.Errorf("OCI runtime reports container %s as %s, but it was %s; refusing to adopt the OCI runtime's state until it is accepted", c.ID(), newState, oldState)
+		}
+		c.state.DivergentState = newState
+		c.state.DivergentPID = c.state.PID
+		c.state.State = oldState
+		c.state.PID = oldPID
+	}
+}
+
 // Create container root filesystem for use
 func (c *Container) setupStorage(ctx context.Context) error {
+	defer c.recordStartupTiming(StartupPhaseSetupStorage, time.Now())
+
 	if !c.valid {
 		return errors.Wrapf(ErrCtrRemoved, "container %s is not valid", c.ID())
 	}
@@ -195,6 +464,10 @@ func (c *Container) setupStorage(ctx context.Context) error {
 		return errors.Wrapf(ErrInvalidArg, "must provide image ID and image name to use an image")
 	}
 
+	if err := c.checkStorageDriver(); err != nil {
+		return err
+	}
+
 	options := storage.ContainerOptions{
 		IDMappingOptions: storage.IDMappingOptions{
 			HostUIDMapping: true,
@@ -235,6 +508,7 @@ func (c *Container) setupStorage(ctx context.Context) error {
 	c.config.ProcessLabel = containerInfo.ProcessLabel
 	c.config.MountLabel = containerInfo.MountLabel
 	c.config.StaticDir = containerInfo.Dir
+	c.config.StorageDriver = c.runtime.store.GraphDriverName()
 	c.state.RunDir = containerInfo.RunDir
 	c.state.DestinationRunDir = c.state.RunDir
 	if c.state.UserNSRoot != "" {
@@ -249,12 +523,29 @@ func (c *Container) setupStorage(ctx context.Context) error {
 		c.config.Command = containerInfo.Config.Config.Cmd
 	}
 
-	artifacts := filepath.Join(c.config.StaticDir, artifactsDir)
-	if err := os.MkdirAll(artifacts, 0755); err != nil {
-		return errors.Wrapf(err, "error creating artifacts directory %q", artifacts)
+	return c.repairArtifacts()
+}
+
+// checkStorageDriver checks that the container's storage was created with the
+// storage driver the runtime uses, as storage created by another driver cannot
+// be read
+func (c *Container) checkStorageDriver() error {
+	if c.config.StorageDriver == "" {
+		// Created before the driver was recorded, or not created yet
+		return nil
 	}
+	return storageDriverMismatch(c.ID(), c.config.StorageDriver, c.runtime.store.GraphDriverName())
+}
 
-	return nil
+// storageDriverMismatch returns an error explaining how to recover if a
+// container's storage was created with a different driver than the active one
+func storageDriverMismatch(id, created, active string) error {
+	if created == active {
+		return nil
+	}
+	return errors.Wrapf(ErrStorageDriverMismatch,
+		"container %s was created with storage driver %q but the %q driver is in use; switch back to the %q driver to use the container, or remove and recreate it",
+		id, created, active, created)
 }
 
 // Tear down a container's storage prior to removal
@@ -305,6 +596,8 @@ func resetState(state *containerState) error {
 	state.ExecSessions = make(map[string]*ExecSession)
 	state.NetworkStatus = nil
 	state.BindMounts = make(map[string]string)
+	state.PortsClaimed = false
+	state.PortMappings = nil
 
 	return nil
 }
@@ -395,6 +688,52 @@ func (c *Container) removeConmonFiles() error {
 }
 
 func (c *Container) export(path string) error {
+	return c.exportCompressed(path, archive.Uncompressed)
+}
+
+// exportToWriter writes the container's root filesystem as a tar archive to w
+// The caller owns w and is responsible for closing it.
+func (c *Container) exportToWriter(w io.Writer) error {
+	return c.exportCompressedToWriter(w, archive.Uncompressed)
+}
+
+// exportCompressed exports the container's root filesystem as a tar archive
+// at path, compressed with the given compression
+// If the export fails, the partially written file is removed, unless path is
+// not a regular file, such as /dev/stdout.
+func (c *Container) exportCompressed(path string, compression archive.Compression) (err error) {
+	outFile, err := os.Create(path)
+	if err != nil {
+		return errors.Wrapf(err, "error creating file %q", path)
+	}
+	removeOnError := false
+	if info, err := outFile.Stat(); err == nil && info.Mode().IsRegular() {
+		removeOnError = true
+	}
+	defer func() {
+		if err == nil || !removeOnError {
+			return
+		}
+		if err2 := os.Remove(path); err2 != nil && !os.IsNotExist(err2) {
+			logrus.Errorf("error removing partial export %q: %v", path, err2)
+		}
+	}()
+
+	if err := c.exportCompressedToWriter(outFile, compression); err != nil {
+		outFile.Close()
+		return errors.Wrapf(err, "error writing export of container %s to %q", c.ID(), path)
+	}
+	if err := outFile.Close(); err != nil {
+		return errors.Wrapf(err, "error writing export of container %s to %q", c.ID(), path)
+	}
+	return nil
+}
+
+// exportCompressedToWriter writes the container's root filesystem as a tar
+// archive, compressed with the given compression, to w
+// The container's storage is mounted for the export if it is not already, and
+// unmounted again afterwards, whether or not writing to w succeeds.
+func (c *Container) exportCompressedToWriter(w io.Writer, compression archive.Compression) error {
 	mountPoint := c.state.Mountpoint
 	if !c.state.Mounted {
 		mount, err := c.runtime.store.Mount(c.ID(), c.config.MountLabel)
@@ -409,19 +748,401 @@ func (c *Container) export(path string) error {
 		}()
 	}
 
-	input, err := archive.Tar(mountPoint, archive.Uncompressed)
+	input, err := archive.Tar(mountPoint, compression)
 	if err != nil {
 		return errors.Wrapf(err, "error reading container directory %q", c.ID())
 	}
+	defer input.Close()
 
-	outFile, err := os.Create(path)
+	_, err = io.Copy(w, input)
+	return err
+}
+
+// bindMountSources returns the sources of the bind mounts in the given mounts
+func bindMountSources(mounts []spec.Mount) []string {
+	sources := []string{}
+	for _, m := range mounts {
+		isBind := m.Type == "bind"
+		for _, opt := range m.Options {
+			if opt == "bind" || opt == "rbind" {
+				isBind = true
+			}
+		}
+		if isBind {
+			sources = append(sources, m.Source)
+		}
+	}
+	return sources
+}
+
+// checkMountSources applies the container's mount source policy to the sources
+// of the container's bind mounts that have disappeared since they were last
+// checked
+func (c *Container) checkMountSources() error {
+	if c.config.MountSourcePolicy == "" {
+		return nil
+	}
+
+	known := make(map[string]bool, len(c.state.MissingMountSources))
+	for _, src := range c.state.MissingMountSources {
+		known[src] = true
+	}
+
+	newlyMissing := []string{}
+	for _, src := range bindMountSources(c.config.Spec.Mounts) {
+		if known[src] {
+			continue
+		}
+		if _, err := os.Stat(src); err != nil {
+			if !os.IsNotExist(err) {
+				return errors.Wrapf(err, "error accessing mount source %s of container %s", src, c.ID())
+			}
+			newlyMissing = append(newlyMissing, src)
+			known[src] = true
+		}
+	}
+	if len(newlyMissing) == 0 {
+		return nil
+	}
+	c.state.MissingMountSources = append(c.state.MissingMountSources, newlyMissing...)
+
+	policy := c.config.MountSourcePolicy
+	switch policy {
+	case MountSourcePolicyLog:
+		for _, src := range newlyMissing {
+			logrus.Warnf("Source %s of a bind mount of container %s has disappeared", src, c.ID())
+		}
+	case MountSourcePolicyEvent:
+		for _, src := range newlyMissing {
+			logrus.WithFields(logrus.Fields{
+				"event":     "mount-source-missing",
+				"container": c.ID(),
+				"source":    src,
+			}).Warnf("Bind mount source disappeared")
+		}
+	case MountSourcePolicyStop:
+		logrus.Warnf("Sources %s of bind mounts of container %s have disappeared, stopping it", strings.Join(newlyMissing, ", "), c.ID())
+		if err := c.stop(c.config.StopTimeout); err != nil {
+			return err
+		}
+		c.state.StopReason = fmt.Sprintf("bind mount sources disappeared: %s", strings.Join(newlyMissing, ", "))
+	}
+
+	return nil
+}
+
+// autoRemove removes a container that has exited and was cleaned up.
+// If the container cannot be removed, the reason is recorded in its state.
+func (c *Container) autoRemove(ctx context.Context) error {
+	logrus.Debugf("Automatically removing container %s", c.ID())
+
+	removeErr := c.runtime.RemoveContainer(ctx, c, false)
+	if removeErr == nil {
+		return nil
+	}
+
+	logrus.Errorf("Error automatically removing container %s: %v", c.ID(), removeErr)
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if err := c.syncContainer(); err != nil {
+		// The container may have been removed after all
+		logrus.Debugf("Error updating container %s state after failed removal: %v", c.ID(), err)
+		return errors.Wrapf(removeErr, "error automatically removing container %s", c.ID())
+	}
+	c.state.AutoRemoveError = removeErr.Error()
+	if err := c.save(); err != nil {
+		logrus.Errorf("Error saving container %s state: %v", c.ID(), err)
+	}
+
+	return errors.Wrapf(removeErr, "error automatically removing container %s", c.ID())
+}
+
+// autoRemoveExited cleans up and removes the container with the given ID, which
+// was seen exiting and is removed automatically
+// It runs in the background, once the container is no longer locked by the
+// sync that saw it exit. Failures are logged, and recorded in the container's
+// state by autoRemove.
+func (r *Runtime) autoRemoveExited(id string) {
+	ctr, err := r.state.Container(id)
 	if err != nil {
-		return errors.Wrapf(err, "error creating file %q", path)
+		// Its exit command may have removed it already
+		logrus.Debugf("Not automatically removing container %s: %v", id, err)
+		return
 	}
-	defer outFile.Close()
 
-	_, err = io.Copy(outFile, input)
-	return err
+	if err := ctr.Cleanup(context.Background()); err != nil {
+		if cause := errors.Cause(err); cause == ErrNoSuchCtr || cause == ErrCtrRemoved {
+			return
+		}
+		logrus.Errorf("Error automatically removing container %s: %v", id, err)
+	}
+}
+
+// rootfsConsumers returns the IDs of containers that currently have the
+// container's root filesystem mounted
+func (c *Container) rootfsConsumers() ([]string, error) {
+	deps, err := c.runtime.state.ContainerInUse(c)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error retrieving containers depending on container %s", c.ID())
+	}
+
+	consumers := []string{}
+	for _, id := range deps {
+		dep, err := c.runtime.state.Container(id)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error retrieving container %s", id)
+		}
+		dep.lock.Lock()
+		err = c.runtime.state.UpdateContainer(dep)
+		_, mounted := dep.state.RootfsMountSources[c.ID()]
+		dep.lock.Unlock()
+		if err != nil {
+			return nil, errors.Wrapf(err, "error updating container %s state", id)
+		}
+		if mounted {
+			consumers = append(consumers, id)
+		}
+	}
+
+	return consumers, nil
+}
+
+// effectiveUser resolves the user and groups of the container's process the
+// same way the OCI spec is generated
+func (c *Container) effectiveUser() (*UserInfo, error) {
+	mountPoint := c.state.Mountpoint
+	if !c.state.Mounted {
+		mount, err := c.runtime.store.Mount(c.ID(), c.config.MountLabel)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error mounting container %q", c.ID())
+		}
+		mountPoint = mount
+		defer func() {
+			if _, err := c.runtime.store.Unmount(c.ID(), false); err != nil {
+				logrus.Errorf("error unmounting container %q: %v", c.ID(), err)
+			}
+		}()
+	}
+
+	// Without a user set, the UID and GID of the spec are used as-is
+	userSpec := c.config.User
+	if userSpec == "" && c.config.Spec.Process != nil {
+		userSpec = fmt.Sprintf("%d:%d", c.config.Spec.Process.User.UID, c.config.Spec.Process.User.GID)
+	}
+
+	execUser, err := lookup.GetUserGroupInfo(mountPoint, userSpec, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error resolving user %q of container %s", userSpec, c.ID())
+	}
+
+	info := &UserInfo{
+		UID:  uint32(execUser.Uid),
+		GID:  uint32(execUser.Gid),
+		Home: execUser.Home,
+	}
+	if u, err := lookup.GetUser(mountPoint, strconv.Itoa(execUser.Uid)); err == nil {
+		info.Username = u.Name
+	}
+	if g, err := lookup.GetGroup(mountPoint, strconv.Itoa(execUser.Gid)); err == nil {
+		info.Groupname = g.Name
+	}
+
+	if c.config.Spec.Process != nil {
+		info.AdditionalGids = append(info.AdditionalGids, c.config.Spec.Process.User.AdditionalGids...)
+	}
+	if len(c.config.Groups) > 0 {
+		gids, err := lookup.GetContainerGroups(c.config.Groups, mountPoint, nil)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error resolving additional groups of container %s", c.ID())
+		}
+		info.AdditionalGids = append(info.AdditionalGids, gids...)
+	}
+	// The groups the user belongs to are added if a group wasn't directly
+	// specified
+	if !rootless.IsRootless() && !strings.Contains(c.config.User, ":") {
+		for _, gid := range execUser.Sgids {
+			info.AdditionalGids = append(info.AdditionalGids, uint32(gid))
+		}
+	}
+
+	return info, nil
+}
+
+// repairArtifacts ensures the container's artifacts directory exists with the
+// permissions, ownership, and label it was created with
+func (c *Container) repairArtifacts() error {
+	artifacts := filepath.Join(c.config.StaticDir, artifactsDir)
+	if err := os.MkdirAll(artifacts, 0755); err != nil {
+		return errors.Wrapf(err, "error creating artifacts directory %q", artifacts)
+	}
+
+	info, err := os.Stat(artifacts)
+	if err != nil {
+		return errors.Wrapf(err, "error accessing artifacts directory %q", artifacts)
+	}
+	if !info.IsDir() {
+		return errors.Wrapf(ErrInternal, "artifacts path %q of container %s is not a directory", artifacts, c.ID())
+	}
+	if info.Mode().Perm() != 0755 {
+		if err := os.Chmod(artifacts, 0755); err != nil {
+			return errors.Wrapf(err, "error setting permissions of artifacts directory %q", artifacts)
+		}
+	}
+
+	if stat, ok := info.Sys().(*syscall.Stat_t); !ok || int(stat.Uid) != c.RootUID() || int(stat.Gid) != c.RootGID() {
+		if err := os.Chown(artifacts, c.RootUID(), c.RootGID()); err != nil {
+			return errors.Wrapf(err, "error setting ownership of artifacts directory %q", artifacts)
+		}
+	}
+
+	if c.config.MountLabel != "" {
+		if err := label.Relabel(artifacts, c.config.MountLabel, false); err != nil {
+			return errors.Wrapf(err, "error relabeling artifacts directory %q", artifacts)
+		}
+	}
+
+	return nil
+}
+
+// crashSignal returns the signal that killed a container's process, given its
+// exit code, if it is a signal that dumps core
+func crashSignal(exitCode int32) (syscall.Signal, bool) {
+	if exitCode <= 128 {
+		return 0, false
+	}
+
+	sig := syscall.Signal(exitCode - 128)
+	switch sig {
+	case syscall.SIGQUIT, syscall.SIGILL, syscall.SIGTRAP, syscall.SIGABRT,
+		syscall.SIGBUS, syscall.SIGFPE, syscall.SIGSEGV, syscall.SIGSYS:
+		return sig, true
+	}
+
+	return 0, false
+}
+
+// collectCoreDumps moves core dumps the kernel wrote to the working directory
+// of the container's process into the container's artifacts, removing the
+// oldest collected cores to stay within the container's core dump size limit
+func (c *Container) collectCoreDumps() error {
+	if !c.state.Mounted {
+		return errors.Wrapf(ErrCtrStateInvalid, "container %s must be mounted to collect core dumps", c.ID())
+	}
+
+	cwd := "/"
+	if c.config.Spec.Process != nil && c.config.Spec.Process.Cwd != "" {
+		cwd = c.config.Spec.Process.Cwd
+	}
+	cwdPath, err := securejoin.SecureJoin(c.state.Mountpoint, cwd)
+	if err != nil {
+		return errors.Wrapf(err, "error resolving working directory %q", cwd)
+	}
+
+	entries, err := ioutil.ReadDir(cwdPath)
+	if err != nil {
+		return errors.Wrapf(err, "error reading working directory %q", cwd)
+	}
+
+	coresDir := c.getArtifactPath(coreDumpsDir)
+	if err := os.MkdirAll(coresDir, 0700); err != nil {
+		return errors.Wrapf(err, "error creating core dump directory %q", coresDir)
+	}
+
+	for _, entry := range entries {
+		// Only cores written since the container last started
+		if !entry.Mode().IsRegular() || !isCoreFileName(entry.Name()) || entry.ModTime().Before(c.state.StartedTime) {
+			continue
+		}
+		if entry.Size() > c.config.CoreDumpMaxSize {
+			logrus.Warnf("Core dump %s of container %s is larger than the limit of %d bytes, not collecting it", entry.Name(), c.ID(), c.config.CoreDumpMaxSize)
+			continue
+		}
+		if err := pruneCoreDumps(coresDir, c.config.CoreDumpMaxSize-entry.Size()); err != nil {
+			return err
+		}
+
+		src := filepath.Join(cwdPath, entry.Name())
+		dest := filepath.Join(coresDir, fmt.Sprintf("%s-%d", entry.Name(), entry.ModTime().Unix()))
+		if err := moveFile(src, dest); err != nil {
+			return errors.Wrapf(err, "error collecting core dump %s of container %s", entry.Name(), c.ID())
+		}
+		logrus.Debugf("Collected core dump %s of container %s", dest, c.ID())
+	}
+
+	return nil
+}
+
+// isCoreFileName returns whether name is a core file written by the kernel's
+// default core pattern, with or without the PID appended
+func isCoreFileName(name string) bool {
+	if name == "core" {
+		return true
+	}
+	if !strings.HasPrefix(name, "core.") {
+		return false
+	}
+	_, err := strconv.Atoi(strings.TrimPrefix(name, "core."))
+	return err == nil
+}
+
+// pruneCoreDumps removes the oldest core dumps in dir until their total size is
+// at most maxSize
+func pruneCoreDumps(dir string, maxSize int64) error {
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return errors.Wrapf(err, "error reading core dump directory %q", dir)
+	}
+
+	var total int64
+	for _, entry := range entries {
+		total += entry.Size()
+	}
+
+	// Oldest first
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].ModTime().Before(entries[j].ModTime())
+	})
+	for _, entry := range entries {
+		if total <= maxSize {
+			break
+		}
+		if err := os.Remove(filepath.Join(dir, entry.Name())); err != nil {
+			return errors.Wrapf(err, "error removing core dump %q", entry.Name())
+		}
+		total -= entry.Size()
+	}
+
+	return nil
+}
+
+// moveFile moves src to dest, copying it if they are on different filesystems
+func moveFile(src, dest string) error {
+	if err := os.Rename(src, dest); err == nil {
+		return nil
+	}
+
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dest, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		os.Remove(dest)
+		return err
+	}
+	if err := out.Close(); err != nil {
+		os.Remove(dest)
+		return err
+	}
+
+	return os.Remove(src)
 }
 
 // Get path of artifact with a given name for this container
@@ -442,14 +1163,78 @@ func (c *Container) isStopped() (bool, error) {
 	return (c.state.State == ContainerStateStopped || c.state.State == ContainerStateExited), nil
 }
 
+// watchExitFile returns a watcher of the directory conmon writes the
+// container's exit file to, and a channel that is closed once the exit file
+// is created or written. The watcher must be closed by the caller, which
+// closes the channel as well.
+func (c *Container) watchExitFile() (*fsnotify.Watcher, <-chan struct{}, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "error creating watcher for exit file of container %s", c.ID())
+	}
+	if err := watcher.Add(c.runtime.ociRuntime.exitsDir); err != nil {
+		watcher.Close()
+		return nil, nil, errors.Wrapf(err, "error watching exit file of container %s", c.ID())
+	}
+
+	exitFile := c.runtime.ociRuntime.exitFilePath(c)
+	written := make(chan struct{})
+	go func() {
+		defer close(written)
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if event.Name == exitFile && event.Op&(fsnotify.Create|fsnotify.Write) != 0 {
+					return
+				}
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				logrus.Debugf("Error watching exit file of container %s: %v", c.ID(), err)
+			}
+		}
+	}()
+
+	return watcher, written, nil
+}
+
 // save container state to the database
 func (c *Container) save() error {
+	c.state.StateHistory = addStateTransition(c.state.StateHistory, c.state.State, time.Now())
 	if err := c.runtime.state.SaveContainer(c); err != nil {
 		return errors.Wrapf(err, "error saving container %s state", c.ID())
 	}
 	return nil
 }
 
+// addStateTransition records a transition to the given state in history, if the
+// state differs from the last one recorded. Containers start out configured.
+// Only the last maxStateHistory transitions are kept.
+func addStateTransition(history []StateTransition, state ContainerStatus, now time.Time) []StateTransition {
+	from := ContainerStateConfigured
+	if len(history) > 0 {
+		from = history[len(history)-1].To
+	}
+	if from == state {
+		return history
+	}
+
+	history = append(history, StateTransition{
+		From: from,
+		To:   state,
+		Time: now,
+	})
+	if len(history) > maxStateHistory {
+		history = history[len(history)-maxStateHistory:]
+	}
+
+	return history
+}
+
 // Check if a container's dependencies are running
 // Returns a []string containing the IDs of dependencies that are not running
 func (c *Container) checkDependenciesRunning() ([]string, error) {
@@ -523,11 +1308,16 @@ func (c *Container) completeNetworkSetup() error {
 
 // Initialize a container, creating it in the runtime
 func (c *Container) init(ctx context.Context) error {
+	if err := c.checkResources(); err != nil {
+		return err
+	}
+
 	if err := c.makeBindMounts(); err != nil {
 		return err
 	}
 
 	// Generate the OCI spec
+	specStart := time.Now()
 	spec, err := c.generateSpec(ctx)
 	if err != nil {
 		return err
@@ -537,9 +1327,19 @@ func (c *Container) init(ctx context.Context) error {
 	if err := c.saveSpec(spec); err != nil {
 		return err
 	}
+	c.recordStartupTiming(StartupPhaseGenerateSpec, specStart)
 
 	// With the spec complete, do an OCI create
-	if err := c.runtime.ociRuntime.createContainer(c, c.config.CgroupParent, false); err != nil {
+	createStart := time.Now()
+	err = c.withEnvSecrets(spec, func() error {
+		return c.runtime.ociRuntime.createContainer(c, c.config.CgroupParent, false)
+	})
+	c.recordStartupTiming(StartupPhaseOCICreate, createStart)
+	if err != nil {
+		// Save the reason creation failed
+		if err2 := c.save(); err2 != nil {
+			logrus.Errorf("Error saving container %s state: %v", c.ID(), err2)
+		}
 		return err
 	}
 
@@ -547,6 +1347,11 @@ func (c *Container) init(ctx context.Context) error {
 
 	c.state.ExitCode = 0
 	c.state.Exited = false
+	c.state.ExitCodeSource = ""
+	c.state.PeakMemory = 0
+	c.state.StopReason = ""
+	c.state.AutoRemoveError = ""
+	c.state.MissingMountSources = nil
 	c.state.State = ContainerStateCreated
 
 	if err := c.save(); err != nil {
@@ -623,7 +1428,7 @@ func (c *Container) initAndStart(ctx context.Context) (err error) {
 		return errors.Wrapf(ErrCtrStateInvalid, "cannot start paused container %s", c.ID())
 	}
 
-	if err := c.prepare(); err != nil {
+	if err := c.prepare(ctx); err != nil {
 		return err
 	}
 	defer func() {
@@ -655,20 +1460,81 @@ func (c *Container) initAndStart(ctx context.Context) (err error) {
 
 // Internal, non-locking function to start a container
 func (c *Container) start() error {
-	if err := c.runtime.ociRuntime.startContainer(c); err != nil {
+	ociStart := time.Now()
+	err := c.startWithTimeout()
+	c.recordStartupTiming(StartupPhaseOCIStart, ociStart)
+	if err != nil {
+		// Save the reason the start failed
+		if err2 := c.save(); err2 != nil {
+			logrus.Errorf("Error saving container %s state: %v", c.ID(), err2)
+		}
 		return err
 	}
 	logrus.Debugf("Started container %s", c.ID())
 
 	c.state.State = ContainerStateRunning
+	c.state.StoppedByUser = false
+	c.state.NextRestart = time.Time{}
+	if c.hasHealthCheck() {
+		c.state.Health = &HealthState{Status: HealthStatusStarting}
+	}
 
-	return c.save()
+	// The audit is monitoring only, so it failing does not stop the container
+	if err := c.startFSAudit(); err != nil {
+		logrus.Errorf("Error auditing filesystem changes of container %s: %v", c.ID(), err)
+	}
+
+	if err := c.save(); err != nil {
+		return err
+	}
+
+	c.runtime.startHealthCheck(c)
+
+	return nil
+}
+
+// startWithTimeout starts the container in the OCI runtime, killing it if it
+// does not start within the container's startup timeout
+func (c *Container) startWithTimeout() error {
+	if c.config.StartupTimeout == 0 {
+		return c.runtime.ociRuntime.startContainer(c)
+	}
+
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- c.runtime.ociRuntime.startContainer(c)
+	}()
+
+	timeout := time.Duration(c.config.StartupTimeout) * time.Second
+	select {
+	case err := <-errChan:
+		return err
+	case <-time.After(timeout):
+	}
+
+	logrus.Warnf("Container %s did not start within %s, killing it", c.ID(), timeout)
+	if err := c.runtime.ociRuntime.killContainer(c, 9); err != nil {
+		logrus.Errorf("Error killing container %s after startup timeout: %v", c.ID(), err)
+	}
+	// Wait for the OCI runtime to give up on the start, so it is no longer
+	// touching the container's state
+	if err := <-errChan; err != nil {
+		logrus.Debugf("Start of container %s failed after startup timeout: %v", c.ID(), err)
+	}
+
+	c.state.StopReason = fmt.Sprintf("startup timeout: container did not start within %s", timeout)
+	if err := c.runtime.ociRuntime.updateContainerStatus(c); err != nil {
+		logrus.Errorf("Error updating container %s status: %v", c.ID(), err)
+	}
+	return errors.Wrapf(ErrCtrStartupTimeout, "container %s did not start within %s", c.ID(), timeout)
 }
 
 // Internal, non-locking function to stop container
 func (c *Container) stop(timeout uint) error {
 	logrus.Debugf("Stopping ctr %s with timeout %d", c.ID(), timeout)
 
+	c.runtime.stopHealthCheck(c.ID())
+
 	if err := c.runtime.ociRuntime.stopContainer(c, timeout); err != nil {
 		return err
 	}
@@ -678,6 +1544,15 @@ func (c *Container) stop(timeout uint) error {
 		return err
 	}
 
+	// The restart policy does not apply to containers stopped on request
+	c.state.StoppedByUser = true
+	c.state.NextRestart = time.Time{}
+	if c.valid {
+		if err := c.save(); err != nil {
+			return err
+		}
+	}
+
 	// Container should clean itself up
 	return nil
 }
@@ -719,7 +1594,7 @@ func (c *Container) restartWithTimeout(ctx context.Context, timeout uint) (err e
 			return err
 		}
 	}
-	if err := c.prepare(); err != nil {
+	if err := c.prepare(ctx); err != nil {
 		return err
 	}
 	defer func() {
@@ -746,38 +1621,99 @@ func (c *Container) restartWithTimeout(ctx context.Context, timeout uint) (err e
 	return c.start()
 }
 
+// shmOptions returns the mount options of a container's SHM tmpfs of the given
+// mode and size. A mode of 0 selects the default, 1777.
+func shmOptions(mode os.FileMode, size int64) string {
+	if mode == 0 {
+		return fmt.Sprintf("mode=1777,size=%d", size)
+	}
+
+	perm := uint32(mode.Perm())
+	if mode&os.ModeSetuid != 0 {
+		perm |= 04000
+	}
+	if mode&os.ModeSetgid != 0 {
+		perm |= 02000
+	}
+	if mode&os.ModeSticky != 0 {
+		perm |= 01000
+	}
+	return fmt.Sprintf("mode=%o,size=%d", perm, size)
+}
+
 // mountStorage sets up the container's root filesystem
 // It mounts the image and any other requested mounts
 // TODO: Add ability to override mount label so we can use this for Mount() too
 // TODO: Can we use this for export? Copying SHM into the export might not be
 // good
-func (c *Container) mountStorage() (string, error) {
-	var err error
+// The container's mounts are mounted before its root filesystem; if mounting
+// any of them fails, the ones already mounted are unmounted again.
+func (c *Container) mountStorage(ctx context.Context) (_ string, err error) {
 	// Container already mounted, nothing to do
 	if c.state.Mounted {
 		return c.state.Mountpoint, nil
 	}
 
+	defer c.recordStartupTiming(StartupPhaseMountStorage, time.Now())
+
+	if err := c.checkStorageDriver(); err != nil {
+		return "", err
+	}
+
+	// Limit how many containers are mounted at the same time
+	if err := c.runtime.acquireMountSlot(ctx); err != nil {
+		return "", errors.Wrapf(err, "error mounting storage for container %s", c.ID())
+	}
+	defer c.runtime.releaseMountSlot()
+
 	if !rootless.IsRootless() {
-		// TODO: generalize this mount code so it will mount every mount in ctr.config.Mounts
-		mounted, err := mount.Mounted(c.config.ShmDir)
+		var mounted bool
+		mounted, err = mount.Mounted(c.config.ShmDir)
 		if err != nil {
 			return "", errors.Wrapf(err, "unable to determine if %q is mounted", c.config.ShmDir)
 		}
 
-		if err := os.Chown(c.config.ShmDir, c.RootUID(), c.RootGID()); err != nil {
-			return "", errors.Wrapf(err, "failed to chown %s", c.config.ShmDir)
-		}
-
-		if !mounted {
-			shmOptions := fmt.Sprintf("mode=1777,size=%d", c.config.ShmSize)
-			if err := c.mountSHM(shmOptions); err != nil {
-				return "", err
+		if c.config.UseHostShm {
+			// The host's /dev/shm is used as it is, so we do not chown it
+			if !mounted {
+				if err := c.mountHostSHM(); err != nil {
+					return "", err
+				}
 			}
+		} else {
 			if err := os.Chown(c.config.ShmDir, c.RootUID(), c.RootGID()); err != nil {
 				return "", errors.Wrapf(err, "failed to chown %s", c.config.ShmDir)
 			}
+
+			if !mounted {
+				if err := c.mountSHM(shmOptions(c.config.ShmMode, c.config.ShmSize)); err != nil {
+					return "", err
+				}
+				if err := os.Chown(c.config.ShmDir, c.RootUID(), c.RootGID()); err != nil {
+					return "", errors.Wrapf(err, "failed to chown %s", c.config.ShmDir)
+				}
+			}
+		}
+		if !mounted {
+			defer func() {
+				if err != nil {
+					if err2 := c.unmountSHM(c.config.ShmDir); err2 != nil {
+						logrus.Errorf("Error unmounting SHM of container %s: %v", c.ID(), err2)
+					}
+				}
+			}()
+		}
+
+		if err := c.mountStorageMounts(); err != nil {
+			return "", err
 		}
+		defer func() {
+			if err != nil {
+				c.unmountMounts(c.config.ShmDir)
+			}
+		}()
+	} else if len(c.config.StorageMounts) > 0 {
+		logrus.Warnf("Not mounting the storage mounts of container %s as a non-root user", c.ID())
 	}
 
 	mountPoint := c.config.Rootfs
@@ -786,11 +1722,196 @@ func (c *Container) mountStorage() (string, error) {
 		if err != nil {
 			return "", err
 		}
+		if c.config.ReadOnlyRootfs {
+			if err := c.makeRootfsReadOnly(mountPoint); err != nil {
+				if err2 := c.unmount(false); err2 != nil {
+					logrus.Errorf("Error unmounting storage for container %s: %v", c.ID(), err2)
+				}
+				return "", err
+			}
+		}
+	}
+
+	if err := c.mountRootfsSources(); err != nil {
+		if mountPoint != c.config.Rootfs {
+			if err2 := c.unmount(false); err2 != nil {
+				logrus.Errorf("Error unmounting storage for container %s: %v", c.ID(), err2)
+			}
+		}
+		return "", err
+	}
+
+	if err := c.mountNamedVolumes(ctx); err != nil {
+		c.releaseRootfsSources()
+		if mountPoint != c.config.Rootfs {
+			if err2 := c.unmount(false); err2 != nil {
+				logrus.Errorf("Error unmounting storage for container %s: %v", c.ID(), err2)
+			}
+		}
+		return "", err
 	}
 
 	return mountPoint, nil
 }
 
+// mountNamedVolumes creates the named volumes of the container which do not
+// exist yet and mounts the filesystems of those backed by one
+func (c *Container) mountNamedVolumes(ctx context.Context) error {
+	for _, vol := range c.config.NamedVolumes {
+		volume, err := c.runtime.state.Volume(vol.Name)
+		if err != nil && errors.Cause(err) == ErrNoSuchVolume {
+			volume, err = c.runtime.newVolume(ctx, WithVolumeName(vol.Name))
+			if err == nil {
+				if err := os.Chown(volume.MountPoint(), c.RootUID(), c.RootGID()); err != nil {
+					c.releaseNamedVolumes()
+					return errors.Wrapf(err, "failed to chown volume %s", vol.Name)
+				}
+			} else if errors.Cause(err) == ErrVolumeExists {
+				// Another container created it first
+				volume, err = c.runtime.state.Volume(vol.Name)
+			}
+		}
+		if err != nil {
+			c.releaseNamedVolumes()
+			return errors.Wrapf(err, "error retrieving volume %s of container %s", vol.Name, c.ID())
+		}
+
+		volume.lock.Lock()
+		err = volume.mount()
+		volume.lock.Unlock()
+		if err != nil {
+			c.releaseNamedVolumes()
+			return err
+		}
+	}
+
+	return nil
+}
+
+// releaseNamedVolumes unmounts the filesystems backing the container's named
+// volumes which no other mounted container uses
+// The data of the volumes is left alone
+func (c *Container) releaseNamedVolumes() {
+	for _, vol := range c.config.NamedVolumes {
+		volume, err := c.runtime.state.Volume(vol.Name)
+		if err != nil {
+			logrus.Debugf("Not releasing volume %s of container %s: %v", vol.Name, c.ID(), err)
+			continue
+		}
+		if volume.config.Options["type"] == "" {
+			continue
+		}
+
+		volume.lock.Lock()
+		if !c.volumeUsedByOthers(volume) {
+			if err := volume.unmount(); err != nil {
+				logrus.Errorf("Error releasing volume %s of container %s: %v", vol.Name, c.ID(), err)
+			}
+		}
+		volume.lock.Unlock()
+	}
+}
+
+// volumeUsedByOthers returns whether other containers using the volume are
+// mounted. A container whose state cannot be determined counts as mounted.
+func (c *Container) volumeUsedByOthers(volume *Volume) bool {
+	ctrIDs, err := c.runtime.state.VolumeInUse(volume)
+	if err != nil {
+		logrus.Errorf("Error retrieving containers using volume %s: %v", volume.Name(), err)
+		return true
+	}
+
+	for _, id := range ctrIDs {
+		if id == c.ID() {
+			continue
+		}
+		ctr, err := c.runtime.state.Container(id)
+		if err != nil {
+			return true
+		}
+		if err := c.runtime.state.UpdateContainer(ctr); err != nil {
+			return true
+		}
+		if ctr.state.Mounted {
+			return true
+		}
+	}
+
+	return false
+}
+
+// mountRootfsSources mounts the root filesystems of the containers listed in
+// the container's RootfsMounts, taking a reference on each mount
+func (c *Container) mountRootfsSources() error {
+	if len(c.config.RootfsMounts) == 0 {
+		return nil
+	}
+	if c.state.RootfsMountSources == nil {
+		c.state.RootfsMountSources = make(map[string]string)
+	}
+
+	for _, m := range c.config.RootfsMounts {
+		if _, ok := c.state.RootfsMountSources[m.Container]; ok {
+			continue
+		}
+
+		provider, err := c.runtime.state.Container(m.Container)
+		if err != nil {
+			c.releaseRootfsSources()
+			return errors.Wrapf(err, "error retrieving container %s whose root filesystem is mounted into container %s", m.Container, c.ID())
+		}
+
+		// Containers with a user-provided rootfs aren't mounted through
+		// c/storage, so there is no reference to take
+		if provider.config.Rootfs != "" {
+			c.state.RootfsMountSources[m.Container] = provider.config.Rootfs
+			continue
+		}
+
+		mountPoint, err := provider.mount()
+		if err != nil {
+			c.releaseRootfsSources()
+			return errors.Wrapf(err, "error mounting root filesystem of container %s into container %s", m.Container, c.ID())
+		}
+		c.state.RootfsMountSources[m.Container] = mountPoint
+	}
+
+	return nil
+}
+
+// releaseRootfsSources drops the references the container holds on the mounts
+// of other containers' root filesystems
+func (c *Container) releaseRootfsSources() {
+	for id := range c.state.RootfsMountSources {
+		provider, err := c.runtime.state.Container(id)
+		if err != nil {
+			logrus.Errorf("Error retrieving container %s to unmount its root filesystem from container %s: %v", id, c.ID(), err)
+			delete(c.state.RootfsMountSources, id)
+			continue
+		}
+
+		if provider.config.Rootfs == "" {
+			if err := provider.unmount(false); err != nil {
+				logrus.Errorf("Error unmounting root filesystem of container %s from container %s: %v", id, c.ID(), err)
+			}
+		}
+		delete(c.state.RootfsMountSources, id)
+	}
+}
+
+// unmountMounts unmounts the container's mounts in the reverse order of the one
+// they were added in, except for skip
+func (c *Container) unmountMounts(skip string) {
+	for i := len(c.config.Mounts) - 1; i >= 0; i-- {
+		if c.config.Mounts[i] == skip {
+			continue
+		}
+		if err := c.unmountSHM(c.config.Mounts[i]); err != nil {
+			logrus.Errorf("Error unmounting %s of container %s: %v", c.config.Mounts[i], c.ID(), err)
+		}
+	}
+}
+
 // cleanupStorage unmounts and cleans up the container's root filesystem
 func (c *Container) cleanupStorage() error {
 	if !c.state.Mounted {
@@ -798,15 +1919,25 @@ func (c *Container) cleanupStorage() error {
 		logrus.Debugf("Storage is already unmounted, skipping...")
 		return nil
 	}
-	for _, mount := range c.config.Mounts {
-		if err := c.unmountSHM(mount); err != nil {
-			return err
-		}
-	}
+	c.unmountMounts("")
+	c.removeHotMounts()
+	c.releaseRootfsSources()
+	c.releaseNamedVolumes()
+	c.removeRunDirFiles()
 	if c.config.Rootfs != "" {
+		// Save the files removed from the run directory
+		if c.valid {
+			return c.save()
+		}
 		return nil
 	}
 
+	if c.config.ReadOnlyRootfs && c.state.Mountpoint != "" {
+		if err := c.makeRootfsWritable(c.state.Mountpoint); err != nil {
+			logrus.Warnf("%v", err)
+		}
+	}
+
 	if err := c.unmount(false); err != nil {
 		// If the container has already been removed, warn but don't
 		// error
@@ -835,11 +1966,15 @@ func (c *Container) cleanup(ctx context.Context) error {
 
 	logrus.Debugf("Cleaning up container %s", c.ID())
 
+	c.stopFSAudit()
+
 	// Clean up network namespace, if present
 	if err := c.cleanupNetwork(); err != nil {
 		lastError = err
 	}
 
+	c.runtime.releaseHostPorts(c)
+
 	// Unmount storage
 	if err := c.cleanupStorage(); err != nil {
 		if lastError != nil {
@@ -858,6 +1993,15 @@ func (c *Container) cleanup(ctx context.Context) error {
 		}
 	}
 
+	// Remove any cgroups we created that the OCI runtime left behind
+	if err := c.cleanupCgroups(); err != nil {
+		if lastError != nil {
+			logrus.Errorf("Error removing container %s cgroups: %v", c.ID(), err)
+		} else {
+			lastError = err
+		}
+	}
+
 	return lastError
 }
 
@@ -965,6 +2109,15 @@ func (c *Container) makeBindMounts() error {
 	}
 	c.state.BindMounts["/etc/hosts"] = newHosts
 
+	// Make /etc/localtime
+	if c.config.Timezone != "" {
+		localtimePath, err := c.generateLocaltime()
+		if err != nil {
+			return errors.Wrapf(err, "error creating localtime file for container %s", c.ID())
+		}
+		c.state.BindMounts["/etc/localtime"] = localtimePath
+	}
+
 	// Make /etc/hostname
 	// This should never change, so no need to recreate if it exists
 	if _, ok := c.state.BindMounts["/etc/hostname"]; !ok {
@@ -975,6 +2128,16 @@ func (c *Container) makeBindMounts() error {
 		c.state.BindMounts["/etc/hostname"] = hostnamePath
 	}
 
+	// Make /etc/machine-id
+	// This should never change, so no need to recreate if it exists
+	if _, ok := c.state.BindMounts["/etc/machine-id"]; !ok && c.MachineIDMode() != MachineIDModeNone {
+		machineIDPath, err := c.generateMachineID()
+		if err != nil {
+			return errors.Wrapf(err, "error creating machine-id file for container %s", c.ID())
+		}
+		c.state.BindMounts["/etc/machine-id"] = machineIDPath
+	}
+
 	// Make .containerenv
 	// Empty file, so no need to recreate if it exists
 	if _, ok := c.state.BindMounts["/run/.containerenv"]; !ok {
@@ -992,14 +2155,56 @@ func (c *Container) makeBindMounts() error {
 		if _, ok := c.state.BindMounts[mount.Destination]; !ok {
 			c.state.BindMounts[mount.Destination] = mount.Source
 		}
+		if rel, err := filepath.Rel(c.state.DestinationRunDir, mount.Source); err == nil && !strings.HasPrefix(rel, "..") {
+			c.trackRunDirFile(rel)
+		}
 	}
 
 	return nil
 }
 
+// trackRunDirFile records that libpod created the given file or directory,
+// relative to the container's run directory
+func (c *Container) trackRunDirFile(name string) {
+	for _, file := range c.state.RunDirFiles {
+		if file == name {
+			return
+		}
+	}
+	c.state.RunDirFiles = append(c.state.RunDirFiles, name)
+}
+
+// removeRunDirFiles removes the files and directories libpod created in the
+// container's run directory, and forgets the bind mounts of those files so
+// they are created again when the container is next started
+func (c *Container) removeRunDirFiles() {
+	if len(c.state.RunDirFiles) == 0 {
+		return
+	}
+
+	removed := make(map[string]bool, len(c.state.RunDirFiles))
+	remaining := []string{}
+	for _, name := range c.state.RunDirFiles {
+		if err := os.RemoveAll(filepath.Join(c.state.RunDir, name)); err != nil {
+			logrus.Errorf("Error removing %s from run directory of container %s: %v", name, c.ID(), err)
+			remaining = append(remaining, name)
+			continue
+		}
+		removed[filepath.Join(c.state.DestinationRunDir, name)] = true
+	}
+
+	for dest, src := range c.state.BindMounts {
+		if removed[src] {
+			delete(c.state.BindMounts, dest)
+		}
+	}
+	c.state.RunDirFiles = remaining
+}
+
 // writeStringToRundir copies the provided file to the runtimedir
 func (c *Container) writeStringToRundir(destFile, output string) (string, error) {
 	destFileName := filepath.Join(c.state.RunDir, destFile)
+	c.trackRunDirFile(destFile)
 
 	if err := os.Remove(destFileName); err != nil && !os.IsNotExist(err) {
 		return "", errors.Wrapf(err, "error removing %s for container %s", destFile, c.ID())
@@ -1025,6 +2230,74 @@ func (c *Container) writeStringToRundir(destFile, output string) (string, error)
 	return filepath.Join(c.state.DestinationRunDir, destFile), nil
 }
 
+// generateLocaltime creates the container's localtime file from the host's
+// zoneinfo database
+func (c *Container) generateLocaltime() (string, error) {
+	zonePath := "/etc/localtime"
+	if c.config.Timezone != timezoneLocal {
+		path, err := zoneinfoPath(c.config.Timezone)
+		if err != nil {
+			return "", err
+		}
+		zonePath = path
+	}
+
+	contents, err := ioutil.ReadFile(zonePath)
+	if err != nil {
+		return "", errors.Wrapf(err, "unable to read %s", zonePath)
+	}
+
+	return c.writeStringToRundir("localtime", string(contents))
+}
+
+// generateMachineID creates the container's machine-id file according to its
+// machine ID mode
+func (c *Container) generateMachineID() (string, error) {
+	var machineID string
+	switch c.MachineIDMode() {
+	case MachineIDModeHost:
+		contents, err := ioutil.ReadFile("/etc/machine-id")
+		if err != nil {
+			return "", errors.Wrapf(err, "unable to read host machine ID")
+		}
+		machineID = strings.TrimSpace(string(contents))
+	case MachineIDModeGenerate:
+		machineID = stringid.GenerateNonCryptoID()
+	case MachineIDModeContainerID:
+		machineID = c.ID()
+	default:
+		return "", errors.Wrapf(ErrInvalidArg, "cannot generate machine ID for mode %q", c.MachineIDMode())
+	}
+
+	// Machine IDs are 32 lowercase hexadecimal characters, our IDs are
+	// longer
+	if len(machineID) > 32 {
+		machineID = machineID[:32]
+	}
+
+	return c.writeStringToRundir("machine-id", machineID+"\n")
+}
+
+// timezoneName returns the name of the container's timezone for use in the TZ
+// environment variable. If the host's timezone is used and cannot be
+// determined, "" is returned.
+func (c *Container) timezoneName() string {
+	if c.config.Timezone != timezoneLocal {
+		return c.config.Timezone
+	}
+
+	// /etc/localtime is usually a symlink into the zoneinfo database
+	target, err := filepath.EvalSymlinks("/etc/localtime")
+	if err != nil {
+		return ""
+	}
+	name, err := filepath.Rel(zoneinfoDir, target)
+	if err != nil || strings.HasPrefix(name, "..") {
+		return ""
+	}
+	return name
+}
+
 // generatePasswd generates a container specific passwd file,
 // iff g.config.User is a number
 func (c *Container) generatePasswd() (string, error) {
@@ -1114,6 +2387,15 @@ func (c *Container) generateResolvConf() (string, error) {
 		}
 	}
 
+	// Point the container at its caching resolver, if it has one
+	if c.config.DNSCache {
+		if err := c.setupDNSCache(nameservers); err != nil {
+			logrus.Warnf("Unable to use caching DNS resolver for container %s, using nameservers directly: %v", c.ID(), err)
+		} else {
+			nameservers = []string{dnsCacheAddress}
+		}
+	}
+
 	search := resolvconf.GetSearchDomains(resolv.Content)
 	if len(c.config.DNSSearch) > 0 {
 		search = c.config.DNSSearch
@@ -1125,6 +2407,7 @@ func (c *Container) generateResolvConf() (string, error) {
 	}
 
 	destPath := filepath.Join(c.state.RunDir, "resolv.conf")
+	c.trackRunDirFile("resolv.conf")
 
 	if err := os.Remove(destPath); err != nil && !os.IsNotExist(err) {
 		return "", errors.Wrapf(err, "error removing resolv.conf for container %s", c.ID())
@@ -1273,6 +2556,12 @@ func (c *Container) addLocalVolumes(ctx context.Context, g *generate.Generator)
 
 // Save OCI spec to disk, replacing any existing specs for the container
 func (c *Container) saveSpec(spec *spec.Spec) error {
+	return c.writeSpec(spec, 0644)
+}
+
+// writeSpec writes the OCI spec to the container's bundle with the given
+// permissions
+func (c *Container) writeSpec(spec *spec.Spec, perm os.FileMode) error {
 	// If the OCI spec already exists, we need to replace it
 	// Cannot guarantee some things, e.g. network namespaces, have the same
 	// paths
@@ -1293,7 +2582,7 @@ func (c *Container) saveSpec(spec *spec.Spec) error {
 	if err != nil {
 		return errors.Wrapf(err, "error exporting runtime spec for container %s to JSON", c.ID())
 	}
-	if err := ioutil.WriteFile(jsonPath, fileJSON, 0644); err != nil {
+	if err := ioutil.WriteFile(jsonPath, fileJSON, perm); err != nil {
 		return errors.Wrapf(err, "error writing runtime spec JSON for container %s to disk", c.ID())
 	}
 
@@ -1386,3 +2675,74 @@ func getExcludedCGroups() (excludes []string) {
 	excludes = []string{"rdma"}
 	return
 }
+
+// withEnvSecrets runs create, which has the OCI runtime create the container
+// from its bundle, with the container's environment secrets injected into the
+// spec in the bundle. The spec without the secrets is restored afterwards, so
+// their values are only on disk while the OCI runtime reads the bundle.
+func (c *Container) withEnvSecrets(s *spec.Spec, create func() error) error {
+	if len(c.config.EnvSecrets) == 0 {
+		return create()
+	}
+
+	secretSpec, err := c.injectEnvSecrets(s)
+	if err != nil {
+		return err
+	}
+	if err := c.writeSpec(secretSpec, 0600); err != nil {
+		return err
+	}
+
+	createErr := create()
+
+	if err := c.saveSpec(s); err != nil {
+		if createErr == nil {
+			return errors.Wrapf(err, "error removing secrets from OCI spec of container %s", c.ID())
+		}
+		logrus.Errorf("Error removing secrets from OCI spec of container %s: %v", c.ID(), err)
+	}
+
+	return createErr
+}
+
+// injectEnvSecrets returns a copy of the spec with the container's environment
+// secrets resolved and added to its process's environment
+func (c *Container) injectEnvSecrets(s *spec.Spec) (*spec.Spec, error) {
+	if s.Process == nil {
+		return nil, errors.Wrapf(ErrInvalidArg, "container %s has no process to set secrets in the environment of", c.ID())
+	}
+	if c.runtime.secretStore == nil {
+		return nil, errors.Wrapf(ErrInvalidArg, "container %s uses secrets but no secret store is configured", c.ID())
+	}
+
+	names := make([]string, 0, len(c.config.EnvSecrets))
+	for name := range c.config.EnvSecrets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	values := make(map[string]string, len(names))
+	for _, name := range names {
+		value, err := c.runtime.secretStore.Lookup(c.config.EnvSecrets[name])
+		if err != nil {
+			return nil, errors.Wrapf(err, "error resolving secret for environment variable %s of container %s", name, c.ID())
+		}
+		values[name] = value
+	}
+
+	secretSpec := *s
+	process := *s.Process
+	process.Env = make([]string, 0, len(s.Process.Env)+len(names))
+	for _, env := range s.Process.Env {
+		// Secrets replace variables of the same name
+		if _, ok := values[strings.SplitN(env, "=", 2)[0]]; !ok {
+			process.Env = append(process.Env, env)
+		}
+	}
+	for _, name := range names {
+		process.Env = append(process.Env, name+"="+values[name])
+	}
+	secretSpec.Process = &process
+
+	return &secretSpec, nil
+}
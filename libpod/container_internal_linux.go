@@ -3,6 +3,7 @@
 package libpod
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -16,6 +17,7 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/containerd/cgroups"
 	cnitypes "github.com/containernetworking/cni/pkg/types/current"
 	"github.com/containernetworking/plugins/pkg/ns"
 	crioAnnotations "github.com/containers/libpod/pkg/annotations"
@@ -23,6 +25,8 @@ import (
 	"github.com/containers/libpod/pkg/lookup"
 	"github.com/containers/libpod/pkg/rootless"
 	"github.com/containers/storage/pkg/idtools"
+	"github.com/containers/storage/pkg/mount"
+	"github.com/cyphar/filepath-securejoin"
 	spec "github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/opencontainers/runtime-tools/generate"
 	"github.com/opencontainers/selinux/go-selinux/label"
@@ -39,6 +43,24 @@ func (c *Container) mountSHM(shmOptions string) error {
 	return nil
 }
 
+// mountHostSHM bind mounts the host's /dev/shm on the container's SHM directory
+// It is unmounted along with the container's other mounts by unmountSHM
+func (c *Container) mountHostSHM() error {
+	if err := unix.Mount("/dev/shm", c.config.ShmDir, "", unix.MS_BIND, ""); err != nil {
+		return errors.Wrapf(err, "failed to bind mount host /dev/shm on %q", c.config.ShmDir)
+	}
+	if c.config.MountLabel != "" {
+		// The host and other containers use it too, so use a shared label
+		if err := label.Relabel(c.config.ShmDir, c.config.MountLabel, true); err != nil {
+			if err2 := unix.Unmount(c.config.ShmDir, unix.MNT_DETACH); err2 != nil {
+				logrus.Errorf("Error unmounting host /dev/shm from %q: %v", c.config.ShmDir, err2)
+			}
+			return errors.Wrapf(err, "failed to relabel host /dev/shm for container %s", c.ID())
+		}
+	}
+	return nil
+}
+
 func (c *Container) unmountSHM(mount string) error {
 	if err := unix.Unmount(mount, unix.MNT_DETACH); err != nil {
 		if err != syscall.EINVAL {
@@ -48,9 +70,198 @@ func (c *Container) unmountSHM(mount string) error {
 	return nil
 }
 
+// readOnlyRootfsFiles are the files libpod may bind mount into containers,
+// which must exist before their root filesystem is made read-only
+var readOnlyRootfsFiles = []string{"/etc/hostname", "/etc/hosts", "/etc/localtime", "/etc/passwd", "/etc/resolv.conf"}
+
+// makeRootfsReadOnly remounts the container's root filesystem, mounted at
+// mountPoint, read-only
+// The mount points of the container's mounts are created first, as the OCI
+// runtime cannot create them on a read-only root filesystem.
+func (c *Container) makeRootfsReadOnly(mountPoint string) error {
+	for _, m := range c.config.Spec.Mounts {
+		isDir := true
+		if m.Type == "bind" {
+			if info, err := os.Stat(m.Source); err == nil && !info.IsDir() {
+				isDir = false
+			}
+		}
+		if err := createRootfsMountpoint(mountPoint, m.Destination, isDir); err != nil {
+			return errors.Wrapf(err, "error creating mount point %s in root filesystem of container %s", m.Destination, c.ID())
+		}
+	}
+	for _, dir := range []string{"/dev/shm", "/run"} {
+		if err := createRootfsMountpoint(mountPoint, dir, true); err != nil {
+			return errors.Wrapf(err, "error creating mount point %s in root filesystem of container %s", dir, c.ID())
+		}
+	}
+	for _, file := range readOnlyRootfsFiles {
+		if err := createRootfsMountpoint(mountPoint, file, false); err != nil {
+			return errors.Wrapf(err, "error creating mount point %s in root filesystem of container %s", file, c.ID())
+		}
+	}
+
+	if err := unix.Mount("", mountPoint, "", unix.MS_REMOUNT|unix.MS_BIND|unix.MS_RDONLY, ""); err != nil {
+		return errors.Wrapf(err, "error remounting root filesystem of container %s read-only", c.ID())
+	}
+	return nil
+}
+
+// makeRootfsWritable remounts the container's root filesystem, mounted at
+// mountPoint, read-write again, before it is unmounted, as its mount may be
+// shared with other users of the container's storage
+func (c *Container) makeRootfsWritable(mountPoint string) error {
+	if err := unix.Mount("", mountPoint, "", unix.MS_REMOUNT|unix.MS_BIND, ""); err != nil {
+		return errors.Wrapf(err, "error remounting root filesystem of container %s read-write", c.ID())
+	}
+	return nil
+}
+
+// createRootfsMountpoint creates the mount point dest in the root filesystem
+// at rootfs if it does not exist, as a directory or an empty file
+func createRootfsMountpoint(rootfs, dest string, isDir bool) error {
+	path, err := securejoin.SecureJoin(rootfs, dest)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Lstat(path); err == nil || !os.IsNotExist(err) {
+		return err
+	}
+	if isDir {
+		return os.MkdirAll(path, 0755)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+// mountStorageMounts mounts the container's storage mounts, in the order of
+// the container's mounts. If one fails, the ones already mounted are
+// unmounted again.
+func (c *Container) mountStorageMounts() (err error) {
+	mounted := []string{}
+	defer func() {
+		if err != nil {
+			for i := len(mounted) - 1; i >= 0; i-- {
+				if err2 := c.unmountSHM(mounted[i]); err2 != nil {
+					logrus.Errorf("Error unmounting %s of container %s: %v", mounted[i], c.ID(), err2)
+				}
+			}
+		}
+	}()
+
+	for _, mountpoint := range c.config.Mounts {
+		if mountpoint == c.config.ShmDir {
+			continue
+		}
+		m, ok := c.config.StorageMounts[mountpoint]
+		if !ok {
+			logrus.Debugf("No storage mount configured for %s of container %s, not mounting it", mountpoint, c.ID())
+			continue
+		}
+
+		isMounted, err := mount.Mounted(mountpoint)
+		if err != nil {
+			return errors.Wrapf(err, "unable to determine if %q is mounted", mountpoint)
+		}
+		if isMounted {
+			continue
+		}
+
+		if err := c.mountStorageMount(mountpoint, m); err != nil {
+			return err
+		}
+		mounted = append(mounted, mountpoint)
+	}
+
+	return nil
+}
+
+// mountStorageMount mounts a single storage mount of the container on
+// mountpoint, creating mountpoint if needed
+func (c *Container) mountStorageMount(mountpoint string, m StorageMount) error {
+	flags, data := mount.ParseOptions(strings.Join(m.Options, ","))
+	isBind := m.Type == "bind" || flags&unix.MS_BIND == unix.MS_BIND
+
+	relabel, shared := false, false
+	opts := []string{}
+	for _, opt := range strings.Split(data, ",") {
+		switch opt {
+		case "":
+		case "z":
+			relabel, shared = true, true
+		case "Z":
+			relabel, shared = true, false
+		default:
+			opts = append(opts, opt)
+		}
+	}
+	data = strings.Join(opts, ",")
+
+	fsType := m.Type
+	if isBind {
+		flags |= unix.MS_BIND
+		fsType = ""
+	} else if c.config.MountLabel != "" {
+		data = label.FormatMountLabel(data, c.config.MountLabel)
+	}
+
+	// The mount point must be a file to bind mount a file on it
+	isDir := true
+	if isBind {
+		info, err := os.Stat(m.Source)
+		if err != nil {
+			return errors.Wrapf(err, "error accessing source %s of mount %s of container %s", m.Source, mountpoint, c.ID())
+		}
+		isDir = info.IsDir()
+	}
+	if isDir {
+		if err := os.MkdirAll(mountpoint, 0700); err != nil {
+			return errors.Wrapf(err, "error creating mount point %s of container %s", mountpoint, c.ID())
+		}
+	} else if _, err := os.Stat(mountpoint); os.IsNotExist(err) {
+		if err := os.MkdirAll(filepath.Dir(mountpoint), 0700); err != nil {
+			return errors.Wrapf(err, "error creating mount point %s of container %s", mountpoint, c.ID())
+		}
+		f, err := os.OpenFile(mountpoint, os.O_CREATE|os.O_WRONLY, 0600)
+		if err != nil {
+			return errors.Wrapf(err, "error creating mount point %s of container %s", mountpoint, c.ID())
+		}
+		f.Close()
+	}
+
+	if relabel && isBind && c.config.MountLabel != "" {
+		if err := label.Relabel(m.Source, c.config.MountLabel, shared); err != nil {
+			return errors.Wrapf(err, "failed to relabel source %s of mount %s of container %s", m.Source, mountpoint, c.ID())
+		}
+	}
+
+	if err := unix.Mount(m.Source, mountpoint, fsType, uintptr(flags), data); err != nil {
+		return errors.Wrapf(err, "failed to mount %s on %s for container %s", m.Source, mountpoint, c.ID())
+	}
+
+	// Bind mounts only take flags such as read-only when remounted
+	bindFlags := flags &^ (unix.MS_BIND | unix.MS_REC)
+	if isBind && bindFlags != 0 {
+		if err := unix.Mount("", mountpoint, "", uintptr(flags|unix.MS_REMOUNT), ""); err != nil {
+			if err2 := unix.Unmount(mountpoint, unix.MNT_DETACH); err2 != nil {
+				logrus.Errorf("Error unmounting %s of container %s: %v", mountpoint, c.ID(), err2)
+			}
+			return errors.Wrapf(err, "failed to remount %s for container %s", mountpoint, c.ID())
+		}
+	}
+
+	return nil
+}
+
 // prepare mounts the container and sets up other required resources like net
 // namespaces
-func (c *Container) prepare() (err error) {
+func (c *Container) prepare(ctx context.Context) (err error) {
 	var (
 		wg                              sync.WaitGroup
 		netNS                           ns.NetNS
@@ -60,6 +271,15 @@ func (c *Container) prepare() (err error) {
 		saveNetworkStatus               bool
 	)
 
+	if err := c.runtime.claimHostPorts(c); err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			c.runtime.releaseHostPorts(c)
+		}
+	}()
+
 	wg.Add(2)
 
 	go func() {
@@ -73,7 +293,7 @@ func (c *Container) prepare() (err error) {
 	// Mount storage if not mounted
 	go func() {
 		defer wg.Done()
-		mountPoint, mountStorageErr = c.mountStorage()
+		mountPoint, mountStorageErr = c.mountStorage(ctx)
 	}()
 
 	wg.Wait()
@@ -115,6 +335,11 @@ func (c *Container) cleanupNetwork() error {
 		return nil
 	}
 
+	// Stop the container's caching DNS resolver (if it has one)
+	if err := c.stopDNSCache(); err != nil {
+		logrus.Errorf("unable to stop caching DNS resolver for container %s: %v", c.ID(), err)
+	}
+
 	// Stop the container's network namespace (if it has one)
 	if err := c.runtime.teardownNetNS(c); err != nil {
 		logrus.Errorf("unable to cleanup network for container %s: %q", c.ID(), err)
@@ -130,6 +355,149 @@ func (c *Container) cleanupNetwork() error {
 	return nil
 }
 
+// cleanupCgroups removes the container's cgroup if libpod created it and the OCI
+// runtime left it behind. Adopted cgroups, and containers that do not have
+// cgroups managed by libpod, are left alone.
+// recordPeakMemory records the peak memory usage of the container's cgroup in
+// its state. It must be called once the container stopped, before its cgroup
+// is removed.
+func (c *Container) recordPeakMemory() error {
+	if c.CgroupMode() == CgroupModeDisabled {
+		return nil
+	}
+
+	cgroupPath, err := c.CGroupPath()
+	if err != nil {
+		return err
+	}
+
+	peak, err := cgroupPeakMemory(cgroupPath)
+	if err != nil {
+		return err
+	}
+	c.state.PeakMemory = peak
+
+	return nil
+}
+
+func (c *Container) cleanupCgroups() error {
+	if err := c.cleanupConmonCgroup(); err != nil {
+		logrus.Errorf("Error removing conmon cgroup of container %s: %v", c.ID(), err)
+	}
+
+	switch c.CgroupMode() {
+	case CgroupModeDisabled, CgroupModeSplit:
+		logrus.Debugf("Not removing cgroups of container %s (cgroup mode %s)", c.ID(), c.CgroupMode())
+		return nil
+	}
+
+	// Systemd removes the scope of the container once it is empty
+	if rootless.IsRootless() || c.runtime.config.CgroupManager != CgroupfsCgroupsManager {
+		return nil
+	}
+
+	// We only know how to manage cgroup v1 hierarchies
+	unified, err := isCgroup2UnifiedMode()
+	if err != nil {
+		return err
+	}
+	if unified {
+		return nil
+	}
+
+	cgroupPath, err := c.CGroupPath()
+	if err != nil {
+		return err
+	}
+
+	control, err := cgroups.Load(GetV1CGroups(getExcludedCGroups()), cgroups.StaticPath(cgroupPath))
+	if err != nil {
+		if err == cgroups.ErrCgroupDeleted {
+			return nil
+		}
+		return errors.Wrapf(err, "error loading cgroup %s of container %s", cgroupPath, c.ID())
+	}
+
+	logrus.Debugf("Removing leftover cgroup %s of container %s", cgroupPath, c.ID())
+	if err := control.Delete(); err != nil {
+		return errors.Wrapf(err, "error removing cgroup %s of container %s", cgroupPath, c.ID())
+	}
+
+	return nil
+}
+
+// cleanupConmonCgroup removes the cgroup conmon was placed in if libpod created
+// it. Cgroups that are still in use, for example by the conmon of another
+// container, are left behind and removed by a later cleanup.
+func (c *Container) cleanupConmonCgroup() error {
+	if c.state.ConmonCgroupPath == "" {
+		return nil
+	}
+	if !c.state.ConmonCgroupCreated {
+		c.state.ConmonCgroupPath = ""
+		return nil
+	}
+
+	control, err := cgroups.Load(GetV1CGroups(getExcludedCGroups()), cgroups.StaticPath(c.state.ConmonCgroupPath))
+	if err != nil {
+		if err != cgroups.ErrCgroupDeleted {
+			return errors.Wrapf(err, "error loading conmon cgroup %s of container %s", c.state.ConmonCgroupPath, c.ID())
+		}
+	} else {
+		procs, err := control.Processes(cgroups.Memory, false)
+		if err != nil {
+			return errors.Wrapf(err, "error listing processes in conmon cgroup %s of container %s", c.state.ConmonCgroupPath, c.ID())
+		}
+		if len(procs) > 0 {
+			logrus.Debugf("Conmon cgroup %s of container %s is still in use, not removing it", c.state.ConmonCgroupPath, c.ID())
+			return nil
+		}
+
+		logrus.Debugf("Removing conmon cgroup %s of container %s", c.state.ConmonCgroupPath, c.ID())
+		if err := control.Delete(); err != nil {
+			return errors.Wrapf(err, "error removing conmon cgroup %s of container %s", c.state.ConmonCgroupPath, c.ID())
+		}
+	}
+
+	c.state.ConmonCgroupPath = ""
+	c.state.ConmonCgroupCreated = false
+	return nil
+}
+
+// logFullThreshold is the amount of free space below which the filesystem
+// holding a container's log is considered full
+const logFullThreshold = 1024 * 1024
+
+// handleLogFilesystemFull applies the container's log full policy if the
+// filesystem holding its log has run out of space
+func (c *Container) handleLogFilesystemFull() error {
+	var st unix.Statfs_t
+	if err := unix.Statfs(filepath.Dir(c.LogPath()), &st); err != nil {
+		return errors.Wrapf(err, "error getting filesystem information for log of container %s", c.ID())
+	}
+	available := uint64(st.Bavail) * uint64(st.Bsize)
+	if available >= logFullThreshold {
+		return nil
+	}
+
+	policy := c.LogFullPolicy()
+	logrus.Warnf("Filesystem holding log %s of container %s is full (%s), applying policy %s", c.LogPath(), c.ID(), unix.ENOSPC, policy)
+
+	switch policy {
+	case LogFullPolicyDrop:
+		if err := os.Truncate(c.LogPath(), 0); err != nil && !os.IsNotExist(err) {
+			return errors.Wrapf(err, "error truncating log of container %s", c.ID())
+		}
+	case LogFullPolicyStop:
+		if err := c.stop(c.config.StopTimeout); err != nil {
+			return err
+		}
+		c.state.StopReason = fmt.Sprintf("log filesystem full: %s", unix.ENOSPC)
+	}
+
+	return nil
+}
+
 // Generate spec for a container
 // Accepts a map of the container's dependencies
 func (c *Container) generateSpec(ctx context.Context) (*spec.Spec, error) {
@@ -190,6 +558,46 @@ func (c *Container) generateSpec(ctx context.Context) (*spec.Spec, error) {
 		}
 	}
 
+	// Add the root filesystems of other containers
+	for _, m := range c.config.RootfsMounts {
+		srcPath, ok := c.state.RootfsMountSources[m.Container]
+		if !ok {
+			return nil, errors.Wrapf(ErrInternal, "root filesystem of container %s is not mounted", m.Container)
+		}
+		newMount := spec.Mount{
+			Type:        "bind",
+			Source:      srcPath,
+			Destination: m.Destination,
+			Options:     []string{"rbind", "private"},
+		}
+		if m.ReadOnly {
+			newMount.Options = append(newMount.Options, "ro")
+		}
+		if !MountExists(g.Mounts(), m.Destination) {
+			g.AddMount(newMount)
+		} else {
+			logrus.Warnf("User mount overriding root filesystem of container %s at %q", m.Container, m.Destination)
+		}
+	}
+
+	for _, vol := range c.config.NamedVolumes {
+		volume, err := c.runtime.state.Volume(vol.Name)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error retrieving volume %s of container %s", vol.Name, c.ID())
+		}
+		newMount := spec.Mount{
+			Type:        "bind",
+			Source:      volume.MountPoint(),
+			Destination: vol.Dest,
+			Options:     append([]string{"rbind", "private"}, vol.Options...),
+		}
+		if !MountExists(g.Mounts(), vol.Dest) {
+			g.AddMount(newMount)
+		} else {
+			logrus.Warnf("User mount overriding volume %s of container %s at %q", vol.Name, c.ID(), vol.Dest)
+		}
+	}
+
 	if !rootless.IsRootless() {
 		if c.state.ExtensionStageHooks, err = c.setupOCIHooks(ctx, g.Config); err != nil {
 			return nil, errors.Wrapf(err, "error setting up OCI Hooks")
@@ -223,6 +631,18 @@ func (c *Container) generateSpec(ctx context.Context) (*spec.Spec, error) {
 		}
 	}
 
+	// The tmpfs is mounted by the OCI runtime inside the container's mount
+	// namespace, with the container's mount label, so it goes away with
+	// the container and does not need to be unmounted in cleanupStorage
+	if runTmpfs, size, mode := c.RunTmpfs(); runTmpfs && !MountExists(g.Mounts(), "/run") {
+		g.AddMount(spec.Mount{
+			Destination: "/run",
+			Type:        "tmpfs",
+			Source:      "tmpfs",
+			Options:     []string{"rw", "rprivate", "noexec", "nosuid", "nodev", "tmpcopyup", fmt.Sprintf("size=%d", size), fmt.Sprintf("mode=%o", mode)},
+		})
+	}
+
 	if c.config.Systemd {
 		if err := c.setupSystemd(g.Mounts(), g); err != nil {
 			return nil, errors.Wrapf(err, "error adding systemd-specific mounts")
@@ -280,6 +700,9 @@ func (c *Container) generateSpec(ctx context.Context) (*spec.Spec, error) {
 	}
 
 	g.SetRootPath(c.state.RealMountpoint)
+	if c.config.ReadOnlyRootfs {
+		g.SetRootReadonly(true)
+	}
 	g.AddAnnotation(crioAnnotations.Created, c.config.CreatedTime.Format(time.RFC3339Nano))
 	g.AddAnnotation("org.opencontainers.image.stopSignal", fmt.Sprintf("%d", c.config.StopSignal))
 
@@ -304,8 +727,30 @@ func (c *Container) generateSpec(ctx context.Context) (*spec.Spec, error) {
 		g.AddProcessEnv("container", "libpod")
 	}
 
-	if rootless.IsRootless() {
+	// Allow the process to dump core if we are collecting cores
+	if c.config.CoreDumpMaxSize > 0 {
+		g.AddProcessRlimits("RLIMIT_CORE", uint64(c.config.CoreDumpMaxSize), uint64(c.config.CoreDumpMaxSize))
+	}
+
+	// Set TZ to match /etc/localtime, unless the user already set it
+	if c.config.Timezone != "" {
+		foundTZEnv := false
+		for _, env := range g.Config.Process.Env {
+			if strings.HasPrefix(env, "TZ=") {
+				foundTZEnv = true
+				break
+			}
+		}
+		if name := c.timezoneName(); !foundTZEnv && name != "" {
+			g.AddProcessEnv("TZ", name)
+		}
+	}
+
+	if rootless.IsRootless() || c.CgroupMode() == CgroupModeDisabled {
 		g.SetLinuxCgroupsPath("")
+	} else if c.CgroupMode() == CgroupModeSplit {
+		logrus.Debugf("Adopting cgroup %s for container %s", c.config.CgroupParent, c.ID())
+		g.SetLinuxCgroupsPath(c.config.CgroupParent)
 	} else if c.runtime.config.CgroupManager == SystemdCgroupsManager {
 		// When runc is set to use Systemd as a cgroup manager, it
 		// expects cgroups to be passed as follows:
@@ -406,7 +851,14 @@ func (c *Container) checkpoint(ctx context.Context, keep bool) (err error) {
 	if c.state.State != ContainerStateRunning {
 		return errors.Wrapf(ErrCtrStateInvalid, "%q is not running, cannot checkpoint", c.state.State)
 	}
+
+	if err := c.quiesceForCheckpoint(); err != nil {
+		return err
+	}
+
 	if err := c.runtime.ociRuntime.checkpointContainer(c); err != nil {
+		// The container is still running, let it continue
+		c.resumeAfterCheckpoint()
 		return err
 	}
 
@@ -421,6 +873,10 @@ func (c *Container) checkpoint(ctx context.Context, keep bool) (err error) {
 		return err
 	}
 
+	if err := c.writeCheckpointMetadata(); err != nil {
+		return err
+	}
+
 	logrus.Debugf("Checkpointed container %s", c.ID())
 
 	c.state.State = ContainerStateStopped
@@ -440,6 +896,205 @@ func (c *Container) checkpoint(ctx context.Context, keep bool) (err error) {
 	return c.save()
 }
 
+// quiesceForCheckpoint runs the container's quiesce command and syncs its root
+// filesystem, if configured, so it can be checkpointed consistently
+func (c *Container) quiesceForCheckpoint() error {
+	if len(c.config.CheckpointQuiesceCommand) > 0 {
+		logrus.Debugf("Quiescing container %s before checkpoint", c.ID())
+		if output, err := c.runtime.ociRuntime.execContainerSync(c, c.config.CheckpointQuiesceCommand); err != nil {
+			return errors.Wrapf(err, "error quiescing container %s, not checkpointing it: %s", c.ID(), output)
+		}
+	}
+
+	if c.config.CheckpointSyncFS && c.state.Mounted {
+		if err := syncFilesystem(c.state.Mountpoint); err != nil {
+			c.resumeAfterCheckpoint()
+			return errors.Wrapf(err, "error syncing root filesystem of container %s, not checkpointing it", c.ID())
+		}
+	}
+
+	return nil
+}
+
+// resumeAfterCheckpoint runs the container's resume command, if configured
+// Failures are logged, as the container is running either way
+func (c *Container) resumeAfterCheckpoint() {
+	if len(c.config.CheckpointResumeCommand) == 0 {
+		return
+	}
+
+	logrus.Debugf("Resuming container %s after checkpoint", c.ID())
+	if output, err := c.runtime.ociRuntime.execContainerSync(c, c.config.CheckpointResumeCommand); err != nil {
+		logrus.Errorf("Error resuming container %s after checkpoint: %v: %s", c.ID(), err, output)
+	}
+}
+
+// syncFilesystem flushes the filesystem holding path to disk
+func syncFilesystem(path string) error {
+	fd, err := unix.Open(path, unix.O_RDONLY|unix.O_DIRECTORY|unix.O_CLOEXEC, 0)
+	if err != nil {
+		return errors.Wrapf(err, "error opening %s", path)
+	}
+	defer unix.Close(fd)
+
+	return unix.Syncfs(fd)
+}
+
+// checkpointMetadataFile is the name of the file in the checkpoint directory
+// describing the host the checkpoint was taken on
+const checkpointMetadataFile = "libpod-checkpoint.json"
+
+// checkpointMetadata describes the host a checkpoint was taken on, so its
+// compatibility with the current host can be verified before restoring
+type checkpointMetadata struct {
+	KernelRelease string    `json:"kernelRelease"`
+	OCIRuntime    string    `json:"ociRuntime"`
+	CriuVersion   int       `json:"criuVersion"`
+	CreatedTime   time.Time `json:"createdTime"`
+}
+
+// getHostCheckpointMetadata returns the checkpoint metadata of the current host
+func (c *Container) getHostCheckpointMetadata() (*checkpointMetadata, error) {
+	return hostCheckpointMetadata(c.runtime.ociRuntime.name)
+}
+
+// hostCheckpointMetadata returns the checkpoint metadata of the current host,
+// using the given OCI runtime
+func hostCheckpointMetadata(ociRuntime string) (*checkpointMetadata, error) {
+	var uts unix.Utsname
+	if err := unix.Uname(&uts); err != nil {
+		return nil, errors.Wrapf(err, "error getting kernel release")
+	}
+
+	criuVersion, err := criu.GetCriuVersion()
+	if err != nil {
+		return nil, errors.Wrapf(err, "error getting CRIU version")
+	}
+
+	return &checkpointMetadata{
+		KernelRelease: string(bytes.TrimRight(uts.Release[:], "\x00")),
+		OCIRuntime:    ociRuntime,
+		CriuVersion:   criuVersion,
+		CreatedTime:   time.Now(),
+	}, nil
+}
+
+// writeCheckpointMetadata records the current host in the container's
+// checkpoint directory
+func (c *Container) writeCheckpointMetadata() error {
+	metadata, err := c.getHostCheckpointMetadata()
+	if err != nil {
+		return err
+	}
+
+	metadataJSON, err := json.MarshalIndent(metadata, "", "	")
+	if err != nil {
+		return errors.Wrapf(err, "error encoding checkpoint metadata for container %s", c.ID())
+	}
+
+	metadataPath := filepath.Join(c.CheckpointPath(), checkpointMetadataFile)
+	if err := ioutil.WriteFile(metadataPath, metadataJSON, 0644); err != nil {
+		return errors.Wrapf(err, "error writing checkpoint metadata for container %s", c.ID())
+	}
+
+	return nil
+}
+
+// validateCheckpoint verifies that the checkpoint in the given directory is
+// complete and can be restored on this host.
+// All problems found are reported in a single error.
+func (c *Container) validateCheckpoint(checkpointPath string) error {
+	info, err := os.Stat(checkpointPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return errors.Wrapf(ErrInvalidCheckpoint, "no checkpoint found for container %s at %s", c.ID(), checkpointPath)
+		}
+		return errors.Wrapf(err, "error accessing checkpoint %s", checkpointPath)
+	}
+	if !info.IsDir() {
+		return errors.Wrapf(ErrInvalidCheckpoint, "checkpoint %s is not a directory", checkpointPath)
+	}
+
+	var problems []string
+
+	// Files CRIU and the OCI runtime need to restore
+	requiredFiles := []string{
+		filepath.Join(checkpointPath, "inventory.img"),
+		filepath.Join(c.bundlePath(), "config.json"),
+	}
+	for _, file := range requiredFiles {
+		if _, err := os.Stat(file); err != nil {
+			problems = append(problems, fmt.Sprintf("required file %s is missing", file))
+		}
+	}
+
+	if !criu.CheckForCriu() {
+		problems = append(problems, fmt.Sprintf("restoring requires at least CRIU %d", criu.MinCriuVersion))
+	}
+
+	metadataJSON, err := ioutil.ReadFile(filepath.Join(checkpointPath, checkpointMetadataFile))
+	if err != nil {
+		// Checkpoints made by older versions of libpod do not record
+		// the host they were taken on
+		logrus.Warnf("Checkpoint %s of container %s has no metadata, unable to verify host compatibility", checkpointPath, c.ID())
+	} else {
+		recorded := new(checkpointMetadata)
+		if err := json.Unmarshal(metadataJSON, recorded); err != nil {
+			problems = append(problems, fmt.Sprintf("checkpoint metadata is corrupt: %v", err))
+		} else if host, err := c.getHostCheckpointMetadata(); err != nil {
+			problems = append(problems, err.Error())
+		} else {
+			problems = append(problems, checkpointIncompatibilities(recorded, host)...)
+		}
+	}
+
+	if len(problems) > 0 {
+		return errors.Wrapf(ErrInvalidCheckpoint, "checkpoint %s of container %s cannot be restored: %s", checkpointPath, c.ID(), strings.Join(problems, "; "))
+	}
+
+	return nil
+}
+
+// checkpointIncompatibilities returns the reasons a checkpoint taken on the
+// recorded host cannot be restored on the given host
+func checkpointIncompatibilities(recorded, host *checkpointMetadata) []string {
+	var problems []string
+	if recorded.KernelRelease != host.KernelRelease {
+		problems = append(problems, fmt.Sprintf("checkpoint was taken on kernel %s, host is running kernel %s", recorded.KernelRelease, host.KernelRelease))
+	}
+	if recorded.OCIRuntime != host.OCIRuntime {
+		problems = append(problems, fmt.Sprintf("checkpoint was taken with OCI runtime %s, but %s is in use", recorded.OCIRuntime, host.OCIRuntime))
+	}
+	if recorded.CriuVersion > host.CriuVersion {
+		problems = append(problems, fmt.Sprintf("checkpoint was taken with CRIU %d, host has older CRIU %d", recorded.CriuVersion, host.CriuVersion))
+	}
+	return problems
+}
+
+// validateCheckpointHost verifies that the checkpoint in the given directory,
+// which must record the host it was taken on, can be restored on this host
+// using the given OCI runtime
+func validateCheckpointHost(checkpointPath, ociRuntime string) error {
+	metadataJSON, err := ioutil.ReadFile(filepath.Join(checkpointPath, checkpointMetadataFile))
+	if err != nil {
+		return errors.Wrapf(ErrInvalidCheckpoint, "checkpoint %s does not record the host it was taken on: %v", checkpointPath, err)
+	}
+	recorded := new(checkpointMetadata)
+	if err := json.Unmarshal(metadataJSON, recorded); err != nil {
+		return errors.Wrapf(ErrInvalidCheckpoint, "checkpoint metadata in %s is corrupt: %v", checkpointPath, err)
+	}
+
+	host, err := hostCheckpointMetadata(ociRuntime)
+	if err != nil {
+		return err
+	}
+	if problems := checkpointIncompatibilities(recorded, host); len(problems) > 0 {
+		return errors.Wrapf(ErrInvalidCheckpoint, "checkpoint %s cannot be restored on this host: %s", checkpointPath, strings.Join(problems, "; "))
+	}
+
+	return nil
+}
+
 func (c *Container) restore(ctx context.Context, keep bool) (err error) {
 
 	if !criu.CheckForCriu() {
@@ -450,10 +1105,10 @@ func (c *Container) restore(ctx context.Context, keep bool) (err error) {
 		return errors.Wrapf(ErrCtrStateInvalid, "container %s is running or paused, cannot restore", c.ID())
 	}
 
-	// Let's try to stat() CRIU's inventory file. If it does not exist, it makes
-	// no sense to try a restore. This is a minimal check if a checkpoint exist.
-	if _, err := os.Stat(filepath.Join(c.CheckpointPath(), "inventory.img")); os.IsNotExist(err) {
-		return errors.Wrapf(err, "A complete checkpoint for this container cannot be found, cannot restore")
+	// Make sure the checkpoint is complete and was taken on a compatible
+	// host before we touch anything
+	if err := c.validateCheckpoint(c.CheckpointPath()); err != nil {
+		return err
 	}
 
 	// Read network configuration from checkpoint
@@ -484,7 +1139,7 @@ func (c *Container) restore(ctx context.Context, keep bool) (err error) {
 		}
 	}
 
-	if err := c.prepare(); err != nil {
+	if err := c.prepare(ctx); err != nil {
 		return err
 	}
 	defer func() {
@@ -510,6 +1165,11 @@ func (c *Container) restore(ctx context.Context, keep bool) (err error) {
 		return err
 	}
 
+	// The root filesystem is not necessarily mounted where it was when
+	// the container was checkpointed, for example if the container was
+	// migrated from another host
+	g.SetRootPath(c.state.RealMountpoint)
+
 	// We want to have the same network namespace as before.
 	if c.config.CreateNetNS {
 		g.AddOrReplaceLinuxNamespace(spec.NetworkNamespace, c.state.NetNS.Path())
@@ -527,7 +1187,9 @@ func (c *Container) restore(ctx context.Context, keep bool) (err error) {
 	// Cleanup for a working restore.
 	c.removeConmonFiles()
 
-	if err := c.runtime.ociRuntime.createContainer(c, c.config.CgroupParent, true); err != nil {
+	if err := c.withEnvSecrets(g.Spec(), func() error {
+		return c.runtime.ociRuntime.createContainer(c, c.config.CgroupParent, true)
+	}); err != nil {
 		return err
 	}
 
@@ -535,6 +1197,9 @@ func (c *Container) restore(ctx context.Context, keep bool) (err error) {
 
 	c.state.State = ContainerStateRunning
 
+	// The container was checkpointed quiesced
+	c.resumeAfterCheckpoint()
+
 	if !keep {
 		// Delete all checkpoint related files. At this point, in theory, all files
 		// should exist. Still ignoring errors for now as the container should be
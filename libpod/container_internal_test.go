@@ -8,9 +8,12 @@ import (
 	"path/filepath"
 	"runtime"
 	"strings"
+	"syscall"
 	"testing"
+	"time"
 
 	rspec "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -79,3 +82,135 @@ func init() {
 		panic("we need a reliable executable path on Windows")
 	}
 }
+
+func TestAddStateTransition(t *testing.T) {
+	now := time.Now()
+
+	history := addStateTransition(nil, ContainerStateConfigured, now)
+	assert.Empty(t, history)
+
+	history = addStateTransition(history, ContainerStateCreated, now)
+	history = addStateTransition(history, ContainerStateCreated, now)
+	history = addStateTransition(history, ContainerStateRunning, now)
+	assert.Equal(t, []StateTransition{
+		{From: ContainerStateConfigured, To: ContainerStateCreated, Time: now},
+		{From: ContainerStateCreated, To: ContainerStateRunning, Time: now},
+	}, history)
+
+	for i := 0; i < maxStateHistory; i++ {
+		history = addStateTransition(history, ContainerStatePaused, now)
+		history = addStateTransition(history, ContainerStateRunning, now)
+	}
+	assert.Len(t, history, maxStateHistory)
+	assert.Equal(t, ContainerStateRunning, history[len(history)-1].To)
+}
+
+func TestCrashSignal(t *testing.T) {
+	sig, crashed := crashSignal(128 + int32(syscall.SIGSEGV))
+	assert.True(t, crashed)
+	assert.Equal(t, syscall.SIGSEGV, sig)
+
+	sig, crashed = crashSignal(128 + int32(syscall.SIGABRT))
+	assert.True(t, crashed)
+	assert.Equal(t, syscall.SIGABRT, sig)
+
+	for _, exitCode := range []int32{0, 1, 128, 128 + int32(syscall.SIGKILL), 128 + int32(syscall.SIGTERM)} {
+		_, crashed := crashSignal(exitCode)
+		assert.False(t, crashed, exitCode)
+	}
+}
+
+func TestIsCoreFileName(t *testing.T) {
+	for _, name := range []string{"core", "core.1", "core.12345"} {
+		assert.True(t, isCoreFileName(name), name)
+	}
+	for _, name := range []string{"core.", "core.txt", "cores", "core.1.gz", "mycore"} {
+		assert.False(t, isCoreFileName(name), name)
+	}
+}
+
+func TestBindMountSources(t *testing.T) {
+	mounts := []rspec.Mount{
+		{Destination: "/proc", Type: "proc", Source: "proc"},
+		{Destination: "/data", Type: "bind", Source: "/srv/data"},
+		{Destination: "/etc/foo", Source: "/etc/foo", Options: []string{"rbind", "ro"}},
+		{Destination: "/tmp", Type: "tmpfs", Source: "tmpfs", Options: []string{"nosuid"}},
+	}
+	assert.Equal(t, []string{"/srv/data", "/etc/foo"}, bindMountSources(mounts))
+	assert.Equal(t, []string{}, bindMountSources(nil))
+}
+
+func TestUnexpectedStateChange(t *testing.T) {
+	assert.False(t, unexpectedStateChange(ContainerStateRunning, ContainerStateRunning))
+	assert.False(t, unexpectedStateChange(ContainerStateRunning, ContainerStateStopped))
+	assert.False(t, unexpectedStateChange(ContainerStatePaused, ContainerStateExited))
+	assert.True(t, unexpectedStateChange(ContainerStateStopped, ContainerStateRunning))
+	assert.True(t, unexpectedStateChange(ContainerStateCreated, ContainerStateRunning))
+	assert.True(t, unexpectedStateChange(ContainerStateRunning, ContainerStatePaused))
+}
+
+func TestRestartBackoffNextDelay(t *testing.T) {
+	backoff := &RestartBackoff{
+		BaseDelay:  time.Second,
+		MaxDelay:   10 * time.Second,
+		Multiplier: 3,
+		Jitter:     0.5,
+	}
+
+	delay := backoff.nextDelay(0)
+	assert.Equal(t, time.Second, delay)
+	delay = backoff.nextDelay(delay)
+	assert.Equal(t, 3*time.Second, delay)
+	delay = backoff.nextDelay(delay)
+	assert.Equal(t, 9*time.Second, delay)
+	delay = backoff.nextDelay(delay)
+	assert.Equal(t, 10*time.Second, delay)
+	assert.Equal(t, 10*time.Second, backoff.nextDelay(delay))
+
+	assert.Equal(t, 10*time.Second, backoff.jitter(10*time.Second, 0))
+	assert.Equal(t, 7500*time.Millisecond, backoff.jitter(10*time.Second, 0.5))
+}
+
+func TestApplyRestartPolicy(t *testing.T) {
+	c := &Container{
+		config: &ContainerConfig{ID: "abc", RestartPolicy: RestartPolicyOnFailure, RestartRetries: 2},
+		state:  &containerState{ExitCode: 1},
+	}
+
+	c.applyRestartPolicy()
+	assert.False(t, c.state.NextRestart.IsZero())
+
+	c.state.NextRestart = time.Time{}
+	c.state.RestartCount = 2
+	c.applyRestartPolicy()
+	assert.True(t, c.state.NextRestart.IsZero())
+
+	// A successful run resets the count, and on-failure does not restart it
+	c.state.ExitCode = 0
+	c.applyRestartPolicy()
+	assert.Equal(t, 0, c.state.RestartCount)
+	assert.True(t, c.state.NextRestart.IsZero())
+
+	c.config.RestartPolicy = RestartPolicyAlways
+	c.applyRestartPolicy()
+	assert.False(t, c.state.NextRestart.IsZero())
+
+	c.state.NextRestart = time.Time{}
+	c.state.StoppedByUser = true
+	c.applyRestartPolicy()
+	assert.True(t, c.state.NextRestart.IsZero())
+}
+
+func TestStorageDriverMismatch(t *testing.T) {
+	assert.NoError(t, storageDriverMismatch("abc", "overlay", "overlay"))
+
+	err := storageDriverMismatch("abc", "overlay", "vfs")
+	assert.Equal(t, ErrStorageDriverMismatch, errors.Cause(err))
+	assert.Contains(t, err.Error(), `"overlay"`)
+}
+
+func TestShmOptions(t *testing.T) {
+	assert.Equal(t, "mode=1777,size=65536", shmOptions(0, 65536))
+	assert.Equal(t, "mode=700,size=1024", shmOptions(0700, 1024))
+	assert.Equal(t, "mode=1770,size=1024", shmOptions(os.ModeSticky|0770, 1024))
+}
@@ -12,11 +12,27 @@ func (c *Container) mountSHM(shmOptions string) error {
 	return ErrNotImplemented
 }
 
+func (c *Container) mountHostSHM() error {
+	return ErrNotImplemented
+}
+
 func (c *Container) unmountSHM(mount string) error {
 	return ErrNotImplemented
 }
 
-func (c *Container) prepare() (err error) {
+func (c *Container) makeRootfsReadOnly(mountPoint string) error {
+	return ErrNotImplemented
+}
+
+func (c *Container) makeRootfsWritable(mountPoint string) error {
+	return ErrNotImplemented
+}
+
+func (c *Container) mountStorageMounts() error {
+	return ErrNotImplemented
+}
+
+func (c *Container) prepare(ctx context.Context) (err error) {
 	return ErrNotImplemented
 }
 
@@ -24,6 +40,14 @@ func (c *Container) cleanupNetwork() error {
 	return ErrNotImplemented
 }
 
+func (c *Container) cleanupCgroups() error {
+	return ErrNotImplemented
+}
+
+func (c *Container) recordPeakMemory() error {
+	return ErrNotImplemented
+}
+
 func (c *Container) generateSpec(ctx context.Context) (*spec.Spec, error) {
 	return nil, ErrNotImplemented
 }
@@ -35,3 +59,15 @@ func (c *Container) checkpoint(ctx context.Context, keep bool) error {
 func (c *Container) restore(ctx context.Context, keep bool) error {
 	return ErrNotImplemented
 }
+
+func (c *Container) validateCheckpoint(checkpointPath string) error {
+	return ErrNotImplemented
+}
+
+func validateCheckpointHost(checkpointPath, ociRuntime string) error {
+	return ErrNotImplemented
+}
+
+func (c *Container) handleLogFilesystemFull() error {
+	return nil
+}
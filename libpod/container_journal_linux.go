@@ -0,0 +1,219 @@
+// +build linux
+
+package libpod
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// journalSocket is the socket journald receives entries on
+const journalSocket = "/run/systemd/journal/socket"
+
+// Fields of the journal entries of a container's output
+const (
+	journalFieldID       = "CONTAINER_ID"
+	journalFieldIDFull   = "CONTAINER_ID_FULL"
+	journalFieldName     = "CONTAINER_NAME"
+	journalFieldStream   = "CONTAINER_STREAM"
+	journalFieldLogTime  = "CONTAINER_LOG_TIME"
+	journalFieldLogTag   = "CONTAINER_LOG_TAG"
+	journalFieldMessage  = "MESSAGE"
+	journalFieldPriority = "PRIORITY"
+	journalFieldCursor   = "__CURSOR"
+)
+
+// forwardLogToJournal sends the lines conmon added to the container's log
+// since we last looked to the journal, if its log driver is journald
+func (c *Container) forwardLogToJournal() error {
+	if c.LogDriver() != LogDriverJournald {
+		return nil
+	}
+
+	logFile, err := os.Open(c.LogPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return errors.Wrapf(err, "error opening log of container %s", c.ID())
+	}
+	defer logFile.Close()
+
+	info, err := logFile.Stat()
+	if err != nil {
+		return errors.Wrapf(err, "error accessing log of container %s", c.ID())
+	}
+	// The log was truncated or replaced, start over
+	if info.Size() < c.state.LogJournalOffset {
+		c.state.LogJournalOffset = 0
+	}
+	if info.Size() == c.state.LogJournalOffset {
+		return nil
+	}
+
+	if _, err := logFile.Seek(c.state.LogJournalOffset, io.SeekStart); err != nil {
+		return errors.Wrapf(err, "error reading log of container %s", c.ID())
+	}
+
+	conn, err := net.Dial("unixgram", journalSocket)
+	if err != nil {
+		return errors.Wrapf(err, "error connecting to the journal")
+	}
+	defer conn.Close()
+
+	reader := bufio.NewReader(logFile)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err == io.EOF {
+			// Wait for the rest of a partially written line
+			break
+		}
+		if err != nil {
+			return errors.Wrapf(err, "error reading log of container %s", c.ID())
+		}
+
+		if _, err := conn.Write(journalEntry(c.journalLogFields(line))); err != nil {
+			return errors.Wrapf(err, "error sending log of container %s to the journal", c.ID())
+		}
+		c.state.LogJournalOffset += int64(len(line))
+	}
+
+	return nil
+}
+
+// journalLogFields returns the fields of the journal entry of a line of the
+// container's log
+func (c *Container) journalLogFields(line []byte) map[string]string {
+	shortID := c.ID()
+	if len(shortID) > 12 {
+		shortID = shortID[:12]
+	}
+	fields := map[string]string{
+		journalFieldID:     shortID,
+		journalFieldIDFull: c.ID(),
+		journalFieldName:   c.Name(),
+	}
+
+	line = bytes.TrimSuffix(line, []byte("\n"))
+	parts := bytes.SplitN(line, []byte(" "), 4)
+	stream, ok := logLineStream(line)
+	if !ok || len(parts) < 4 {
+		fields[journalFieldMessage] = string(line)
+		fields[journalFieldPriority] = "6"
+		return fields
+	}
+
+	fields[journalFieldLogTime] = string(parts[0])
+	fields[journalFieldStream] = string(stream)
+	fields[journalFieldLogTag] = string(parts[2])
+	fields[journalFieldMessage] = string(parts[3])
+	fields[journalFieldPriority] = "6"
+	if stream == LogStreamStderr {
+		fields[journalFieldPriority] = "3"
+	}
+	return fields
+}
+
+// journalEntry encodes fields in journald's native protocol
+func journalEntry(fields map[string]string) []byte {
+	var b bytes.Buffer
+	for key, value := range fields {
+		if !strings.Contains(value, "\n") {
+			fmt.Fprintf(&b, "%s=%s\n", key, value)
+			continue
+		}
+		// Values with newlines are sent with their length
+		b.WriteString(key)
+		b.WriteByte('\n')
+		binary.Write(&b, binary.LittleEndian, uint64(len(value)))
+		b.WriteString(value)
+		b.WriteByte('\n')
+	}
+	return b.Bytes()
+}
+
+// journalReader reads the lines of a container's log sent to the journal
+type journalReader struct {
+	id     string
+	cursor string
+}
+
+// newJournalReader returns a reader of the lines of the container's log sent
+// to the journal
+func (c *Container) newJournalReader() (logLineReader, error) {
+	if _, err := exec.LookPath("journalctl"); err != nil {
+		return nil, errors.Wrapf(err, "journalctl is required to read the log of container %s from the journal", c.ID())
+	}
+	return &journalReader{id: c.ID()}, nil
+}
+
+// readLines returns the lines sent to the journal since the last call, in the
+// format conmon logs them in
+func (r *journalReader) readLines() ([]string, error) {
+	args := []string{"--no-pager", "--output=json", journalFieldIDFull + "=" + r.id}
+	if r.cursor != "" {
+		args = append(args, "--after-cursor="+r.cursor)
+	}
+	output, err := exec.Command("journalctl", args...).Output()
+	if err != nil {
+		return nil, errors.Wrapf(err, "error reading the journal")
+	}
+
+	var lines []string
+	decoder := json.NewDecoder(bytes.NewReader(output))
+	for decoder.More() {
+		entry := make(map[string]interface{})
+		if err := decoder.Decode(&entry); err != nil {
+			return nil, errors.Wrapf(err, "error decoding journal entry")
+		}
+		if cursor, ok := entry[journalFieldCursor].(string); ok {
+			r.cursor = cursor
+		}
+		lines = append(lines, journalLogLine(entry))
+	}
+
+	return lines, nil
+}
+
+func (r *journalReader) close() {}
+
+// journalLogLine returns the line of a container's log a journal entry was
+// sent for
+func journalLogLine(entry map[string]interface{}) string {
+	message := journalFieldString(entry[journalFieldMessage])
+	logTime, _ := entry[journalFieldLogTime].(string)
+	stream, _ := entry[journalFieldStream].(string)
+	tag, _ := entry[journalFieldLogTag].(string)
+	if logTime == "" || stream == "" || tag == "" {
+		return message
+	}
+	return fmt.Sprintf("%s %s %s %s", logTime, stream, tag, message)
+}
+
+// journalFieldString returns the value of a journal field, which journalctl
+// gives as an array of bytes if it is not valid UTF-8
+func journalFieldString(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case []interface{}:
+		b := make([]byte, 0, len(v))
+		for _, c := range v {
+			if n, ok := c.(float64); ok {
+				b = append(b, byte(n))
+			}
+		}
+		return string(b)
+	}
+	return ""
+}
@@ -0,0 +1,42 @@
+// +build linux
+
+package libpod
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJournalEntry(t *testing.T) {
+	assert.Equal(t, "MESSAGE=hello\n", string(journalEntry(map[string]string{"MESSAGE": "hello"})))
+
+	entry := journalEntry(map[string]string{"MESSAGE": "a\nb"})
+	assert.Equal(t, "MESSAGE\n", string(entry[:8]))
+	assert.Equal(t, uint64(3), binary.LittleEndian.Uint64(entry[8:16]))
+	assert.Equal(t, "a\nb\n", string(entry[16:]))
+}
+
+func TestJournalLogLine(t *testing.T) {
+	ctr := &Container{config: &ContainerConfig{ID: "0123456789abcdef", Name: "test"}}
+	line := "2018-10-01T10:00:00.000000000Z stderr F oops"
+
+	fields := ctr.journalLogFields([]byte(line + "\n"))
+	assert.Equal(t, "0123456789ab", fields[journalFieldID])
+	assert.Equal(t, "test", fields[journalFieldName])
+	assert.Equal(t, "oops", fields[journalFieldMessage])
+	assert.Equal(t, "3", fields[journalFieldPriority])
+
+	entry := make(map[string]interface{})
+	for key, value := range fields {
+		entry[key] = value
+	}
+	assert.Equal(t, line, journalLogLine(entry))
+
+	// journalctl gives values that are not valid UTF-8 as arrays of bytes
+	var binaryEntry map[string]interface{}
+	assert.NoError(t, json.Unmarshal([]byte(`{"MESSAGE": [104, 105]}`), &binaryEntry))
+	assert.Equal(t, "hi", journalLogLine(binaryEntry))
+}
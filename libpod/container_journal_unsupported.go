@@ -0,0 +1,14 @@
+// +build !linux
+
+package libpod
+
+func (c *Container) forwardLogToJournal() error {
+	if c.LogDriver() != LogDriverJournald {
+		return nil
+	}
+	return ErrNotImplemented
+}
+
+func (c *Container) newJournalReader() (logLineReader, error) {
+	return nil, ErrNotImplemented
+}
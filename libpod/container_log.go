@@ -0,0 +1,621 @@
+package libpod
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// LogStream is an output stream of a container's process
+type LogStream string
+
+const (
+	// LogStreamStdout is the standard output of the container's process
+	LogStreamStdout LogStream = "stdout"
+	// LogStreamStderr is the standard error of the container's process
+	LogStreamStderr LogStream = "stderr"
+)
+
+// logSuppressedFormat is the message of the lines marking output dropped by a
+// container's log rate limit
+const logSuppressedFormat = "[libpod] %d lines suppressed by log rate limit"
+
+// logRateState is the rate of a container's output in the current second
+type logRateState struct {
+	// Window is the second the counts are for
+	Window time.Time `json:"window,omitempty"`
+	// Lines is the number of lines written in the window
+	Lines int64 `json:"lines,omitempty"`
+	// Bytes is the number of bytes written in the window
+	Bytes int64 `json:"bytes,omitempty"`
+	// Suppressed is the number of lines of each stream dropped in the
+	// window
+	Suppressed map[LogStream]int64 `json:"suppressed,omitempty"`
+}
+
+// admit returns whether a line of size bytes of the given stream, written at
+// t, is within the limits of lines and bytes per second, and counts it.
+// When t starts a new window, the lines suppressed in the previous window are
+// returned as well.
+func (s *logRateState) admit(t time.Time, stream LogStream, size, maxLines, maxBytes int64) (map[LogStream]int64, bool) {
+	var suppressed map[LogStream]int64
+	window := t.Truncate(time.Second)
+	if !window.Equal(s.Window) {
+		suppressed = s.Suppressed
+		s.Window = window
+		s.Lines = 0
+		s.Bytes = 0
+		s.Suppressed = nil
+	}
+
+	if (maxLines > 0 && s.Lines+1 > maxLines) || (maxBytes > 0 && s.Bytes+size > maxBytes) {
+		if s.Suppressed == nil {
+			s.Suppressed = make(map[LogStream]int64)
+		}
+		s.Suppressed[stream]++
+		return suppressed, false
+	}
+
+	s.Lines++
+	s.Bytes += size
+	return suppressed, true
+}
+
+// logSuppressedLine returns the log line marking that count lines of stream
+// were suppressed, in the format of the container's log
+func logSuppressedLine(t time.Time, stream LogStream, count int64) []byte {
+	return []byte(fmt.Sprintf("%s %s F "+logSuppressedFormat+"\n", t.UTC().Format(time.RFC3339Nano), stream, count))
+}
+
+// logStreamPath returns the path of the log holding the given stream of the
+// container's output, when the container's log streams are split
+func (c *Container) logStreamPath(stream LogStream) string {
+	return filepath.Join(c.config.StaticDir, string(stream)+".log")
+}
+
+// splitLogStreams copies the lines conmon added to the container's log since we
+// last looked into the logs of the streams they belong to, rotating each
+// stream's log when it grows beyond the container's limit
+func (c *Container) splitLogStreams() error {
+	if !c.config.SplitLogStreams {
+		return nil
+	}
+
+	logFile, err := os.Open(c.LogPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return errors.Wrapf(err, "error opening log of container %s", c.ID())
+	}
+	defer logFile.Close()
+
+	info, err := logFile.Stat()
+	if err != nil {
+		return errors.Wrapf(err, "error accessing log of container %s", c.ID())
+	}
+	// The log was truncated or replaced, start over
+	if info.Size() < c.state.LogSplitOffset {
+		c.state.LogSplitOffset = 0
+	}
+	if info.Size() == c.state.LogSplitOffset {
+		return nil
+	}
+
+	if _, err := logFile.Seek(c.state.LogSplitOffset, io.SeekStart); err != nil {
+		return errors.Wrapf(err, "error reading log of container %s", c.ID())
+	}
+
+	streams := make(map[LogStream]*os.File)
+	defer func() {
+		for _, f := range streams {
+			f.Close()
+		}
+	}()
+
+	reader := bufio.NewReader(logFile)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err != nil {
+			// Leave partial lines until conmon finishes them
+			if err == io.EOF {
+				break
+			}
+			return errors.Wrapf(err, "error reading log of container %s", c.ID())
+		}
+
+		stream, ok := logLineStream(line)
+		if !ok {
+			logrus.Debugf("Skipping malformed line in log of container %s", c.ID())
+			c.state.LogSplitOffset += int64(len(line))
+			continue
+		}
+
+		if c.config.LogRateLimitLines > 0 || c.config.LogRateLimitBytes > 0 {
+			t, ok := logLineTime(line)
+			if !ok {
+				t = time.Now()
+			}
+			if c.state.LogRate == nil {
+				c.state.LogRate = new(logRateState)
+			}
+			suppressed, write := c.state.LogRate.admit(t, stream, int64(len(line)), c.config.LogRateLimitLines, c.config.LogRateLimitBytes)
+			for _, s := range []LogStream{LogStreamStdout, LogStreamStderr} {
+				if suppressed[s] == 0 {
+					continue
+				}
+				if err := c.writeLogStreamLine(streams, s, logSuppressedLine(t, s, suppressed[s])); err != nil {
+					return err
+				}
+			}
+			if !write {
+				c.state.LogSplitOffset += int64(len(line))
+				continue
+			}
+		}
+
+		if err := c.writeLogStreamLine(streams, stream, line); err != nil {
+			return err
+		}
+		c.state.LogSplitOffset += int64(len(line))
+	}
+
+	return nil
+}
+
+// writeLogStreamLine writes a line to the log of the given stream
+func (c *Container) writeLogStreamLine(streams map[LogStream]*os.File, stream LogStream, line []byte) error {
+	f, err := c.openLogStream(streams, stream, int64(len(line)))
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(line); err != nil {
+		return errors.Wrapf(err, "error writing %s log of container %s", stream, c.ID())
+	}
+	return nil
+}
+
+// openLogStream returns the open log of the given stream from streams, opening
+// it if necessary. If writing size more bytes would take the log over the
+// container's limit, the log is rotated first.
+// Only one rotated log is kept for each stream.
+func (c *Container) openLogStream(streams map[LogStream]*os.File, stream LogStream, size int64) (*os.File, error) {
+	path := c.logStreamPath(stream)
+
+	if c.config.LogStreamMaxSize > 0 {
+		info, err := os.Stat(path)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, errors.Wrapf(err, "error accessing %s log of container %s", stream, c.ID())
+		}
+		if err == nil && info.Size() > 0 && info.Size()+size > c.config.LogStreamMaxSize {
+			if f, ok := streams[stream]; ok {
+				f.Close()
+				delete(streams, stream)
+			}
+			if err := os.Rename(path, path+".1"); err != nil {
+				return nil, errors.Wrapf(err, "error rotating %s log of container %s", stream, c.ID())
+			}
+		}
+	}
+
+	if f, ok := streams[stream]; ok {
+		return f, nil
+	}
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error opening %s log of container %s", stream, c.ID())
+	}
+	streams[stream] = f
+
+	return f, nil
+}
+
+// rotateLog rotates the container's log once it reaches LogSizeMax bytes,
+// returning whether it did. The log must have been split and sent to the
+// journal first. The log is copied to <log>.1, after older rotated
+// logs are shifted up and those beyond LogMaxFiles removed, and then emptied.
+// conmon keeps the log open for appending, so it is truncated in place rather
+// than renamed; the copy is renamed into place so readers never see a partial
+// rotated log. Output conmon writes between the copy and the truncation is
+// lost. Must be called with the container lock held.
+func (c *Container) rotateLog() (bool, error) {
+	if c.config.LogSizeMax == 0 {
+		return false, nil
+	}
+
+	path := c.LogPath()
+	info, err := os.Stat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, errors.Wrapf(err, "error accessing log of container %s", c.ID())
+	}
+	if info.Size() < c.config.LogSizeMax {
+		return false, nil
+	}
+
+	if err := shiftRotatedLogs(path, c.config.LogMaxFiles); err != nil {
+		return false, errors.Wrapf(err, "error rotating log of container %s", c.ID())
+	}
+	if c.config.LogMaxFiles > 0 {
+		if err := copyFileAtomic(path, rotatedLogPath(path, 1)); err != nil {
+			return false, errors.Wrapf(err, "error rotating log of container %s", c.ID())
+		}
+	}
+	if err := os.Truncate(path, 0); err != nil {
+		return false, errors.Wrapf(err, "error truncating log of container %s", c.ID())
+	}
+	c.state.LogSplitOffset = 0
+	c.state.LogJournalOffset = 0
+
+	logrus.Debugf("Rotated log of container %s at %d bytes", c.ID(), info.Size())
+	return true, nil
+}
+
+// rotatedLogPath returns the path of the nth rotated log of the log at path
+func rotatedLogPath(path string, n int) string {
+	return fmt.Sprintf("%s.%d", path, n)
+}
+
+// shiftRotatedLogs renames the rotated logs of the log at path, <path>.1 to
+// <path>.2 and so on, making room for a new <path>.1, and removes those that
+// would exceed maxFiles
+func shiftRotatedLogs(path string, maxFiles int) error {
+	if maxFiles == 0 {
+		return nil
+	}
+	if err := os.Remove(rotatedLogPath(path, maxFiles)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	for n := maxFiles - 1; n >= 1; n-- {
+		if err := os.Rename(rotatedLogPath(path, n), rotatedLogPath(path, n+1)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// copyFileAtomic copies the file at src to dest, through a temporary file
+// renamed to dest once complete
+func copyFileAtomic(src, dest string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	tmp, err := ioutil.TempFile(filepath.Dir(dest), filepath.Base(dest)+".")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, in); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(0600); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), dest)
+}
+
+// LogOptions are options for reading a container's log with LogLines
+type LogOptions struct {
+	// Follow keeps sending lines as they are logged until the container
+	// stops
+	Follow bool
+	// Since, if set, leaves out lines logged before it
+	Since time.Time
+	// Tail, if positive, only sends the last Tail lines already logged
+	Tail int
+}
+
+// logFollowInterval is how often a followed log is checked for new lines
+const logFollowInterval = 250 * time.Millisecond
+
+// logFollowDone returns whether a container whose log is followed has stopped,
+// so no more lines will be logged
+func (c *Container) logFollowDone() (bool, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if err := c.syncContainer(); err != nil {
+		if errors.Cause(err) == ErrCtrRemoved || errors.Cause(err) == ErrNoSuchCtr {
+			return true, nil
+		}
+		return true, err
+	}
+
+	switch c.state.State {
+	case ContainerStateCreated, ContainerStateRunning, ContainerStatePaused:
+		return false, nil
+	}
+	return true, nil
+}
+
+// logLineReader reads the lines logged by a container since it last read them
+type logLineReader interface {
+	readLines() ([]string, error)
+	close()
+}
+
+// newLogLineReader returns a reader of the container's log, from the journal
+// if its log driver is journald
+func (c *Container) newLogLineReader() (logLineReader, error) {
+	if c.LogDriver() == LogDriverJournald {
+		return c.newJournalReader()
+	}
+	return &logFileReader{path: c.LogPath()}, nil
+}
+
+// logFileReader reads the lines appended to a log since it last read it,
+// starting over if the log is truncated when it is rotated
+type logFileReader struct {
+	path    string
+	file    *os.File
+	offset  int64
+	partial []byte
+}
+
+// readLines returns the complete lines appended to the log since the last
+// call, without their trailing newlines. A log that does not exist yet has no
+// lines.
+func (r *logFileReader) readLines() ([]string, error) {
+	if r.file == nil {
+		f, err := os.Open(r.path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil, nil
+			}
+			return nil, err
+		}
+		r.file = f
+	}
+
+	info, err := r.file.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if info.Size() < r.offset {
+		r.offset = 0
+		r.partial = nil
+	}
+	if _, err := r.file.Seek(r.offset, io.SeekStart); err != nil {
+		return nil, err
+	}
+	data, err := ioutil.ReadAll(r.file)
+	if err != nil {
+		return nil, err
+	}
+	r.offset += int64(len(data))
+
+	data = append(r.partial, data...)
+	var lines []string
+	for {
+		idx := bytes.IndexByte(data, '\n')
+		if idx < 0 {
+			break
+		}
+		lines = append(lines, string(data[:idx]))
+		data = data[idx+1:]
+	}
+	r.partial = append([]byte(nil), data...)
+
+	return lines, nil
+}
+
+func (r *logFileReader) close() {
+	if r.file != nil {
+		r.file.Close()
+	}
+}
+
+// filterLogLines returns the lines logged at or after since, if it is set, and
+// of those the last tail, if tail is positive
+func filterLogLines(lines []string, since time.Time, tail int) []string {
+	if !since.IsZero() {
+		filtered := make([]string, 0, len(lines))
+		for _, line := range lines {
+			if t, ok := logLineTime([]byte(line)); ok && t.Before(since) {
+				continue
+			}
+			filtered = append(filtered, line)
+		}
+		lines = filtered
+	}
+	if tail > 0 && len(lines) > tail {
+		lines = lines[len(lines)-tail:]
+	}
+	return lines
+}
+
+// logLineStream returns the stream a line of a CRI-formatted log belongs to
+func logLineStream(line []byte) (LogStream, bool) {
+	fields := bytes.SplitN(line, []byte(" "), 3)
+	if len(fields) < 3 {
+		return "", false
+	}
+
+	switch stream := LogStream(fields[1]); stream {
+	case LogStreamStdout, LogStreamStderr:
+		return stream, true
+	}
+
+	return "", false
+}
+
+// logLineTime returns the time a line of a CRI-formatted log was written
+func logLineTime(line []byte) (time.Time, bool) {
+	idx := bytes.IndexByte(line, ' ')
+	if idx < 0 {
+		return time.Time{}, false
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, string(line[:idx]))
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return t, true
+}
+
+// writeJournalLog writes the lines of the given streams the container sent to
+// the journal to w
+func (c *Container) writeJournalLog(streams []LogStream, w io.Writer) error {
+	// Pick up lines logged since we last sent the log to the journal
+	offset := c.state.LogJournalOffset
+	if err := c.forwardLogToJournal(); err != nil {
+		return err
+	}
+	if c.state.LogJournalOffset != offset {
+		if err := c.save(); err != nil {
+			return err
+		}
+	}
+
+	reader, err := c.newJournalReader()
+	if err != nil {
+		return err
+	}
+	defer reader.close()
+
+	lines, err := reader.readLines()
+	if err != nil {
+		return err
+	}
+	for _, line := range lines {
+		stream, ok := logLineStream([]byte(line))
+		if !ok {
+			continue
+		}
+		for _, s := range streams {
+			if s != stream {
+				continue
+			}
+			if _, err := io.WriteString(w, line+"\n"); err != nil {
+				return errors.Wrapf(err, "error writing log of container %s", c.ID())
+			}
+		}
+	}
+
+	return nil
+}
+
+// writeLogStreams writes the logs of the given streams to w, merging them in
+// the order their lines were written
+func (c *Container) writeLogStreams(streams []LogStream, w io.Writer) error {
+	readers := make([]*logStreamReader, 0, len(streams))
+	defer func() {
+		for _, r := range readers {
+			r.close()
+		}
+	}()
+	for _, stream := range streams {
+		r, err := newLogStreamReader(c.logStreamPath(stream))
+		if err != nil {
+			return errors.Wrapf(err, "error opening %s log of container %s", stream, c.ID())
+		}
+		readers = append(readers, r)
+	}
+
+	return mergeLogLines(readers, w)
+}
+
+// logStreamReader reads the lines of a stream's log, starting with its rotated
+// log if there is one
+type logStreamReader struct {
+	files   []*os.File
+	reader  *bufio.Reader
+	next    []byte
+	nextErr error
+}
+
+// newLogStreamReader opens the log of a stream at path, along with its rotated
+// log. Logs that do not exist are treated as empty.
+func newLogStreamReader(path string) (*logStreamReader, error) {
+	r := &logStreamReader{}
+	for _, p := range []string{path + ".1", path} {
+		f, err := os.Open(p)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			r.close()
+			return nil, err
+		}
+		r.files = append(r.files, f)
+	}
+
+	readers := make([]io.Reader, 0, len(r.files))
+	for _, f := range r.files {
+		readers = append(readers, f)
+	}
+	r.reader = bufio.NewReader(io.MultiReader(readers...))
+	r.advance()
+
+	return r, nil
+}
+
+// advance reads the next line of the log
+func (r *logStreamReader) advance() {
+	r.next, r.nextErr = r.reader.ReadBytes('\n')
+	if r.nextErr == io.EOF && len(r.next) > 0 {
+		r.nextErr = nil
+	}
+}
+
+func (r *logStreamReader) close() {
+	for _, f := range r.files {
+		f.Close()
+	}
+}
+
+// mergeLogLines writes the lines of the given logs to w, always writing the
+// earliest line next. Lines without a valid timestamp are written as soon as
+// they are read.
+func mergeLogLines(readers []*logStreamReader, w io.Writer) error {
+	for {
+		var earliest *logStreamReader
+		var earliestTime time.Time
+		for _, r := range readers {
+			if r.nextErr == io.EOF {
+				continue
+			}
+			if r.nextErr != nil {
+				return r.nextErr
+			}
+			t, ok := logLineTime(r.next)
+			if !ok {
+				earliest = r
+				break
+			}
+			if earliest == nil || t.Before(earliestTime) {
+				earliest = r
+				earliestTime = t
+			}
+		}
+		if earliest == nil {
+			return nil
+		}
+
+		if _, err := w.Write(earliest.next); err != nil {
+			return err
+		}
+		earliest.advance()
+	}
+}
@@ -0,0 +1,181 @@
+package libpod
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogLineStream(t *testing.T) {
+	stream, ok := logLineStream([]byte("2016-10-06T00:17:09.669794202Z stdout F log content\n"))
+	assert.True(t, ok)
+	assert.Equal(t, LogStreamStdout, stream)
+
+	stream, ok = logLineStream([]byte("2016-10-06T00:17:09.669794202Z stderr P partial"))
+	assert.True(t, ok)
+	assert.Equal(t, LogStreamStderr, stream)
+
+	_, ok = logLineStream([]byte("2016-10-06T00:17:09.669794202Z stdin F content\n"))
+	assert.False(t, ok)
+	_, ok = logLineStream([]byte("garbage\n"))
+	assert.False(t, ok)
+}
+
+func TestMergeLogLines(t *testing.T) {
+	dir, err := ioutil.TempDir("", "libpod_test_")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	stdout := filepath.Join(dir, "stdout.log")
+	stderr := filepath.Join(dir, "stderr.log")
+	require.NoError(t, ioutil.WriteFile(stdout+".1", []byte("2018-01-01T00:00:01Z stdout F one\n"), 0600))
+	require.NoError(t, ioutil.WriteFile(stdout, []byte("2018-01-01T00:00:03Z stdout F three\n2018-01-01T00:00:05Z stdout F five\n"), 0600))
+	require.NoError(t, ioutil.WriteFile(stderr, []byte("2018-01-01T00:00:02Z stderr F two\n2018-01-01T00:00:04Z stderr F four\n"), 0600))
+
+	var readers []*logStreamReader
+	for _, path := range []string{stdout, stderr, filepath.Join(dir, "missing.log")} {
+		r, err := newLogStreamReader(path)
+		require.NoError(t, err)
+		defer r.close()
+		readers = append(readers, r)
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, mergeLogLines(readers, &buf))
+	assert.Equal(t, "2018-01-01T00:00:01Z stdout F one\n"+
+		"2018-01-01T00:00:02Z stderr F two\n"+
+		"2018-01-01T00:00:03Z stdout F three\n"+
+		"2018-01-01T00:00:04Z stderr F four\n"+
+		"2018-01-01T00:00:05Z stdout F five\n", buf.String())
+}
+
+func TestLogRateStateAdmit(t *testing.T) {
+	base := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := new(logRateState)
+
+	suppressed, ok := s.admit(base, LogStreamStdout, 10, 2, 0)
+	assert.True(t, ok)
+	assert.Nil(t, suppressed)
+	_, ok = s.admit(base.Add(100*time.Millisecond), LogStreamStdout, 10, 2, 0)
+	assert.True(t, ok)
+	_, ok = s.admit(base.Add(200*time.Millisecond), LogStreamStdout, 10, 2, 0)
+	assert.False(t, ok)
+	_, ok = s.admit(base.Add(300*time.Millisecond), LogStreamStderr, 10, 2, 0)
+	assert.False(t, ok)
+
+	// The next second reports what was suppressed
+	suppressed, ok = s.admit(base.Add(time.Second), LogStreamStdout, 10, 2, 0)
+	assert.True(t, ok)
+	assert.Equal(t, map[LogStream]int64{LogStreamStdout: 1, LogStreamStderr: 1}, suppressed)
+
+	// Byte limits
+	s = new(logRateState)
+	_, ok = s.admit(base, LogStreamStdout, 60, 0, 100)
+	assert.True(t, ok)
+	_, ok = s.admit(base, LogStreamStdout, 60, 0, 100)
+	assert.False(t, ok)
+}
+
+func TestLogSuppressedLine(t *testing.T) {
+	line := logSuppressedLine(time.Date(2018, 1, 1, 0, 0, 1, 0, time.UTC), LogStreamStderr, 42)
+	assert.Equal(t, "2018-01-01T00:00:01Z stderr F [libpod] 42 lines suppressed by log rate limit\n", string(line))
+
+	stream, ok := logLineStream(line)
+	assert.True(t, ok)
+	assert.Equal(t, LogStreamStderr, stream)
+	_, ok = logLineTime(line)
+	assert.True(t, ok)
+}
+
+func TestRotateLog(t *testing.T) {
+	dir, err := ioutil.TempDir("", "libpod-log")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "ctr.log")
+	ctr := &Container{
+		config: &ContainerConfig{ID: "test", LogPath: path, LogSizeMax: 4, LogMaxFiles: 2},
+		state:  &containerState{},
+	}
+
+	for _, contents := range []string{"one\n", "two\n", "three\n"} {
+		require.NoError(t, ioutil.WriteFile(path, []byte(contents), 0600))
+		rotated, err := ctr.rotateLog()
+		require.NoError(t, err)
+		assert.True(t, rotated)
+	}
+
+	for n, want := range map[int]string{1: "three\n", 2: "two\n"} {
+		contents, err := ioutil.ReadFile(rotatedLogPath(path, n))
+		require.NoError(t, err)
+		assert.Equal(t, want, string(contents))
+	}
+	_, err = os.Stat(rotatedLogPath(path, 3))
+	assert.True(t, os.IsNotExist(err))
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), info.Size())
+
+	// Below the limit, the log is left as it is
+	require.NoError(t, ioutil.WriteFile(path, []byte("a\n"), 0600))
+	rotated, err := ctr.rotateLog()
+	require.NoError(t, err)
+	assert.False(t, rotated)
+}
+
+func TestLogFileReader(t *testing.T) {
+	dir, err := ioutil.TempDir("", "libpod-log")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "ctr.log")
+	reader := &logFileReader{path: path}
+	defer reader.close()
+
+	// The log does not exist yet
+	lines, err := reader.readLines()
+	require.NoError(t, err)
+	assert.Empty(t, lines)
+
+	require.NoError(t, ioutil.WriteFile(path, []byte("one\ntw"), 0600))
+	lines, err = reader.readLines()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"one"}, lines)
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0600)
+	require.NoError(t, err)
+	_, err = f.WriteString("o\nthree\n")
+	require.NoError(t, err)
+	lines, err = reader.readLines()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"two", "three"}, lines)
+
+	// Rotation truncates the log
+	require.NoError(t, f.Truncate(0))
+	_, err = f.WriteString("four\n")
+	require.NoError(t, err)
+	f.Close()
+	lines, err = reader.readLines()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"four"}, lines)
+}
+
+func TestFilterLogLines(t *testing.T) {
+	lines := []string{
+		"2018-01-01T00:00:01Z stdout F one",
+		"2018-01-01T00:00:02Z stdout F two",
+		"2018-01-01T00:00:03Z stderr F three",
+	}
+
+	assert.Equal(t, lines, filterLogLines(lines, time.Time{}, 0))
+	assert.Equal(t, lines[1:], filterLogLines(lines, time.Date(2018, 1, 1, 0, 0, 2, 0, time.UTC), 0))
+	assert.Equal(t, lines[2:], filterLogLines(lines, time.Time{}, 1))
+	assert.Equal(t, lines, filterLogLines(lines, time.Time{}, 10))
+}
@@ -0,0 +1,303 @@
+package libpod
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/containers/storage/pkg/archive"
+	"github.com/containers/storage/pkg/chrootarchive"
+	"github.com/cyphar/filepath-securejoin"
+	spec "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// Files written to the container's bundle for a migration archive
+const (
+	// migrationConfigFile holds the container's configuration
+	migrationConfigFile = "migration-config.json"
+	// migrationRootfsDiffFile is a tar archive of the files added or
+	// modified in the container's root filesystem
+	migrationRootfsDiffFile = "migration-rootfs-diff.tar"
+	// migrationDeletedFilesFile lists the files deleted from the
+	// container's root filesystem, one per line
+	migrationDeletedFilesFile = "migration-deleted.files"
+)
+
+// migrationBundleFiles are the files of the container's bundle needed to
+// restore it, included in a migration archive as they are
+var migrationBundleFiles = []string{"checkpoint", "config.json", "network.status"}
+
+// exportForMigration checkpoints the container and writes a tar archive of its
+// checkpoint, configuration and root filesystem changes to w, which
+// importMigration restores on another host.
+// If the archive cannot be written, the container is restored.
+func (c *Container) exportForMigration(ctx context.Context, w io.Writer) (err error) {
+	if c.state.State != ContainerStateRunning {
+		return errors.Wrapf(ErrCtrStateInvalid, "container %s is not running, cannot migrate it", c.ID())
+	}
+	if c.config.Rootfs != "" {
+		return errors.Wrapf(ErrInvalidArg, "container %s uses an external root filesystem, cannot migrate it", c.ID())
+	}
+	if c.config.Pod != "" {
+		return errors.Wrapf(ErrInvalidArg, "container %s is part of pod %s, cannot migrate it", c.ID(), c.config.Pod)
+	}
+	if deps := c.Dependencies(); len(deps) > 0 {
+		return errors.Wrapf(ErrInvalidArg, "container %s depends on containers %s, cannot migrate it", c.ID(), strings.Join(deps, ", "))
+	}
+
+	if err := c.checkpoint(ctx, true); err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			if err2 := c.syncContainer(); err2 != nil {
+				logrus.Errorf("Error syncing container %s after failed migration: %v", c.ID(), err2)
+				return
+			}
+			if err2 := c.restore(ctx, false); err2 != nil {
+				logrus.Errorf("Error restoring container %s after failed migration: %v", c.ID(), err2)
+			}
+		}
+	}()
+
+	files := []string{migrationConfigFile, migrationRootfsDiffFile, migrationDeletedFilesFile}
+	defer func() {
+		for _, file := range files {
+			if err := os.Remove(filepath.Join(c.bundlePath(), file)); err != nil && !os.IsNotExist(err) {
+				logrus.Debugf("Non-fatal: removal of migration file %s of container %s failed: %v", file, c.ID(), err)
+			}
+		}
+	}()
+
+	configJSON, err := json.Marshal(c.config)
+	if err != nil {
+		return errors.Wrapf(err, "error encoding configuration of container %s", c.ID())
+	}
+	if err := ioutil.WriteFile(filepath.Join(c.bundlePath(), migrationConfigFile), configJSON, 0600); err != nil {
+		return errors.Wrapf(err, "error writing configuration of container %s", c.ID())
+	}
+
+	if err := c.writeMigrationChanges(); err != nil {
+		return err
+	}
+
+	input, err := archive.TarWithOptions(c.bundlePath(), &archive.TarOptions{
+		Compression:  archive.Uncompressed,
+		IncludeFiles: existingFiles(c.bundlePath(), append(files, migrationBundleFiles...)),
+	})
+	if err != nil {
+		return errors.Wrapf(err, "error reading migration files of container %s", c.ID())
+	}
+	defer input.Close()
+
+	if _, err := io.Copy(w, input); err != nil {
+		return errors.Wrapf(err, "error writing migration archive of container %s", c.ID())
+	}
+
+	return nil
+}
+
+// writeMigrationChanges writes the changes to the container's root filesystem
+// since its image to its bundle
+func (c *Container) writeMigrationChanges() error {
+	diff, err := os.OpenFile(filepath.Join(c.bundlePath(), migrationRootfsDiffFile), os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return errors.Wrapf(err, "error creating root filesystem changes of container %s", c.ID())
+	}
+	defer diff.Close()
+
+	deleted, err := os.OpenFile(filepath.Join(c.bundlePath(), migrationDeletedFilesFile), os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return errors.Wrapf(err, "error creating root filesystem deletions of container %s", c.ID())
+	}
+	defer deleted.Close()
+
+	if err := c.exportChanges("", diff, deleted); err != nil {
+		return err
+	}
+
+	if err := diff.Close(); err != nil {
+		return errors.Wrapf(err, "error writing root filesystem changes of container %s", c.ID())
+	}
+	if err := deleted.Close(); err != nil {
+		return errors.Wrapf(err, "error writing root filesystem deletions of container %s", c.ID())
+	}
+
+	return nil
+}
+
+// importMigration creates a container from a migration archive written by
+// exportForMigration and restores it.
+// If the container cannot be restored, it is removed again.
+func (r *Runtime) importMigration(ctx context.Context, input io.Reader) (_ *Container, err error) {
+	dir, err := ioutil.TempDir(r.config.TmpDir, "migration")
+	if err != nil {
+		return nil, errors.Wrapf(err, "error creating directory for migration archive")
+	}
+	defer func() {
+		if err := os.RemoveAll(dir); err != nil {
+			logrus.Errorf("Error removing migration archive directory %s: %v", dir, err)
+		}
+	}()
+
+	if err := chrootarchive.Untar(input, dir, nil); err != nil {
+		return nil, errors.Wrapf(err, "error reading migration archive")
+	}
+
+	// Fail before creating the container if its checkpoint cannot be
+	// restored on this host
+	if err := validateCheckpointHost(filepath.Join(dir, "checkpoint"), r.ociRuntime.name); err != nil {
+		return nil, err
+	}
+
+	configJSON, err := ioutil.ReadFile(filepath.Join(dir, migrationConfigFile))
+	if err != nil {
+		return nil, errors.Wrapf(ErrInvalidArg, "migration archive has no container configuration: %v", err)
+	}
+	config := new(ContainerConfig)
+	if err := json.Unmarshal(configJSON, config); err != nil {
+		return nil, errors.Wrapf(ErrInvalidArg, "migration archive has an invalid container configuration: %v", err)
+	}
+	if config.Spec == nil {
+		return nil, errors.Wrapf(ErrInvalidArg, "migration archive has no runtime spec for container %s", config.ID)
+	}
+
+	ctr, err := r.newContainer(ctx, config.Spec, withMigratedConfig(config))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			if err2 := r.removeContainer(ctx, ctr, true); err2 != nil {
+				logrus.Errorf("Error removing partially imported container %s: %v", ctr.ID(), err2)
+			}
+		}
+	}()
+
+	if err := copyWithTar(dir, existingFiles(dir, migrationBundleFiles), ctr.bundlePath()); err != nil {
+		return nil, errors.Wrapf(err, "error copying checkpoint of container %s", ctr.ID())
+	}
+
+	// The spec refers to the container's directories on the other host
+	bundleSpec, err := ctr.readBundleSpec()
+	if err != nil {
+		return nil, err
+	}
+	if config.ShmDir != ctr.config.ShmDir {
+		rebaseMountSources(bundleSpec.Mounts, config.ShmDir, ctr.config.ShmDir)
+	}
+	rebaseMountSources(bundleSpec.Mounts, config.StaticDir, ctr.config.StaticDir)
+	if err := ctr.saveSpec(bundleSpec); err != nil {
+		return nil, err
+	}
+
+	if err := ctr.applyMigrationChanges(dir); err != nil {
+		return nil, err
+	}
+
+	ctr.lock.Lock()
+	err = ctr.restore(ctx, false)
+	ctr.lock.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	return ctr, nil
+}
+
+// applyMigrationChanges applies the root filesystem changes in the migration
+// archive extracted to dir to the container's root filesystem
+func (c *Container) applyMigrationChanges(dir string) (err error) {
+	mountPoint, err := c.runtime.store.Mount(c.ID(), c.config.MountLabel)
+	if err != nil {
+		return errors.Wrapf(err, "error mounting container %q", c.ID())
+	}
+	defer func() {
+		if _, err := c.runtime.store.Unmount(c.ID(), false); err != nil {
+			logrus.Errorf("error unmounting container %q: %v", c.ID(), err)
+		}
+	}()
+
+	diff, err := os.Open(filepath.Join(dir, migrationRootfsDiffFile))
+	if err != nil {
+		return errors.Wrapf(ErrInvalidArg, "migration archive has no root filesystem changes for container %s: %v", c.ID(), err)
+	}
+	defer diff.Close()
+
+	if err := chrootarchive.Untar(diff, mountPoint, nil); err != nil {
+		return errors.Wrapf(err, "error applying root filesystem changes of container %s", c.ID())
+	}
+
+	deleted, err := os.Open(filepath.Join(dir, migrationDeletedFilesFile))
+	if err != nil {
+		return errors.Wrapf(ErrInvalidArg, "migration archive has no root filesystem deletions for container %s: %v", c.ID(), err)
+	}
+	defer deleted.Close()
+
+	scanner := bufio.NewScanner(deleted)
+	for scanner.Scan() {
+		if scanner.Text() == "" {
+			continue
+		}
+		path, err := securejoin.SecureJoin(mountPoint, scanner.Text())
+		if err != nil {
+			return errors.Wrapf(err, "error resolving deleted file %s of container %s", scanner.Text(), c.ID())
+		}
+		if err := os.RemoveAll(path); err != nil {
+			return errors.Wrapf(err, "error deleting %s from container %s", scanner.Text(), c.ID())
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return errors.Wrapf(err, "error reading root filesystem deletions of container %s", c.ID())
+	}
+
+	return nil
+}
+
+// rebaseMountSources moves the sources of mounts in the directory oldDir to
+// newDir
+func rebaseMountSources(mounts []spec.Mount, oldDir, newDir string) {
+	if oldDir == "" {
+		return
+	}
+	for i, mount := range mounts {
+		if mount.Source == oldDir {
+			mounts[i].Source = newDir
+		} else if strings.HasPrefix(mount.Source, oldDir+"/") {
+			mounts[i].Source = filepath.Join(newDir, strings.TrimPrefix(mount.Source, oldDir+"/"))
+		}
+	}
+}
+
+// existingFiles returns the files that exist in dir
+func existingFiles(dir string, files []string) []string {
+	existing := []string{}
+	for _, file := range files {
+		if _, err := os.Lstat(filepath.Join(dir, file)); err == nil {
+			existing = append(existing, file)
+		}
+	}
+	return existing
+}
+
+// copyWithTar copies the given files in src to dest, preserving ownership,
+// permissions and extended attributes
+func copyWithTar(src string, files []string, dest string) error {
+	input, err := archive.TarWithOptions(src, &archive.TarOptions{
+		Compression:  archive.Uncompressed,
+		IncludeFiles: files,
+	})
+	if err != nil {
+		return err
+	}
+	defer input.Close()
+
+	return chrootarchive.Untar(input, dest, nil)
+}
@@ -0,0 +1,30 @@
+package libpod
+
+import (
+	"testing"
+
+	spec "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRebaseMountSources(t *testing.T) {
+	mounts := []spec.Mount{
+		{Destination: "/etc/resolv.conf", Source: "/old/userdata/resolv.conf"},
+		{Destination: "/dev/shm", Source: "/old/userdata"},
+		{Destination: "/data", Source: "/old/userdataextra"},
+		{Destination: "/proc", Source: "proc"},
+	}
+	rebaseMountSources(mounts, "/old/userdata", "/new/userdata")
+
+	assert.Equal(t, "/new/userdata/resolv.conf", mounts[0].Source)
+	assert.Equal(t, "/new/userdata", mounts[1].Source)
+	assert.Equal(t, "/old/userdataextra", mounts[2].Source)
+	assert.Equal(t, "proc", mounts[3].Source)
+}
+
+func TestIsMigratedShmDir(t *testing.T) {
+	assert.True(t, isMigratedShmDir(&ContainerConfig{ID: "abc", StaticDir: "/static/abc", ShmDir: "/static/abc/shm"}))
+	assert.True(t, isMigratedShmDir(&ContainerConfig{ID: "abc", StaticDir: "/static/abc", ShmDir: "/tmp/containers-root/abc/shm"}))
+	assert.False(t, isMigratedShmDir(&ContainerConfig{ID: "abc", StaticDir: "/static/abc", ShmDir: "/static/def/shm"}))
+	assert.False(t, isMigratedShmDir(&ContainerConfig{ID: "abc", StaticDir: "/static/abc"}))
+}
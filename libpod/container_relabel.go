@@ -0,0 +1,113 @@
+package libpod
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/containers/libpod/pkg/ctime"
+	"github.com/containers/storage/pkg/archive"
+	"github.com/opencontainers/selinux/go-selinux/label"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// relabelRootfs relabels the container's root filesystem with its mount label
+// and returns the number of paths that were relabeled, or -1 if the whole root
+// filesystem was.
+// If incremental is set and the root filesystem was relabeled before, only the
+// files the container added or modified since are relabeled.
+func (c *Container) relabelRootfs(incremental bool) (int, error) {
+	if c.config.MountLabel == "" {
+		return 0, nil
+	}
+
+	mountPoint := c.state.Mountpoint
+	if !c.state.Mounted {
+		mount, err := c.runtime.store.Mount(c.ID(), c.config.MountLabel)
+		if err != nil {
+			return 0, errors.Wrapf(err, "error mounting container %q", c.ID())
+		}
+		mountPoint = mount
+		defer func() {
+			if _, err := c.runtime.store.Unmount(c.ID(), false); err != nil {
+				logrus.Errorf("error unmounting container %q: %v", c.ID(), err)
+			}
+		}()
+	}
+
+	// Files changed while we relabel may be missed, so the next relabel
+	// must start from before this one
+	relabelPoint := time.Now()
+
+	var (
+		relabeled int
+		err       error
+	)
+	// A root filesystem not in storage has no layer to compute changes of
+	if incremental && !c.state.RelabelPoint.IsZero() && c.config.Rootfs == "" {
+		relabeled, err = c.relabelChanges(mountPoint, c.state.RelabelPoint)
+	} else {
+		logrus.Debugf("Relabeling all of the root filesystem of container %s", c.ID())
+		relabeled = -1
+		err = label.Relabel(mountPoint, c.config.MountLabel, false)
+	}
+	if err != nil {
+		return 0, errors.Wrapf(err, "error relabeling root filesystem of container %s", c.ID())
+	}
+
+	c.state.RelabelPoint = relabelPoint
+	if err := c.save(); err != nil {
+		return 0, err
+	}
+
+	return relabeled, nil
+}
+
+// relabelChanges relabels the files in the container's root filesystem, mounted
+// at mountPoint, that the container added or modified since the given time
+func (c *Container) relabelChanges(mountPoint string, since time.Time) (int, error) {
+	storageCtr, err := c.runtime.store.Container(c.ID())
+	if err != nil {
+		return 0, errors.Wrapf(err, "error retrieving storage for container %s", c.ID())
+	}
+	changes, err := c.runtime.store.Changes("", storageCtr.LayerID)
+	if err != nil {
+		return 0, errors.Wrapf(err, "error computing changes of container %s", c.ID())
+	}
+
+	relabeled := 0
+	for _, path := range changedSince(changes, mountPoint, since) {
+		if err := label.SetFileLabel(filepath.Join(mountPoint, path), c.config.MountLabel); err != nil {
+			if os.IsNotExist(err) {
+				// Removed since we computed the changes
+				continue
+			}
+			return relabeled, errors.Wrapf(err, "error relabeling %s", path)
+		}
+		relabeled++
+	}
+
+	logrus.Debugf("Relabeled %d changed paths in the root filesystem of container %s", relabeled, c.ID())
+
+	return relabeled, nil
+}
+
+// changedSince returns the paths of the added or modified files among changes
+// whose inodes, under root, changed after since
+func changedSince(changes []archive.Change, root string, since time.Time) []string {
+	paths := []string{}
+	for _, change := range changes {
+		if change.Kind == archive.ChangeDelete {
+			continue
+		}
+		info, err := os.Lstat(filepath.Join(root, change.Path))
+		if err != nil {
+			continue
+		}
+		if ctime.Created(info).After(since) {
+			paths = append(paths, change.Path)
+		}
+	}
+	return paths
+}
@@ -0,0 +1,32 @@
+package libpod
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/containers/storage/pkg/archive"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChangedSince(t *testing.T) {
+	root, err := ioutil.TempDir("", "libpod_test_")
+	require.NoError(t, err)
+	defer os.RemoveAll(root)
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(root, "old"), []byte("old"), 0644))
+	since := time.Now()
+	time.Sleep(50 * time.Millisecond)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(root, "new"), []byte("new"), 0644))
+
+	paths := changedSince([]archive.Change{
+		{Path: "/old", Kind: archive.ChangeModify},
+		{Path: "/new", Kind: archive.ChangeAdd},
+		{Path: "/gone", Kind: archive.ChangeDelete},
+		{Path: "/missing", Kind: archive.ChangeAdd},
+	}, root, since)
+	assert.Equal(t, []string{"/new"}, paths)
+}
@@ -0,0 +1,141 @@
+package libpod
+
+import (
+	"fmt"
+	"io/ioutil"
+	"runtime"
+	"strings"
+
+	"github.com/containers/storage/pkg/parsers"
+	"github.com/containers/storage/pkg/system"
+	"github.com/docker/go-units"
+	spec "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// hostResources is the capacity of the host that containers' resources are
+// checked against
+type hostResources struct {
+	// MemTotal is the host's total memory, in bytes
+	MemTotal int64
+	// MemFree is the host's currently free memory, in bytes
+	MemFree int64
+	// CPUs is the number of CPUs available to us
+	CPUs int
+	// OnlineCPUs are the IDs of the host's online CPUs, or nil if they are
+	// not known
+	OnlineCPUs map[int]bool
+}
+
+// getHostResources returns the capacity of the host
+func getHostResources() (hostResources, error) {
+	host := hostResources{
+		CPUs: runtime.NumCPU(),
+	}
+
+	memInfo, err := system.ReadMemInfo()
+	if err != nil {
+		return host, errors.Wrapf(err, "error reading host memory information")
+	}
+	host.MemTotal = memInfo.MemTotal
+	host.MemFree = memInfo.MemFree
+
+	// Not knowing which CPUs are online only skips checking cpusets
+	if online, err := ioutil.ReadFile("/sys/devices/system/cpu/online"); err == nil {
+		if cpus, err := parsers.ParseUintList(strings.TrimSpace(string(online))); err == nil {
+			host.OnlineCPUs = cpus
+		}
+	}
+
+	return host, nil
+}
+
+// resourceProblems returns the ways in which the host cannot satisfy the given
+// container resources
+func resourceProblems(res *spec.LinuxResources, host hostResources) []string {
+	problems := []string{}
+	if res == nil {
+		return problems
+	}
+
+	if res.Memory != nil {
+		if res.Memory.Limit != nil && *res.Memory.Limit > host.MemTotal {
+			problems = append(problems, fmt.Sprintf("memory limit %s exceeds the host's total memory of %s",
+				units.BytesSize(float64(*res.Memory.Limit)), units.BytesSize(float64(host.MemTotal))))
+		}
+		if res.Memory.Reservation != nil {
+			if *res.Memory.Reservation > host.MemTotal {
+				problems = append(problems, fmt.Sprintf("memory reservation %s exceeds the host's total memory of %s",
+					units.BytesSize(float64(*res.Memory.Reservation)), units.BytesSize(float64(host.MemTotal))))
+			} else if *res.Memory.Reservation > host.MemFree {
+				problems = append(problems, fmt.Sprintf("memory reservation %s exceeds the host's free memory of %s",
+					units.BytesSize(float64(*res.Memory.Reservation)), units.BytesSize(float64(host.MemFree))))
+			}
+		}
+	}
+
+	if res.CPU != nil {
+		if res.CPU.Quota != nil && *res.CPU.Quota > 0 && res.CPU.Period != nil && *res.CPU.Period > 0 {
+			cpus := float64(*res.CPU.Quota) / float64(*res.CPU.Period)
+			if cpus > float64(host.CPUs) {
+				problems = append(problems, fmt.Sprintf("CPU quota of %.2f CPUs exceeds the host's %d CPUs", cpus, host.CPUs))
+			}
+		}
+		if res.CPU.Cpus != "" && host.OnlineCPUs != nil {
+			cpus, err := parsers.ParseUintList(res.CPU.Cpus)
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("invalid cpuset %q: %v", res.CPU.Cpus, err))
+			} else {
+				for cpu := range cpus {
+					if !host.OnlineCPUs[cpu] {
+						problems = append(problems, fmt.Sprintf("cpuset %q includes CPU %d, which is not online", res.CPU.Cpus, cpu))
+						break
+					}
+				}
+			}
+		}
+	}
+
+	return problems
+}
+
+// validateResources returns the ways in which the host cannot currently
+// satisfy the container's resources
+func (c *Container) validateResources() ([]string, error) {
+	if c.config.Spec == nil || c.config.Spec.Linux == nil {
+		return []string{}, nil
+	}
+
+	host, err := getHostResources()
+	if err != nil {
+		return nil, err
+	}
+
+	return resourceProblems(c.config.Spec.Linux.Resources, host), nil
+}
+
+// checkResources checks the container's resources against the host's capacity
+// according to the runtime's resource check policy
+func (c *Container) checkResources() error {
+	policy := c.runtime.config.ResourceCheckPolicy
+	if policy == "" || policy == ResourceCheckPolicyIgnore {
+		return nil
+	}
+
+	problems, err := c.validateResources()
+	if err != nil {
+		return err
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+
+	if policy == ResourceCheckPolicyRefuse {
+		return errors.Wrapf(ErrInsufficientResources, "container %s: %s", c.ID(), strings.Join(problems, "; "))
+	}
+	for _, problem := range problems {
+		logrus.Warnf("Container %s: %s", c.ID(), problem)
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package libpod
+
+import (
+	"testing"
+
+	spec "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResourceProblems(t *testing.T) {
+	host := hostResources{
+		MemTotal:   4 << 30,
+		MemFree:    1 << 30,
+		CPUs:       2,
+		OnlineCPUs: map[int]bool{0: true, 1: true},
+	}
+	int64p := func(i int64) *int64 { return &i }
+	uint64p := func(i uint64) *uint64 { return &i }
+
+	assert.Empty(t, resourceProblems(nil, host))
+	assert.Empty(t, resourceProblems(&spec.LinuxResources{
+		Memory: &spec.LinuxMemory{Limit: int64p(2 << 30), Reservation: int64p(512 << 20)},
+		CPU:    &spec.LinuxCPU{Quota: int64p(150000), Period: uint64p(100000), Cpus: "0-1"},
+	}, host))
+
+	assert.Len(t, resourceProblems(&spec.LinuxResources{
+		Memory: &spec.LinuxMemory{Limit: int64p(8 << 30)},
+	}, host), 1)
+	assert.Len(t, resourceProblems(&spec.LinuxResources{
+		Memory: &spec.LinuxMemory{Reservation: int64p(2 << 30)},
+	}, host), 1)
+	assert.Len(t, resourceProblems(&spec.LinuxResources{
+		CPU: &spec.LinuxCPU{Quota: int64p(300000), Period: uint64p(100000), Cpus: "1-3"},
+	}, host), 2)
+}
@@ -11,6 +11,8 @@ var (
 	ErrNoSuchPod = errors.New("no such pod")
 	// ErrNoSuchImage indicates the requested image does not exist
 	ErrNoSuchImage = errors.New("no such image")
+	// ErrNoSuchVolume indicates the requested volume does not exist
+	ErrNoSuchVolume = errors.New("no such volume")
 
 	// ErrCtrExists indicates a container with the same name or ID already
 	// exists
@@ -19,6 +21,8 @@ var (
 	ErrPodExists = errors.New("pod already exists")
 	// ErrImageExists indicated an image with the same ID already exists
 	ErrImageExists = errors.New("image already exists")
+	// ErrVolumeExists indicates a volume with the same name already exists
+	ErrVolumeExists = errors.New("volume already exists")
 
 	// ErrCtrStateInvalid indicates a container is in an improper state for
 	// the requested operation
@@ -33,6 +37,9 @@ var (
 	// ErrPodFinalized indicates that the pod has already been created and
 	// cannot be modified
 	ErrPodFinalized = errors.New("pod has been finalized")
+	// ErrVolumeFinalized indicates that the volume has already been created
+	// and cannot be modified
+	ErrVolumeFinalized = errors.New("volume has been finalized")
 
 	// ErrInvalidArg indicates that an invalid argument was passed
 	ErrInvalidArg = errors.New("invalid argument")
@@ -55,6 +62,12 @@ var (
 	// ErrPodRemoved indicates that the pod has already been removed and no
 	// further operations can be performed on it
 	ErrPodRemoved = errors.New("pod has already been removed")
+	// ErrVolumeRemoved indicates that the volume has already been removed
+	// and no further operations can be performed on it
+	ErrVolumeRemoved = errors.New("volume has already been removed")
+	// ErrVolumeBeingUsed indicates that a volume is used by containers and
+	// cannot be removed
+	ErrVolumeBeingUsed = errors.New("volume is being used")
 
 	// ErrDBClosed indicates that the connection to the state database has
 	// already been closed
@@ -67,6 +80,46 @@ var (
 	// different namespace and cannot be accessed or modified.
 	ErrNSMismatch = errors.New("target is in a different namespace")
 
+	// ErrInvalidCheckpoint indicates that a container checkpoint is
+	// incomplete or cannot be restored on this host
+	ErrInvalidCheckpoint = errors.New("invalid checkpoint")
+
+	// ErrStorageDriverMismatch indicates that a container's storage was
+	// created with a different storage driver than the one in use, so it
+	// cannot be accessed
+	ErrStorageDriverMismatch = errors.New("container storage was created with a different storage driver")
+
+	// ErrNoSuchSecret indicates the requested secret does not exist
+	ErrNoSuchSecret = errors.New("no such secret")
+
+	// ErrPortConflict indicates that a host port a container maps is
+	// already claimed by another container
+	ErrPortConflict = errors.New("host port already claimed")
+	// ErrHostPortsExhausted indicates that no host port of the runtime's
+	// host port range is free to allocate to a container
+	ErrHostPortsExhausted = errors.New("no free host port to allocate")
+
+	// ErrInsufficientResources indicates that the host cannot satisfy the
+	// resources requested by a container
+	ErrInsufficientResources = errors.New("insufficient host resources")
+
+	// ErrCtrDependencyCycle indicates that containers depend on each other
+	// in a cycle, so none of them can be started
+	ErrCtrDependencyCycle = errors.New("container dependency cycle")
+
+	// ErrCtrStartupTimeout indicates that a container did not finish
+	// starting within its startup timeout and was stopped
+	ErrCtrStartupTimeout = errors.New("container startup timed out")
+
+	// ErrExecTimeout indicates that a command executed in a container did
+	// not exit within its timeout and was killed
+	ErrExecTimeout = errors.New("exec timed out")
+
+	// ErrPressureNotSupported indicates that pressure stall information is
+	// not available, as the host does not use cgroup v2 or its kernel does
+	// not support PSI
+	ErrPressureNotSupported = errors.New("pressure stall information is not supported")
+
 	// ErrNotImplemented indicates that the requested functionality is not
 	// yet present
 	ErrNotImplemented = errors.New("not yet implemented")
@@ -18,6 +18,10 @@ type InMemoryState struct {
 	pods map[string]*Pod
 	// Maps container ID to container struct.
 	containers map[string]*Container
+	// Maps volume name to volume struct.
+	volumes map[string]*Volume
+	// Maps volume name to the IDs of the containers using it.
+	volumeCtrs map[string]map[string]bool
 	// Maps container ID to a list of IDs of dependencies.
 	ctrDepends map[string][]string
 	// Maps pod ID to a map of container ID to container struct.
@@ -46,6 +50,8 @@ func NewInMemoryState() (State, error) {
 
 	state.pods = make(map[string]*Pod)
 	state.containers = make(map[string]*Container)
+	state.volumes = make(map[string]*Volume)
+	state.volumeCtrs = make(map[string]map[string]bool)
 
 	state.ctrDepends = make(map[string][]string)
 
@@ -207,6 +213,7 @@ func (s *InMemoryState) AddContainer(ctr *Container) error {
 	}
 
 	s.containers[ctr.ID()] = ctr
+	s.addCtrToVolumesMap(ctr)
 
 	// If we're in a namespace, add us to that namespace's indexes
 	if ctr.config.Namespace != "" {
@@ -261,6 +268,7 @@ func (s *InMemoryState) RemoveContainer(ctr *Container) error {
 		return errors.Wrapf(err, "error removing container ID from index")
 	}
 	delete(s.containers, ctr.ID())
+	s.removeCtrFromVolumesMap(ctr)
 	s.nameIndex.Release(ctr.Name())
 
 	delete(s.ctrDepends, ctr.ID())
@@ -663,6 +671,7 @@ func (s *InMemoryState) RemovePodContainers(pod *Pod) error {
 
 		delete(s.containers, ctr.ID())
 		delete(s.ctrDepends, ctr.ID())
+		s.removeCtrFromVolumesMap(ctr)
 	}
 
 	return nil
@@ -735,6 +744,7 @@ func (s *InMemoryState) AddContainerToPod(pod *Pod, ctr *Container) error {
 	}
 
 	s.containers[ctr.ID()] = ctr
+	s.addCtrToVolumesMap(ctr)
 
 	// Add container to pod containers
 	podCtrs[ctr.ID()] = ctr
@@ -814,6 +824,7 @@ func (s *InMemoryState) RemoveContainerFromPod(pod *Pod, ctr *Container) error {
 		return errors.Wrapf(err, "error removing container ID from index")
 	}
 	delete(s.containers, ctr.ID())
+	s.removeCtrFromVolumesMap(ctr)
 	s.nameIndex.Release(ctr.Name())
 
 	// Remove the container from the pod
@@ -893,8 +904,117 @@ func (s *InMemoryState) AllPods() ([]*Pod, error) {
 	return pods, nil
 }
 
+// Volume retrieves a volume from its name
+func (s *InMemoryState) Volume(name string) (*Volume, error) {
+	if name == "" {
+		return nil, ErrEmptyID
+	}
+
+	volume, ok := s.volumes[name]
+	if !ok {
+		return nil, errors.Wrapf(ErrNoSuchVolume, "no volume with name %s found", name)
+	}
+
+	return volume, nil
+}
+
+// HasVolume checks if a volume with the given name is present in the state
+func (s *InMemoryState) HasVolume(name string) (bool, error) {
+	if name == "" {
+		return false, ErrEmptyID
+	}
+
+	_, ok := s.volumes[name]
+
+	return ok, nil
+}
+
+// AddVolume adds a volume to the state
+func (s *InMemoryState) AddVolume(volume *Volume) error {
+	if !volume.valid {
+		return errors.Wrapf(ErrVolumeRemoved, "volume %s is not valid and cannot be added", volume.Name())
+	}
+
+	if _, ok := s.volumes[volume.Name()]; ok {
+		return errors.Wrapf(ErrVolumeExists, "volume with name %s already exists in state", volume.Name())
+	}
+
+	s.volumes[volume.Name()] = volume
+
+	return nil
+}
+
+// RemoveVolume removes a volume from the state
+func (s *InMemoryState) RemoveVolume(volume *Volume) error {
+	if _, ok := s.volumes[volume.Name()]; !ok {
+		volume.valid = false
+		return errors.Wrapf(ErrNoSuchVolume, "no volume exists in state with name %s", volume.Name())
+	}
+
+	delete(s.volumes, volume.Name())
+	volume.valid = false
+
+	return nil
+}
+
+// VolumeInUse returns the IDs of the containers using the given volume, in all
+// namespaces
+func (s *InMemoryState) VolumeInUse(volume *Volume) ([]string, error) {
+	if !volume.valid {
+		return nil, errors.Wrapf(ErrVolumeRemoved, "volume %s is not valid", volume.Name())
+	}
+
+	if _, ok := s.volumes[volume.Name()]; !ok {
+		volume.valid = false
+		return nil, errors.Wrapf(ErrNoSuchVolume, "no volume exists in state with name %s", volume.Name())
+	}
+
+	ctrs := []string{}
+	for id := range s.volumeCtrs[volume.Name()] {
+		ctrs = append(ctrs, id)
+	}
+
+	return ctrs, nil
+}
+
+// AllVolumes retrieves all volumes from the state
+func (s *InMemoryState) AllVolumes() ([]*Volume, error) {
+	volumes := make([]*Volume, 0, len(s.volumes))
+	for _, volume := range s.volumes {
+		volumes = append(volumes, volume)
+	}
+
+	return volumes, nil
+}
+
 // Internal Functions
 
+// Add a container to the mappings of the volumes it uses
+func (s *InMemoryState) addCtrToVolumesMap(ctr *Container) {
+	for _, vol := range ctr.config.NamedVolumes {
+		ctrs, ok := s.volumeCtrs[vol.Name]
+		if !ok {
+			ctrs = make(map[string]bool)
+			s.volumeCtrs[vol.Name] = ctrs
+		}
+		ctrs[ctr.ID()] = true
+	}
+}
+
+// Remove a container from the mappings of the volumes it uses
+func (s *InMemoryState) removeCtrFromVolumesMap(ctr *Container) {
+	for _, vol := range ctr.config.NamedVolumes {
+		ctrs, ok := s.volumeCtrs[vol.Name]
+		if !ok {
+			continue
+		}
+		delete(ctrs, ctr.ID())
+		if len(ctrs) == 0 {
+			delete(s.volumeCtrs, vol.Name)
+		}
+	}
+}
+
 // Add a container to the dependency mappings
 func (s *InMemoryState) addCtrToDependsMap(ctrID, dependsID string) {
 	if dependsID != "" {
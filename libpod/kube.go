@@ -0,0 +1,373 @@
+package libpod
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/cri-o/ocicni/pkg/ocicni"
+	"github.com/ghodss/yaml"
+	spec "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/pkg/errors"
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// kubeUnsupportedAnnotationPrefix prefixes the annotations describing
+// container features that Kubernetes cannot express, so they are not lost when
+// a container is converted to a pod
+const kubeUnsupportedAnnotationPrefix = "unsupported.podman.io/"
+
+// generateForKube converts the container to a Kubernetes pod with a single
+// container
+func (c *Container) generateForKube() (*v1.Pod, error) {
+	kubeCtr, err := c.containerToV1Container()
+	if err != nil {
+		return nil, err
+	}
+
+	annotations := make(map[string]string)
+	podSpec := v1.PodSpec{
+		Containers: []v1.Container{kubeCtr},
+	}
+
+	// Host namespaces Kubernetes knows about are set on the pod. Joining
+	// another container's namespaces, or namespace setups Kubernetes
+	// cannot create, are noted in annotations.
+	for _, nsType := range []spec.LinuxNamespaceType{spec.NetworkNamespace, spec.PIDNamespace, spec.IPCNamespace, spec.UTSNamespace, spec.MountNamespace, spec.UserNamespace, spec.CgroupNamespace} {
+		annotation := kubeUnsupportedAnnotationPrefix + string(nsType) + "-namespace"
+		if nsCtr := c.namespaceContainer(nsType); nsCtr != "" {
+			annotations[annotation] = fmt.Sprintf("joins the %s namespace of container %s", nsType, nsCtr)
+			continue
+		}
+
+		hasNS := c.config.Spec.Linux != nil && specHasNamespace(c.config.Spec.Linux.Namespaces, nsType)
+		switch nsType {
+		case spec.NetworkNamespace:
+			podSpec.HostNetwork = !hasNS
+		case spec.PIDNamespace:
+			podSpec.HostPID = !hasNS
+		case spec.IPCNamespace:
+			podSpec.HostIPC = !hasNS
+		case spec.UTSNamespace, spec.MountNamespace:
+			if !hasNS {
+				annotations[annotation] = fmt.Sprintf("uses the host's %s namespace", nsType)
+			}
+		case spec.UserNamespace, spec.CgroupNamespace:
+			// Kubernetes does not create these
+			if hasNS {
+				annotations[annotation] = fmt.Sprintf("runs in a separate %s namespace", nsType)
+			}
+		}
+	}
+
+	// Host path volumes for the container's bind mounts
+	for i, m := range c.kubeBindMounts() {
+		podSpec.Volumes = append(podSpec.Volumes, v1.Volume{
+			Name: kubeCtr.VolumeMounts[i].Name,
+			VolumeSource: v1.VolumeSource{
+				HostPath: &v1.HostPathVolumeSource{
+					Path: m.Source,
+				},
+			},
+		})
+	}
+
+	if len(annotations) == 0 {
+		annotations = nil
+	}
+
+	return &v1.Pod{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Pod",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              c.Name(),
+			Labels:            c.Labels(),
+			Annotations:       annotations,
+			CreationTimestamp: metav1.NewTime(c.CreatedTime()),
+		},
+		Spec: podSpec,
+	}, nil
+}
+
+// containerToV1Container converts the container to a Kubernetes container
+func (c *Container) containerToV1Container() (v1.Container, error) {
+	kubeCtr := v1.Container{
+		Name:  c.Name(),
+		Image: c.config.RootfsImageName,
+		Stdin: c.config.Stdin,
+	}
+
+	if c.config.Spec.Process != nil {
+		kubeCtr.Command = c.config.Spec.Process.Args
+		kubeCtr.WorkingDir = c.config.Spec.Process.Cwd
+		kubeCtr.TTY = c.config.Spec.Process.Terminal
+		kubeCtr.Env = envToV1EnvVars(c.config.Spec.Process.Env)
+	}
+
+	ports, err := portMappingsToV1ContainerPorts(c.config.PortMappings)
+	if err != nil {
+		return v1.Container{}, errors.Wrapf(err, "error converting ports of container %s", c.ID())
+	}
+	kubeCtr.Ports = ports
+
+	for i, m := range c.kubeBindMounts() {
+		kubeCtr.VolumeMounts = append(kubeCtr.VolumeMounts, v1.VolumeMount{
+			Name:      fmt.Sprintf("volume-%d", i),
+			MountPath: m.Destination,
+			ReadOnly:  mountIsReadOnly(m),
+		})
+	}
+
+	if c.config.Spec.Linux != nil {
+		kubeCtr.Resources.Limits = resourcesToV1ResourceList(c.config.Spec.Linux.Resources)
+	}
+
+	if c.config.Privileged {
+		privileged := true
+		kubeCtr.SecurityContext = &v1.SecurityContext{
+			Privileged: &privileged,
+		}
+	}
+
+	return kubeCtr, nil
+}
+
+// kubeBindMounts returns the container's bind mounts, which become host path
+// volumes in Kubernetes
+func (c *Container) kubeBindMounts() []spec.Mount {
+	mounts := []spec.Mount{}
+	for _, m := range c.config.Spec.Mounts {
+		if len(bindMountSources([]spec.Mount{m})) > 0 {
+			mounts = append(mounts, m)
+		}
+	}
+	return mounts
+}
+
+// namespaceContainer returns the ID of the container whose namespace of the
+// given type the container joins, or "" if it does not join one
+func (c *Container) namespaceContainer(nsType spec.LinuxNamespaceType) string {
+	switch nsType {
+	case spec.NetworkNamespace:
+		return c.config.NetNsCtr
+	case spec.PIDNamespace:
+		return c.config.PIDNsCtr
+	case spec.IPCNamespace:
+		return c.config.IPCNsCtr
+	case spec.UTSNamespace:
+		return c.config.UTSNsCtr
+	case spec.UserNamespace:
+		return c.config.UserNsCtr
+	case spec.MountNamespace:
+		return c.config.MountNsCtr
+	case spec.CgroupNamespace:
+		return c.config.CgroupNsCtr
+	}
+	return ""
+}
+
+// specHasNamespace returns whether namespaces include one of the given type
+func specHasNamespace(namespaces []spec.LinuxNamespace, nsType spec.LinuxNamespaceType) bool {
+	for _, ns := range namespaces {
+		if ns.Type == nsType {
+			return true
+		}
+	}
+	return false
+}
+
+// mountIsReadOnly returns whether a mount is mounted read-only
+func mountIsReadOnly(m spec.Mount) bool {
+	for _, opt := range m.Options {
+		if opt == "ro" {
+			return true
+		}
+	}
+	return false
+}
+
+// envToV1EnvVars converts KEY=VALUE environment variables to Kubernetes
+// environment variables
+func envToV1EnvVars(env []string) []v1.EnvVar {
+	vars := make([]v1.EnvVar, 0, len(env))
+	for _, e := range env {
+		split := strings.SplitN(e, "=", 2)
+		envVar := v1.EnvVar{Name: split[0]}
+		if len(split) == 2 {
+			envVar.Value = split[1]
+		}
+		vars = append(vars, envVar)
+	}
+	return vars
+}
+
+// portMappingsToV1ContainerPorts converts the ports forwarded to a container to
+// Kubernetes container ports
+func portMappingsToV1ContainerPorts(mappings []ocicni.PortMapping) ([]v1.ContainerPort, error) {
+	ports := make([]v1.ContainerPort, 0, len(mappings))
+	for _, m := range mappings {
+		var protocol v1.Protocol
+		switch strings.ToLower(m.Protocol) {
+		case "", "tcp":
+			protocol = v1.ProtocolTCP
+		case "udp":
+			protocol = v1.ProtocolUDP
+		default:
+			return nil, errors.Wrapf(ErrInvalidArg, "unsupported port protocol %q", m.Protocol)
+		}
+		ports = append(ports, v1.ContainerPort{
+			ContainerPort: m.ContainerPort,
+			HostPort:      m.HostPort,
+			HostIP:        m.HostIP,
+			Protocol:      protocol,
+		})
+	}
+	return ports, nil
+}
+
+// resourcesToV1ResourceList converts a container's memory and CPU limits to
+// Kubernetes resource limits
+func resourcesToV1ResourceList(resources *spec.LinuxResources) v1.ResourceList {
+	if resources == nil {
+		return nil
+	}
+
+	limits := v1.ResourceList{}
+	if resources.Memory != nil && resources.Memory.Limit != nil && *resources.Memory.Limit > 0 {
+		limits[v1.ResourceMemory] = *resource.NewQuantity(*resources.Memory.Limit, resource.BinarySI)
+	}
+	if resources.CPU != nil && resources.CPU.Quota != nil && *resources.CPU.Quota > 0 &&
+		resources.CPU.Period != nil && *resources.CPU.Period > 0 {
+		milliCPU := *resources.CPU.Quota * 1000 / int64(*resources.CPU.Period)
+		limits[v1.ResourceCPU] = *resource.NewMilliQuantity(milliCPU, resource.DecimalSI)
+	}
+
+	if len(limits) == 0 {
+		return nil
+	}
+	return limits
+}
+
+// kubeAnnotationKeys returns the keys of a pod's annotations, sorted
+func kubeAnnotationKeys(annotations map[string]string) []string {
+	keys := make([]string, 0, len(annotations))
+	for key := range annotations {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// writeKubeYAML writes a Kubernetes object to w as YAML, preceded by comments
+// listing the container features that could not be expressed
+func writeKubeYAML(w io.Writer, pod *v1.Pod) error {
+	data, err := yaml.Marshal(pod)
+	if err != nil {
+		return errors.Wrapf(err, "error marshalling pod %s", pod.Name)
+	}
+
+	for _, key := range kubeAnnotationKeys(pod.Annotations) {
+		if !strings.HasPrefix(key, kubeUnsupportedAnnotationPrefix) {
+			continue
+		}
+		if _, err := fmt.Fprintf(w, "# Unsupported: %s\n", pod.Annotations[key]); err != nil {
+			return err
+		}
+	}
+
+	_, err = w.Write(data)
+	return err
+}
+
+// kubeUnsupportedFields returns descriptions of the fields set in a Kubernetes
+// pod that cannot be converted to libpod containers
+func kubeUnsupportedFields(pod *v1.Pod) []string {
+	unsupported := []string{}
+	add := func(format string, args ...interface{}) {
+		unsupported = append(unsupported, fmt.Sprintf(format, args...))
+	}
+
+	podSpec := pod.Spec
+	if len(podSpec.InitContainers) > 0 {
+		add("spec.initContainers")
+	}
+	if podSpec.RestartPolicy != "" {
+		add("spec.restartPolicy")
+	}
+	if len(podSpec.NodeSelector) > 0 {
+		add("spec.nodeSelector")
+	}
+	if podSpec.Affinity != nil {
+		add("spec.affinity")
+	}
+	if len(podSpec.Tolerations) > 0 {
+		add("spec.tolerations")
+	}
+	if podSpec.ServiceAccountName != "" {
+		add("spec.serviceAccountName")
+	}
+	if len(podSpec.HostAliases) > 0 {
+		add("spec.hostAliases")
+	}
+	if podSpec.DNSConfig != nil {
+		add("spec.dnsConfig")
+	}
+	if podSpec.SecurityContext != nil {
+		add("spec.securityContext")
+	}
+	for _, vol := range podSpec.Volumes {
+		if vol.HostPath == nil {
+			add("spec.volumes[%s]: only hostPath volumes are supported", vol.Name)
+		}
+	}
+
+	for _, ctr := range podSpec.Containers {
+		prefix := fmt.Sprintf("spec.containers[%s]", ctr.Name)
+		if len(ctr.EnvFrom) > 0 {
+			add("%s.envFrom", prefix)
+		}
+		for _, env := range ctr.Env {
+			if env.ValueFrom != nil {
+				add("%s.env[%s].valueFrom", prefix, env.Name)
+			}
+		}
+		for _, port := range ctr.Ports {
+			if port.HostPort != 0 {
+				add("%s.ports[%d].hostPort: ports cannot be forwarded to containers in pods", prefix, port.ContainerPort)
+			}
+		}
+		if len(ctr.Resources.Requests) > 0 {
+			add("%s.resources.requests", prefix)
+		}
+		for name := range ctr.Resources.Limits {
+			if name != v1.ResourceMemory && name != v1.ResourceCPU {
+				add("%s.resources.limits[%s]", prefix, name)
+			}
+		}
+		if ctr.LivenessProbe != nil {
+			add("%s.livenessProbe", prefix)
+		}
+		if ctr.ReadinessProbe != nil {
+			add("%s.readinessProbe", prefix)
+		}
+		if ctr.Lifecycle != nil {
+			add("%s.lifecycle", prefix)
+		}
+		if len(ctr.VolumeDevices) > 0 {
+			add("%s.volumeDevices", prefix)
+		}
+		if sc := ctr.SecurityContext; sc != nil {
+			if sc.Capabilities != nil || sc.SELinuxOptions != nil || sc.RunAsUser != nil ||
+				sc.RunAsNonRoot != nil || sc.ReadOnlyRootFilesystem != nil || sc.AllowPrivilegeEscalation != nil {
+				add("%s.securityContext: only privileged is supported", prefix)
+			}
+		}
+	}
+
+	return unsupported
+}
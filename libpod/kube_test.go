@@ -0,0 +1,86 @@
+package libpod
+
+import (
+	"testing"
+
+	"github.com/cri-o/ocicni/pkg/ocicni"
+	spec "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/api/core/v1"
+)
+
+func TestEnvToV1EnvVars(t *testing.T) {
+	vars := envToV1EnvVars([]string{"PATH=/usr/bin", "EMPTY=", "EQ=a=b", "NOVALUE"})
+	assert.Equal(t, []v1.EnvVar{
+		{Name: "PATH", Value: "/usr/bin"},
+		{Name: "EMPTY"},
+		{Name: "EQ", Value: "a=b"},
+		{Name: "NOVALUE"},
+	}, vars)
+}
+
+func TestPortMappingsToV1ContainerPorts(t *testing.T) {
+	ports, err := portMappingsToV1ContainerPorts([]ocicni.PortMapping{
+		{HostPort: 8080, ContainerPort: 80, Protocol: "tcp"},
+		{HostPort: 53, ContainerPort: 53, Protocol: "UDP", HostIP: "127.0.0.1"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []v1.ContainerPort{
+		{HostPort: 8080, ContainerPort: 80, Protocol: v1.ProtocolTCP},
+		{HostPort: 53, ContainerPort: 53, Protocol: v1.ProtocolUDP, HostIP: "127.0.0.1"},
+	}, ports)
+
+	_, err = portMappingsToV1ContainerPorts([]ocicni.PortMapping{{ContainerPort: 80, Protocol: "sctp"}})
+	assert.Error(t, err)
+}
+
+func TestResourcesToV1ResourceList(t *testing.T) {
+	memory := int64(512 * 1024 * 1024)
+	quota := int64(50000)
+	period := uint64(100000)
+	limits := resourcesToV1ResourceList(&spec.LinuxResources{
+		Memory: &spec.LinuxMemory{Limit: &memory},
+		CPU:    &spec.LinuxCPU{Quota: &quota, Period: &period},
+	})
+	mem := limits[v1.ResourceMemory]
+	cpu := limits[v1.ResourceCPU]
+	assert.Equal(t, "512Mi", mem.String())
+	assert.Equal(t, "500m", cpu.String())
+
+	assert.Nil(t, resourcesToV1ResourceList(&spec.LinuxResources{}))
+	assert.Nil(t, resourcesToV1ResourceList(nil))
+}
+
+func TestKubeUnsupportedFields(t *testing.T) {
+	privileged := true
+	pod := &v1.Pod{
+		Spec: v1.PodSpec{
+			RestartPolicy: v1.RestartPolicyAlways,
+			Volumes: []v1.Volume{
+				{Name: "data", VolumeSource: v1.VolumeSource{HostPath: &v1.HostPathVolumeSource{Path: "/srv"}}},
+				{Name: "scratch", VolumeSource: v1.VolumeSource{EmptyDir: &v1.EmptyDirVolumeSource{}}},
+			},
+			Containers: []v1.Container{
+				{
+					Name: "web",
+					Env: []v1.EnvVar{
+						{Name: "A", Value: "1"},
+						{Name: "B", ValueFrom: &v1.EnvVarSource{}},
+					},
+					Ports:           []v1.ContainerPort{{ContainerPort: 80, HostPort: 8080}},
+					SecurityContext: &v1.SecurityContext{Privileged: &privileged},
+					LivenessProbe:   &v1.Probe{},
+				},
+			},
+		},
+	}
+
+	assert.Equal(t, []string{
+		"spec.restartPolicy",
+		"spec.volumes[scratch]: only hostPath volumes are supported",
+		"spec.containers[web].env[B].valueFrom",
+		"spec.containers[web].ports[80].hostPort: ports cannot be forwarded to containers in pods",
+		"spec.containers[web].livenessProbe",
+	}, kubeUnsupportedFields(pod))
+	assert.Empty(t, kubeUnsupportedFields(&v1.Pod{}))
+}
@@ -1,6 +1,9 @@
 package layers
 
-import cstorage "github.com/containers/storage"
+import (
+	cstorage "github.com/containers/storage"
+	"github.com/pkg/errors"
+)
 
 // FullID gets the full id of a layer given a partial id or name
 func FullID(store cstorage.Store, id string) (string, error) {
@@ -10,3 +13,24 @@ func FullID(store cstorage.Store, id string) (string, error) {
 	}
 	return layer.ID, nil
 }
+
+// LayerChain returns the layer with the given id and all of its parents,
+// ordered from the base layer to the layer itself
+func LayerChain(store cstorage.Store, id string) ([]*cstorage.Layer, error) {
+	chain := []*cstorage.Layer{}
+	seen := make(map[string]bool)
+	for id != "" {
+		if seen[id] {
+			return nil, errors.Errorf("layer %s is its own parent", id)
+		}
+		seen[id] = true
+
+		layer, err := store.Layer(id)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error retrieving layer %s", id)
+		}
+		chain = append([]*cstorage.Layer{layer}, chain...)
+		id = layer.Parent
+	}
+	return chain, nil
+}
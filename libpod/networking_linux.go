@@ -50,7 +50,7 @@ func (r *Runtime) getPodNetwork(id, name, nsPath string, networks []string, port
 
 // Create and configure a new network namespace for a container
 func (r *Runtime) configureNetNS(ctr *Container, ctrNS ns.NetNS) ([]*cnitypes.Result, error) {
-	podNetwork := r.getPodNetwork(ctr.ID(), ctr.Name(), ctrNS.Path(), ctr.config.Networks, ctr.config.PortMappings, ctr.config.StaticIP)
+	podNetwork := r.getPodNetwork(ctr.ID(), ctr.Name(), ctrNS.Path(), ctr.config.Networks, ctr.portMappings(), ctr.config.StaticIP)
 
 	results, err := r.netPlugin.SetUpPod(podNetwork)
 	if err != nil {
@@ -255,7 +255,7 @@ func (r *Runtime) teardownNetNS(ctr *Container) error {
 
 	logrus.Debugf("Tearing down network namespace at %s for container %s", ctr.state.NetNS.Path(), ctr.ID())
 
-	podNetwork := r.getPodNetwork(ctr.ID(), ctr.Name(), ctr.state.NetNS.Path(), ctr.config.Networks, ctr.config.PortMappings, ctr.config.StaticIP)
+	podNetwork := r.getPodNetwork(ctr.ID(), ctr.Name(), ctr.state.NetNS.Path(), ctr.config.Networks, ctr.portMappings(), ctr.config.StaticIP)
 
 	// The network may have already been torn down, so don't fail here, just log
 	if err := r.netPlugin.TearDownPod(podNetwork); err != nil {
@@ -277,26 +277,39 @@ func (r *Runtime) teardownNetNS(ctr *Container) error {
 	return nil
 }
 
-func getContainerNetNS(ctr *Container) (string, error) {
+// netIOStats are the network counters of a network namespace
+type netIOStats struct {
+	// Owner is the ID of the container owning the network namespace
+	Owner     string
+	RxBytes   uint64
+	TxBytes   uint64
+	RxPackets uint64
+	TxPackets uint64
+}
+
+// getContainerNetNS returns the path of the network namespace the container
+// uses and the ID of the container that owns it. If the container has no
+// network namespace of its own or of another container, "" is returned for
+// both.
+func getContainerNetNS(ctr *Container) (string, string, error) {
 	if ctr.state.NetNS != nil {
-		return ctr.state.NetNS.Path(), nil
+		return ctr.state.NetNS.Path(), ctr.ID(), nil
 	}
 	if ctr.config.NetNsCtr != "" {
 		c, err := ctr.runtime.GetContainer(ctr.config.NetNsCtr)
 		if err != nil {
-			return "", err
+			return "", "", err
 		}
 		if err = c.syncContainer(); err != nil {
-			return "", err
+			return "", "", err
 		}
-		return c.state.NetNS.Path(), nil
+		return getContainerNetNS(c)
 	}
-	return "", nil
+	return "", "", nil
 }
 
-func getContainerNetIO(ctr *Container) (*netlink.LinkStatistics, error) {
-	var netStats *netlink.LinkStatistics
-	netNSPath, netPathErr := getContainerNetNS(ctr)
+func getContainerNetIO(ctr *Container) (*netIOStats, error) {
+	netNSPath, owner, netPathErr := getContainerNetNS(ctr)
 	if netPathErr != nil {
 		return nil, netPathErr
 	}
@@ -305,15 +318,28 @@ func getContainerNetIO(ctr *Container) (*netlink.LinkStatistics, error) {
 		// this is a valid state and thus return no error, nor any statistics
 		return nil, nil
 	}
+	netStats := &netIOStats{Owner: owner}
 	err := ns.WithNetNSPath(netNSPath, func(_ ns.NetNS) error {
-		link, err := netlink.LinkByName(ocicni.DefaultInterfaceName)
+		links, err := netlink.LinkList()
 		if err != nil {
 			return err
 		}
-		netStats = link.Attrs().Statistics
+		for _, link := range links {
+			attrs := link.Attrs()
+			if attrs.Flags&net.FlagLoopback != 0 || attrs.Statistics == nil {
+				continue
+			}
+			netStats.RxBytes += attrs.Statistics.RxBytes
+			netStats.TxBytes += attrs.Statistics.TxBytes
+			netStats.RxPackets += attrs.Statistics.RxPackets
+			netStats.TxPackets += attrs.Statistics.TxPackets
+		}
 		return nil
 	})
-	return netStats, err
+	if err != nil {
+		return nil, errors.Wrapf(err, "error reading network statistics of container %s", ctr.ID())
+	}
+	return netStats, nil
 }
 
 func (c *Container) getContainerNetworkInfo(data *inspect.ContainerInspectData) *inspect.ContainerInspectData {
@@ -348,3 +374,114 @@ func (c *Container) getContainerNetworkInfo(data *inspect.ContainerInspectData)
 	}
 	return data
 }
+
+// dnsCacheAddress is the address the caching DNS resolver of a container listens
+// on inside the container's network namespace
+const dnsCacheAddress = "127.0.0.1"
+
+// dnsCacheSize is the number of names cached by a container's DNS resolver
+const dnsCacheSize = 1000
+
+// dnsCachePidPath returns the path to the file holding the PID of the
+// container's caching DNS resolver
+func (c *Container) dnsCachePidPath() string {
+	return filepath.Join(c.state.RunDir, "dnscache.pid")
+}
+
+// dnsCacheRunning returns whether the container's caching DNS resolver is
+// running
+func (c *Container) dnsCacheRunning() bool {
+	pid, err := readPidFile(c.dnsCachePidPath())
+	if err != nil {
+		return false
+	}
+	return unix.Kill(pid, 0) == nil
+}
+
+// setupDNSCache makes sure a caching DNS resolver forwarding to the given
+// nameservers is listening in the container's network namespace.
+// Containers that join the network namespace of another container use that
+// container's resolver.
+func (c *Container) setupDNSCache(nameservers []string) error {
+	if c.config.NetNsCtr != "" {
+		nsCtr, err := c.runtime.state.Container(c.config.NetNsCtr)
+		if err != nil {
+			return errors.Wrapf(err, "error retrieving network namespace container %s", c.config.NetNsCtr)
+		}
+		if err := c.runtime.state.UpdateContainer(nsCtr); err != nil {
+			return errors.Wrapf(err, "error updating container %s state", nsCtr.ID())
+		}
+		if !nsCtr.dnsCacheRunning() {
+			return errors.Wrapf(ErrCtrStateInvalid, "container %s, whose network namespace is joined, is not running a caching DNS resolver", nsCtr.ID())
+		}
+		return nil
+	}
+
+	if c.state.NetNS == nil {
+		return errors.Wrapf(ErrCtrStateInvalid, "container %s does not have a network namespace managed by libpod", c.ID())
+	}
+	if c.dnsCacheRunning() {
+		return nil
+	}
+	if len(nameservers) == 0 {
+		return errors.Wrapf(ErrInvalidArg, "no nameservers to forward queries to")
+	}
+
+	path, err := exec.LookPath("dnsmasq")
+	if err != nil {
+		return errors.Wrapf(err, "could not find dnsmasq")
+	}
+
+	args := []string{
+		"--conf-file=/dev/null",
+		"--no-resolv",
+		"--no-hosts",
+		"--bind-interfaces",
+		fmt.Sprintf("--listen-address=%s", dnsCacheAddress),
+		fmt.Sprintf("--cache-size=%d", dnsCacheSize),
+		fmt.Sprintf("--pid-file=%s", c.dnsCachePidPath()),
+	}
+	for _, server := range nameservers {
+		args = append(args, fmt.Sprintf("--server=%s", server))
+	}
+
+	// dnsmasq daemonizes once it is listening, so wait for the parent to
+	// exit to learn whether it started successfully
+	err = ns.WithNetNSPath(c.state.NetNS.Path(), func(_ ns.NetNS) error {
+		cmd := exec.Command(path, args...)
+		if output, err := cmd.CombinedOutput(); err != nil {
+			return errors.Wrapf(err, "dnsmasq failed: %s", strings.TrimSpace(string(output)))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	c.trackRunDirFile("dnscache.pid")
+
+	logrus.Debugf("Started caching DNS resolver for container %s forwarding to %s", c.ID(), strings.Join(nameservers, ","))
+
+	return nil
+}
+
+// stopDNSCache stops the container's caching DNS resolver, if it is running
+func (c *Container) stopDNSCache() error {
+	pidPath := c.dnsCachePidPath()
+	pid, err := readPidFile(pidPath)
+	if err != nil {
+		if os.IsNotExist(errors.Cause(err)) {
+			return nil
+		}
+		return err
+	}
+
+	if err := unix.Kill(pid, unix.SIGTERM); err != nil && err != unix.ESRCH {
+		return errors.Wrapf(err, "error stopping caching DNS resolver %d for container %s", pid, c.ID())
+	}
+
+	if err := os.Remove(pidPath); err != nil && !os.IsNotExist(err) {
+		return errors.Wrapf(err, "error removing %s", pidPath)
+	}
+
+	return nil
+}
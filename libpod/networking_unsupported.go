@@ -22,6 +22,14 @@ func (r *Runtime) createNetNS(ctr *Container) (err error) {
 	return ErrNotImplemented
 }
 
+// dnsCacheAddress is the address the caching DNS resolver of a container listens
+// on inside the container's network namespace
+const dnsCacheAddress = "127.0.0.1"
+
+func (c *Container) setupDNSCache(nameservers []string) error {
+	return ErrNotImplemented
+}
+
 func (c *Container) getContainerNetworkInfo(data *inspect.ContainerInspectData) *inspect.ContainerInspectData {
 	return nil
 }
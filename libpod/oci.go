@@ -3,8 +3,10 @@ package libpod
 import (
 	"bufio"
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net"
 	"os"
@@ -50,11 +52,40 @@ const (
 	killContainerTimeout = 5 * time.Second
 	// DefaultShmSize is the default shm size
 	DefaultShmSize = 64 * 1024 * 1024
+	// DefaultRunTmpfsSize is the default size of the tmpfs on /run
+	DefaultRunTmpfsSize = 64 * 1024 * 1024
+	// DefaultRunTmpfsMode is the default mode of the tmpfs on /run
+	DefaultRunTmpfsMode = 0755
 	// NsRunDir is the default directory in which running network namespaces
 	// are stored
 	NsRunDir = "/var/run/netns"
 )
 
+// ExitFileFormat is the format conmon writes containers' exit codes to their
+// exit files in
+type ExitFileFormat string
+
+const (
+	// ExitFileFormatAuto accepts any of the known exit file formats. This
+	// is the default.
+	ExitFileFormatAuto ExitFileFormat = "auto"
+	// ExitFileFormatPlain is the exit code in decimal, optionally followed
+	// by a newline
+	ExitFileFormatPlain ExitFileFormat = "plain"
+	// ExitFileFormatJSON is a JSON object with the exit code in its
+	// "exit_code" field
+	ExitFileFormatJSON ExitFileFormat = "json"
+
+	// ExitCodeSourceRuntime is the exit code source of containers whose
+	// exit file was missing or could not be parsed, so only the OCI
+	// runtime's status of the container was used
+	ExitCodeSourceRuntime = "runtime"
+)
+
+// conmonLogArtifact is the name of the artifact conmon's own messages are
+// logged to, if enabled
+const conmonLogArtifact = "conmon.log"
+
 // OCIRuntime represents an OCI-compatible runtime that libpod can call into
 // to perform container operations
 type OCIRuntime struct {
@@ -69,6 +100,18 @@ type OCIRuntime struct {
 	logSizeMax    int64
 	noPivot       bool
 	reservePorts  bool
+	exitFormat    ExitFileFormat
+	conmonLog     conmonLogConfig
+}
+
+// conmonLogConfig is how conmon logs its own messages, as opposed to the
+// output of containers
+type conmonLogConfig struct {
+	// level is the level conmon logs at; if empty, libpod's log level
+	level string
+	// toFile is whether conmon logs to the conmon.log artifact of each
+	// container
+	toFile bool
 }
 
 // syncInfo is used to return data from monitor process to daemon
@@ -78,7 +121,7 @@ type syncInfo struct {
 }
 
 // Make a new OCI runtime with provided options
-func newOCIRuntime(name string, path string, conmonPath string, conmonEnv []string, cgroupManager string, tmpDir string, logSizeMax int64, noPivotRoot bool, reservePorts bool) (*OCIRuntime, error) {
+func newOCIRuntime(name string, path string, conmonPath string, conmonEnv []string, cgroupManager string, tmpDir string, logSizeMax int64, noPivotRoot bool, reservePorts bool, exitFormat ExitFileFormat, conmonLog conmonLogConfig) (*OCIRuntime, error) {
 	runtime := new(OCIRuntime)
 	runtime.name = name
 	runtime.path = path
@@ -89,6 +132,8 @@ func newOCIRuntime(name string, path string, conmonPath string, conmonEnv []stri
 	runtime.logSizeMax = logSizeMax
 	runtime.noPivot = noPivotRoot
 	runtime.reservePorts = reservePorts
+	runtime.exitFormat = exitFormat
+	runtime.conmonLog = conmonLog
 
 	runtime.exitsDir = filepath.Join(runtime.tmpDir, "exits")
 	runtime.socketsDir = filepath.Join(runtime.tmpDir, "socket")
@@ -231,6 +276,17 @@ func bindPorts(ports []ocicni.PortMapping) ([]*os.File, error) {
 
 func (r *OCIRuntime) createOCIContainer(ctr *Container, cgroupParent string, restoreContainer bool) (err error) {
 	var stderrBuf bytes.Buffer
+	// runtimeOutput is the error message conmon relayed from the runtime
+	var runtimeOutput string
+	defer func() {
+		if err != nil {
+			output := runtimeOutput
+			if output == "" {
+				output = stderrBuf.String()
+			}
+			r.recordStartError(ctr, "create", err, output)
+		}
+	}()
 
 	runtimeDir, err := util.GetRootlessRuntimeDir()
 	if err != nil {
@@ -259,6 +315,7 @@ func (r *OCIRuntime) createOCIContainer(ctr *Container, cgroupParent string, res
 	args = append(args, "-r", r.path)
 	args = append(args, "-b", ctr.bundlePath())
 	args = append(args, "-p", filepath.Join(ctr.state.RunDir, "pidfile"))
+	ctr.trackRunDirFile("pidfile")
 	args = append(args, "-l", ctr.LogPath())
 	args = append(args, "--exit-dir", r.exitsDir)
 	if ctr.config.ConmonPidFile != "" {
@@ -283,10 +340,24 @@ func (r *OCIRuntime) createOCIContainer(ctr *Container, cgroupParent string, res
 		args = append(args, "--no-pivot")
 	}
 
-	logLevel := logrus.GetLevel()
-	args = append(args, "--log-level", logLevel.String())
+	logLevel := logrus.GetLevel().String()
+	if r.conmonLog.level != "" {
+		logLevel = r.conmonLog.level
+	}
+	args = append(args, "--log-level", logLevel)
 
-	if logLevel == logrus.DebugLevel {
+	var conmonLogFile *os.File
+	if r.conmonLog.toFile {
+		conmonLogPath := ctr.getArtifactPath(conmonLogArtifact)
+		conmonLogFile, err = os.OpenFile(conmonLogPath, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0640)
+		if err != nil {
+			logrus.Warnf("Error opening conmon log file %s of container %s: %v", conmonLogPath, ctr.ID(), err)
+		} else {
+			defer conmonLogFile.Close()
+			logrus.Debugf("%s messages will be logged to %s", r.conmonPath, conmonLogPath)
+		}
+	}
+	if conmonLogFile == nil && logLevel == logrus.DebugLevel.String() {
 		logrus.Debugf("%s messages will be logged to syslog", r.conmonPath)
 		args = append(args, "--syslog")
 	}
@@ -312,6 +383,14 @@ func (r *OCIRuntime) createOCIContainer(ctr *Container, cgroupParent string, res
 	if ctr.config.Spec.Process.Terminal {
 		cmd.Stderr = &stderrBuf
 	}
+	if conmonLogFile != nil {
+		// conmon logs its own messages to stderr
+		if ctr.config.Spec.Process.Terminal {
+			cmd.Stderr = io.MultiWriter(&stderrBuf, conmonLogFile)
+		} else {
+			cmd.Stderr = conmonLogFile
+		}
+	}
 
 	cmd.ExtraFiles = append(cmd.ExtraFiles, childPipe, childStartPipe)
 	// 0, 1 and 2 are stdin, stdout and stderr
@@ -320,7 +399,7 @@ func (r *OCIRuntime) createOCIContainer(ctr *Container, cgroupParent string, res
 	cmd.Env = append(cmd.Env, fmt.Sprintf("XDG_RUNTIME_DIR=%s", runtimeDir))
 
 	if r.reservePorts {
-		ports, err := bindPorts(ctr.config.PortMappings)
+		ports, err := bindPorts(ctr.portMappings())
 		if err != nil {
 			return err
 		}
@@ -439,6 +518,7 @@ func (r *OCIRuntime) createOCIContainer(ctr *Container, cgroupParent string, res
 		logrus.Debugf("Received container pid: %d", ss.si.Pid)
 		if ss.si.Pid == -1 {
 			if ss.si.Message != "" {
+				runtimeOutput = ss.si.Message
 				return errors.Wrapf(ErrInternal, "container create failed: %s", ss.si.Message)
 			}
 			return errors.Wrapf(ErrInternal, "container create failed")
@@ -514,7 +594,13 @@ func (r *OCIRuntime) updateContainerStatus(ctr *Container) error {
 	// Only grab exit status if we were not already stopped
 	// If we were, it should already be in the database
 	if ctr.state.State == ContainerStateStopped && oldState != ContainerStateStopped {
-		exitFile := filepath.Join(r.exitsDir, ctr.ID())
+		// The container's cgroup is kept until it is removed from the
+		// OCI runtime, so its memory usage can still be read
+		if err := ctr.recordPeakMemory(); err != nil {
+			logrus.Debugf("Error recording peak memory usage of container %s: %v", ctr.ID(), err)
+		}
+
+		exitFile := r.exitFilePath(ctr)
 		var fi os.FileInfo
 		err = kwait.ExponentialBackoff(
 			kwait.Backoff{
@@ -533,34 +619,95 @@ func (r *OCIRuntime) updateContainerStatus(ctr *Container) error {
 			})
 		if err != nil {
 			ctr.state.ExitCode = -1
+			ctr.state.ExitCodeSource = ExitCodeSourceRuntime
 			ctr.state.FinishedTime = time.Now()
 			logrus.Errorf("No exit file for container %s found: %v", ctr.ID(), err)
 			return nil
 		}
 
 		ctr.state.FinishedTime = ctime.Created(fi)
-		statusCodeStr, err := ioutil.ReadFile(exitFile)
+		contents, err := ioutil.ReadFile(exitFile)
 		if err != nil {
 			return errors.Wrapf(err, "failed to read exit file for container %s", ctr.ID())
 		}
-		statusCode, err := strconv.Atoi(string(statusCodeStr))
+		exitCode, format, err := parseExitFile(contents, r.exitFormat)
 		if err != nil {
-			return errors.Wrapf(err, "error converting exit status code for container %s to int",
-				ctr.ID())
+			// The OCI runtime says the container stopped, so trust
+			// that even though we do not know how it exited
+			logrus.Errorf("Error parsing exit file of container %s: %v", ctr.ID(), err)
+			ctr.state.ExitCode = -1
+			ctr.state.ExitCodeSource = ExitCodeSourceRuntime
+		} else {
+			logrus.Debugf("Read exit code %d of container %s from %s exit file %s", exitCode, ctr.ID(), format, exitFile)
+			ctr.state.ExitCode = exitCode
+			ctr.state.ExitCodeSource = exitFile
 		}
-		ctr.state.ExitCode = int32(statusCode)
 
 		oomFilePath := filepath.Join(ctr.bundlePath(), "oom")
 		if _, err = os.Stat(oomFilePath); err == nil {
 			ctr.state.OOMKilled = true
 		}
 
+		if sig, crashed := crashSignal(ctr.state.ExitCode); crashed && ctr.config.CoreDumpMaxSize > 0 {
+			logrus.Infof("Container %s was killed by %s, collecting core dumps", ctr.ID(), sig)
+			if err := ctr.collectCoreDumps(); err != nil {
+				logrus.Errorf("Error collecting core dumps of container %s: %v", ctr.ID(), err)
+			}
+		}
+
 		ctr.state.Exited = true
 	}
 
 	return nil
 }
 
+// exitFilePath returns the path of the file conmon writes the container's exit
+// code to
+func (r *OCIRuntime) exitFilePath(ctr *Container) string {
+	return filepath.Join(r.exitsDir, ctr.ID())
+}
+
+// parseExitFile parses the contents of an exit file in the given format, or in
+// whichever known format they are in if it is ExitFileFormatAuto or "".
+// It returns the exit code and the format it was found in.
+func parseExitFile(contents []byte, format ExitFileFormat) (int32, ExitFileFormat, error) {
+	formats := []ExitFileFormat{format}
+	if format == "" || format == ExitFileFormatAuto {
+		formats = []ExitFileFormat{ExitFileFormatPlain, ExitFileFormatJSON}
+	}
+
+	var lastErr error
+	for _, f := range formats {
+		var (
+			exitCode int64
+			err      error
+		)
+		switch f {
+		case ExitFileFormatPlain:
+			exitCode, err = strconv.ParseInt(strings.TrimSpace(string(contents)), 10, 32)
+		case ExitFileFormatJSON:
+			var exitFile struct {
+				ExitCode *int32 `json:"exit_code"`
+			}
+			if err = json.Unmarshal(contents, &exitFile); err == nil {
+				if exitFile.ExitCode == nil {
+					err = errors.Errorf("no exit_code field")
+				} else {
+					exitCode = int64(*exitFile.ExitCode)
+				}
+			}
+		default:
+			return 0, "", errors.Wrapf(ErrInvalidArg, "unknown exit file format %q", f)
+		}
+		if err == nil {
+			return int32(exitCode), f, nil
+		}
+		lastErr = errors.Wrapf(err, "exit file is not in %s format", f)
+	}
+
+	return 0, "", lastErr
+}
+
 // startContainer starts the given container
 // Sets time the container was started, but does not save it.
 func (r *OCIRuntime) startContainer(ctr *Container) error {
@@ -570,15 +717,39 @@ func (r *OCIRuntime) startContainer(ctr *Container) error {
 		return err
 	}
 	env := []string{fmt.Sprintf("XDG_RUNTIME_DIR=%s", runtimeDir)}
-	if err := utils.ExecCmdWithStdStreams(os.Stdin, os.Stdout, os.Stderr, env, r.path, "start", ctr.ID()); err != nil {
+	var stderrBuf bytes.Buffer
+	if err := utils.ExecCmdWithStdStreams(os.Stdin, os.Stdout, io.MultiWriter(os.Stderr, &stderrBuf), env, r.path, "start", ctr.ID()); err != nil {
+		r.recordStartError(ctr, "start", err, stderrBuf.String())
 		return err
 	}
 
 	ctr.state.StartedTime = time.Now()
+	ctr.state.StartError = nil
 
 	return nil
 }
 
+// recordStartError stores why the OCI runtime failed to create or start a
+// container in the container's state, including the exit code conmon wrote to
+// the exit file, if it wrote one.
+// It does not save the container's state.
+func (r *OCIRuntime) recordStartError(ctr *Container, stage string, err error, output string) {
+	startErr := &StartError{
+		Stage:         stage,
+		Message:       err.Error(),
+		RuntimeOutput: strings.TrimSpace(output),
+		Time:          time.Now(),
+	}
+
+	if contents, err := ioutil.ReadFile(r.exitFilePath(ctr)); err == nil {
+		if exitCode, _, err := parseExitFile(contents, r.exitFormat); err == nil {
+			startErr.ExitCode = &exitCode
+		}
+	}
+
+	ctr.state.StartError = startErr
+}
+
 // killContainer sends the given signal to the given container
 func (r *OCIRuntime) killContainer(ctr *Container, signal uint) error {
 	logrus.Debugf("Sending signal %d to container %s", signal, ctr.ID())
@@ -594,6 +765,54 @@ func (r *OCIRuntime) killContainer(ctr *Container, signal uint) error {
 	return nil
 }
 
+// execContainerSync runs a command in the given container and waits for it to
+// exit, returning its combined output
+// The command is not tracked as an exec session
+func (r *OCIRuntime) execContainerSync(ctr *Container, cmd []string) (string, error) {
+	return r.execContainerSyncWithTimeout(ctr, cmd, 0)
+}
+
+// execContainerSyncWithTimeout is execContainerSync, killing the command if it
+// does not exit within timeout. A timeout of 0 waits indefinitely.
+func (r *OCIRuntime) execContainerSyncWithTimeout(ctr *Container, cmd []string, timeout time.Duration) (string, error) {
+	if len(cmd) == 0 {
+		return "", errors.Wrapf(ErrInvalidArg, "must provide a command to execute")
+	}
+
+	runtimeDir, err := util.GetRootlessRuntimeDir()
+	if err != nil {
+		return "", err
+	}
+
+	args := []string{"exec"}
+	if ctr.config.Spec.Process != nil && ctr.config.Spec.Process.Cwd != "" {
+		args = append(args, "--cwd", ctr.config.Spec.Process.Cwd)
+	}
+	args = append(args, ctr.ID())
+	args = append(args, cmd...)
+
+	logrus.Debugf("Running runtime %s with following arguments: %v", r.path, args)
+
+	ctx := context.Background()
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	execCmd := exec.CommandContext(ctx, r.path, args...)
+	execCmd.Env = append(os.Environ(), fmt.Sprintf("XDG_RUNTIME_DIR=%s", runtimeDir))
+	output, err := execCmd.CombinedOutput()
+	if ctx.Err() == context.DeadlineExceeded {
+		return strings.TrimSpace(string(output)), errors.Wrapf(ErrExecTimeout, "%v did not exit within %s in container %s", cmd, timeout, ctr.ID())
+	}
+	if err != nil {
+		return strings.TrimSpace(string(output)), errors.Wrapf(err, "error running %v in container %s", cmd, ctr.ID())
+	}
+
+	return strings.TrimSpace(string(output)), nil
+}
+
 // stopContainer stops a container, first using its given stop signal (or
 // SIGTERM if no signal was specified), then using SIGKILL
 // Timeout is given in seconds. If timeout is 0, the container will be
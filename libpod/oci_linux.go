@@ -15,11 +15,19 @@ import (
 	"github.com/containers/libpod/utils"
 	"github.com/containers/storage/pkg/idtools"
 	spec "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"golang.org/x/sys/unix"
 )
 
 func (r *OCIRuntime) moveConmonToCgroup(ctr *Container, cgroupParent string, cmd *exec.Cmd) error {
+	if ctr.config.ConmonCgroup != "" {
+		return r.moveConmonToConfiguredCgroup(ctr, cmd)
+	}
+	if mode := ctr.CgroupMode(); mode != CgroupModeEnabled {
+		logrus.Debugf("Leaving conmon for container %s in its current cgroup (cgroup mode %s)", ctr.ID(), mode)
+		return nil
+	}
 	if os.Geteuid() == 0 {
 		if r.cgroupManager == SystemdCgroupsManager {
 			unitName := createUnitName("libpod-conmon", ctr.ID())
@@ -51,6 +59,62 @@ func (r *OCIRuntime) moveConmonToCgroup(ctr *Container, cgroupParent string, cmd
 	return nil
 }
 
+// moveConmonToConfiguredCgroup places conmon in the cgroup set in the
+// container's configuration, recording it in the container's state
+func (r *OCIRuntime) moveConmonToConfiguredCgroup(ctr *Container, cmd *exec.Cmd) error {
+	if os.Geteuid() != 0 {
+		logrus.Warnf("Cannot place conmon for container %s in cgroup %s as a non-root user", ctr.ID(), ctr.config.ConmonCgroup)
+		return nil
+	}
+
+	if r.cgroupManager == SystemdCgroupsManager {
+		unitName := createUnitName("libpod-conmon", ctr.ID())
+		slice := filepath.Base(ctr.config.ConmonCgroup)
+
+		logrus.Infof("Running conmon under slice %s and unitName %s", slice, unitName)
+		if err := utils.RunUnderSystemdScope(cmd.Process.Pid, slice, unitName); err != nil {
+			return errors.Wrapf(err, "error placing conmon for container %s in slice %s", ctr.ID(), slice)
+		}
+		ctr.state.ConmonCgroupPath = filepath.Join(ctr.config.ConmonCgroup, unitName)
+		ctr.state.ConmonCgroupCreated = false
+		return nil
+	}
+
+	// We only know how to manage cgroup v1 hierarchies
+	unified, err := isCgroup2UnifiedMode()
+	if err != nil {
+		return err
+	}
+	if unified {
+		logrus.Warnf("Cannot place conmon for container %s in cgroup %s on a cgroup v2 host", ctr.ID(), ctr.config.ConmonCgroup)
+		return nil
+	}
+
+	cgroupPath := ctr.config.ConmonCgroup
+	exists, err := cgroupExists(cgroupPath)
+	if err != nil {
+		return errors.Wrapf(err, "error checking for conmon cgroup %s of container %s", cgroupPath, ctr.ID())
+	}
+
+	var control cgroups.Cgroup
+	if exists {
+		control, err = cgroups.Load(GetV1CGroups(getExcludedCGroups()), cgroups.StaticPath(cgroupPath))
+	} else {
+		control, err = cgroups.New(GetV1CGroups(getExcludedCGroups()), cgroups.StaticPath(cgroupPath), &spec.LinuxResources{})
+	}
+	if err != nil {
+		return errors.Wrapf(err, "error setting up conmon cgroup %s for container %s", cgroupPath, ctr.ID())
+	}
+	if err := control.Add(cgroups.Process{Pid: cmd.Process.Pid}); err != nil {
+		return errors.Wrapf(err, "error placing conmon for container %s in cgroup %s", ctr.ID(), cgroupPath)
+	}
+
+	ctr.state.ConmonCgroupPath = cgroupPath
+	// Keep ownership of the cgroup if we created it on an earlier start
+	ctr.state.ConmonCgroupCreated = !exists || ctr.state.ConmonCgroupCreated
+	return nil
+}
+
 // newPipe creates a unix socket pair for communication
 func newPipe() (parent *os.File, child *os.File, err error) {
 	fds, err := unix.Socketpair(unix.AF_LOCAL, unix.SOCK_STREAM|unix.SOCK_CLOEXEC, 0)
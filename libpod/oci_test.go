@@ -0,0 +1,43 @@
+package libpod
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseExitFile(t *testing.T) {
+	for _, tc := range []struct {
+		contents string
+		format   ExitFileFormat
+		code     int32
+		found    ExitFileFormat
+	}{
+		{"0", ExitFileFormatAuto, 0, ExitFileFormatPlain},
+		{"137", "", 137, ExitFileFormatPlain},
+		{"1\n", ExitFileFormatPlain, 1, ExitFileFormatPlain},
+		{"-1", ExitFileFormatAuto, -1, ExitFileFormatPlain},
+		{`{"exit_code": 2}`, ExitFileFormatAuto, 2, ExitFileFormatJSON},
+		{"{\"exit_code\":255}\n", ExitFileFormatJSON, 255, ExitFileFormatJSON},
+	} {
+		code, found, err := parseExitFile([]byte(tc.contents), tc.format)
+		assert.NoError(t, err, tc.contents)
+		assert.Equal(t, tc.code, code, tc.contents)
+		assert.Equal(t, tc.found, found, tc.contents)
+	}
+
+	for _, tc := range []struct {
+		contents string
+		format   ExitFileFormat
+	}{
+		{"", ExitFileFormatAuto},
+		{"garbage", ExitFileFormatAuto},
+		{`{"exit_code": 2}`, ExitFileFormatPlain},
+		{"2", ExitFileFormatJSON},
+		{`{"status": 2}`, ExitFileFormatJSON},
+		{"2", "xml"},
+	} {
+		_, _, err := parseExitFile([]byte(tc.contents), tc.format)
+		assert.Error(t, err, tc.contents)
+	}
+}
@@ -5,16 +5,22 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"strings"
 	"syscall"
+	"time"
 
 	"github.com/containers/storage"
 	"github.com/containers/storage/pkg/idtools"
 	"github.com/cri-o/ocicni/pkg/ocicni"
 	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 )
 
 var (
 	nameRegex = regexp.MustCompile("[a-zA-Z0-9_-]+")
+	// volumeNameRegex matches the names of volumes, which are used as
+	// directory names
+	volumeNameRegex = regexp.MustCompile("^[a-zA-Z0-9][a-zA-Z0-9_.-]*$")
 )
 
 // Runtime Creation Options
@@ -32,6 +38,7 @@ func WithStorageConfig(config storage.StoreOptions) RuntimeOption {
 		rt.config.StorageConfig.GraphRoot = config.GraphRoot
 		rt.config.StorageConfig.GraphDriverName = config.GraphDriverName
 		rt.config.StaticDir = filepath.Join(config.GraphRoot, "libpod")
+		rt.config.VolumePath = filepath.Join(config.GraphRoot, "volumes")
 
 		rt.config.StorageConfig.GraphDriverOptions = make([]string, len(config.GraphDriverOptions))
 		copy(rt.config.StorageConfig.GraphDriverOptions, config.GraphDriverOptions)
@@ -178,6 +185,19 @@ func WithStaticDir(dir string) RuntimeOption {
 	}
 }
 
+// WithVolumePath sets the directory the data of named volumes is stored in.
+func WithVolumePath(volPath string) RuntimeOption {
+	return func(rt *Runtime) error {
+		if rt.valid {
+			return ErrRuntimeFinalized
+		}
+
+		rt.config.VolumePath = volPath
+
+		return nil
+	}
+}
+
 // WithHooksDir sets the directory to look for OCI runtime hooks config.
 // Note we are not saving this in database, since this is really just for used
 // for testing.
@@ -244,6 +264,155 @@ func WithMaxLogSize(limit int64) RuntimeOption {
 	}
 }
 
+// WithMaxConcurrentMounts sets the maximum number of containers whose storage
+// the runtime will mount at the same time. Further mounts wait until an
+// earlier one completes. 0 is unlimited.
+func WithMaxConcurrentMounts(limit uint) RuntimeOption {
+	return func(rt *Runtime) error {
+		if rt.valid {
+			return ErrRuntimeFinalized
+		}
+
+		rt.config.MaxConcurrentMounts = limit
+
+		return nil
+	}
+}
+
+// WithStateDivergencePolicy sets what libpod does when the OCI runtime reports a
+// container in a state libpod did not expect it to be in.
+func WithStateDivergencePolicy(policy StateDivergencePolicy) RuntimeOption {
+	return func(rt *Runtime) error {
+		if rt.valid {
+			return ErrRuntimeFinalized
+		}
+
+		switch policy {
+		case StateDivergencePolicyAdopt, StateDivergencePolicyLog, StateDivergencePolicyEvent, StateDivergencePolicyRefuse:
+		default:
+			return errors.Wrapf(ErrInvalidArg, "invalid state divergence policy %q", policy)
+		}
+
+		rt.config.StateDivergencePolicy = policy
+
+		return nil
+	}
+}
+
+// WithResourceCheckPolicy sets whether the runtime checks that the host can
+// satisfy a container's memory and CPU resources before creating it in the OCI
+// runtime, and whether it only warns or refuses to create the container if the
+// host cannot.
+func WithResourceCheckPolicy(policy ResourceCheckPolicy) RuntimeOption {
+	return func(rt *Runtime) error {
+		if rt.valid {
+			return ErrRuntimeFinalized
+		}
+
+		switch policy {
+		case ResourceCheckPolicyIgnore, ResourceCheckPolicyWarn, ResourceCheckPolicyRefuse:
+		default:
+			return errors.Wrapf(ErrInvalidArg, "invalid resource check policy %q", policy)
+		}
+
+		rt.config.ResourceCheckPolicy = policy
+
+		return nil
+	}
+}
+
+// WithHostPortRange sets the range of host ports, inclusive, that are
+// allocated to port mappings of containers that request any host port by
+// setting it to 0.
+func WithHostPortRange(start, end int) RuntimeOption {
+	return func(rt *Runtime) error {
+		if rt.valid {
+			return ErrRuntimeFinalized
+		}
+
+		if err := validateHostPortRange(start, end); err != nil {
+			return err
+		}
+
+		rt.config.HostPortRangeStart = start
+		rt.config.HostPortRangeEnd = end
+
+		return nil
+	}
+}
+
+// WithConmonLogLevel sets the level conmon logs its own messages at, such as
+// "debug" or "warning". By default, conmon logs at libpod's log level.
+func WithConmonLogLevel(level string) RuntimeOption {
+	return func(rt *Runtime) error {
+		if rt.valid {
+			return ErrRuntimeFinalized
+		}
+
+		if _, err := logrus.ParseLevel(level); err != nil {
+			return errors.Wrapf(ErrInvalidArg, "invalid conmon log level %q", level)
+		}
+
+		rt.config.ConmonLogLevel = level
+
+		return nil
+	}
+}
+
+// WithConmonLogToFile sets whether conmon logs its own messages to a conmon.log
+// artifact of each container, separately from the containers' output, which
+// can be read with the container's ConmonLog().
+func WithConmonLogToFile(toFile bool) RuntimeOption {
+	return func(rt *Runtime) error {
+		if rt.valid {
+			return ErrRuntimeFinalized
+		}
+
+		rt.config.ConmonLogToFile = toFile
+
+		return nil
+	}
+}
+
+// WithExitFileFormat sets the format conmon writes containers' exit codes to
+// their exit files in. ExitFileFormatAuto accepts any known format.
+func WithExitFileFormat(format ExitFileFormat) RuntimeOption {
+	return func(rt *Runtime) error {
+		if rt.valid {
+			return ErrRuntimeFinalized
+		}
+
+		switch format {
+		case ExitFileFormatAuto, ExitFileFormatPlain, ExitFileFormatJSON:
+		default:
+			return errors.Wrapf(ErrInvalidArg, "invalid exit file format %q", format)
+		}
+
+		rt.config.ExitFileFormat = format
+
+		return nil
+	}
+}
+
+// WithSecretStore sets the store the secrets injected into containers'
+// environments are resolved from. It takes precedence over the runtime's
+// EnvSecretsDir.
+func WithSecretStore(store SecretStore) RuntimeOption {
+	return func(rt *Runtime) error {
+		if rt.valid {
+			return ErrRuntimeFinalized
+		}
+
+		if store == nil {
+			return errors.Wrapf(ErrInvalidArg, "must provide a secret store")
+		}
+
+		rt.secretStore = store
+
+		return nil
+	}
+}
+
 // WithNoPivotRoot sets the runtime to use MS_MOVE instead of PIVOT_ROOT when
 // starting containers.
 func WithNoPivotRoot(noPivot bool) RuntimeOption {
@@ -349,6 +518,74 @@ func WithShmDir(dir string) CtrCreateOption {
 	}
 }
 
+// WithReadOnlyRootfs makes the container's root filesystem read-only. Its image
+// mount is remounted read-only on the host once mounted, and the container's
+// OCI spec sets a read-only root. Mounts on top of the root filesystem, such as
+// tmpfs mounts on /run and the SHM mount, remain writable.
+func WithReadOnlyRootfs() CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return ErrCtrFinalized
+		}
+
+		ctr.config.ReadOnlyRootfs = true
+
+		return nil
+	}
+}
+
+// WithReuseExisting makes creating the container return the existing container
+// with the same name, if there is one, instead of failing with ErrCtrExists,
+// to make creation idempotent.
+// The existing container is returned as it is; its configuration is not
+// compared against the options given.
+func WithReuseExisting() CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return ErrCtrFinalized
+		}
+
+		ctr.reuseExisting = true
+
+		return nil
+	}
+}
+
+// WithStorageMount adds a mount of source at mountpoint on the host, with the
+// given filesystem type and mount options, that is mounted along with the
+// container's storage and unmounted when it is cleaned up, so the container's
+// OCI spec can bind mount mountpoint into the container.
+func WithStorageMount(source, mountpoint, fsType string, options []string) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return ErrCtrFinalized
+		}
+
+		if !filepath.IsAbs(mountpoint) {
+			return errors.Wrapf(ErrInvalidArg, "storage mount point %q must be an absolute path", mountpoint)
+		}
+		mountpoint = filepath.Clean(mountpoint)
+		if _, ok := ctr.config.StorageMounts[mountpoint]; ok {
+			return errors.Wrapf(ErrInvalidArg, "storage mount point %q is already in use", mountpoint)
+		}
+		if source == "" || fsType == "" {
+			return errors.Wrapf(ErrInvalidArg, "storage mount at %q must have a source and a filesystem type", mountpoint)
+		}
+
+		if ctr.config.StorageMounts == nil {
+			ctr.config.StorageMounts = make(map[string]StorageMount)
+		}
+		ctr.config.StorageMounts[mountpoint] = StorageMount{
+			Source:  source,
+			Type:    fsType,
+			Options: append([]string{}, options...),
+		}
+		ctr.config.Mounts = append(ctr.config.Mounts, mountpoint)
+
+		return nil
+	}
+}
+
 // WithSystemd turns on systemd mode in the container
 func WithSystemd() CtrCreateOption {
 	return func(ctr *Container) error {
@@ -373,6 +610,65 @@ func WithShmSize(size int64) CtrCreateOption {
 	}
 }
 
+// WithShmMode sets the mode of /dev/shm tmpfs mount. The sticky, setuid and
+// setgid bits are given as os.ModeSticky, os.ModeSetuid and os.ModeSetgid.
+func WithShmMode(mode os.FileMode) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return ErrCtrFinalized
+		}
+
+		if mode&^(os.ModePerm|os.ModeSticky|os.ModeSetuid|os.ModeSetgid) != 0 {
+			return errors.Wrapf(ErrInvalidArg, "invalid mode %v for /dev/shm", mode)
+		}
+
+		ctr.config.ShmMode = mode
+		return nil
+	}
+}
+
+// WithHostShm bind mounts the host's /dev/shm into the container instead of
+// a tmpfs mount. It cannot be used together with WithShmDir or WithIPCNSFrom,
+// which supply the container's /dev/shm themselves.
+func WithHostShm() CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return ErrCtrFinalized
+		}
+
+		ctr.config.UseHostShm = true
+		return nil
+	}
+}
+
+// WithRunTmpfs mounts a tmpfs of the given size, in bytes, and mode, such as
+// 0755, on the container's /run. A size or mode of 0 selects the default.
+// Containers in systemd mode always get a tmpfs on /run; this option sets its
+// size and mode. It cannot be used if the container's spec mounts something
+// else on /run.
+func WithRunTmpfs(size int64, mode uint32) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return ErrCtrFinalized
+		}
+
+		if size < 0 {
+			return errors.Wrapf(ErrInvalidArg, "size of /run tmpfs must not be negative")
+		}
+		if mode > 07777 {
+			return errors.Wrapf(ErrInvalidArg, "invalid mode %o for /run tmpfs", mode)
+		}
+		if MountExists(ctr.config.Spec.Mounts, "/run") {
+			return errors.Wrapf(ErrInvalidArg, "cannot mount a tmpfs on /run, the container already has a mount there")
+		}
+
+		ctr.config.RunTmpfs = true
+		ctr.config.RunTmpfsSize = size
+		ctr.config.RunTmpfsMode = mode
+		return nil
+	}
+}
+
 // WithPrivileged sets the privileged flag in the container runtime.
 func WithPrivileged(privileged bool) CtrCreateOption {
 	return func(ctr *Container) error {
@@ -435,101 +731,361 @@ func WithRootFSFromImage(imageID string, imageName string, useImageVolumes bool)
 	}
 }
 
-// WithStdin keeps stdin on the container open to allow interaction.
-func WithStdin() CtrCreateOption {
+// WithStdin keeps stdin on the container open to allow interaction.
+func WithStdin() CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return ErrCtrFinalized
+		}
+
+		ctr.config.Stdin = true
+
+		return nil
+	}
+}
+
+// WithPod adds the container to a pod.
+// Containers which join a pod can only join the Linux namespaces of other
+// containers in the same pod.
+// Containers can only join pods in the same libpod namespace.
+func (r *Runtime) WithPod(pod *Pod) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return ErrCtrFinalized
+		}
+
+		if pod == nil {
+			return ErrInvalidArg
+		}
+
+		ctr.config.Pod = pod.ID()
+
+		return nil
+	}
+}
+
+// WithLabels adds labels to the container.
+func WithLabels(labels map[string]string) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return ErrCtrFinalized
+		}
+
+		ctr.config.Labels = make(map[string]string)
+		for key, value := range labels {
+			ctr.config.Labels[key] = value
+		}
+
+		return nil
+	}
+}
+
+// WithName sets the container's name.
+func WithName(name string) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return ErrCtrFinalized
+		}
+
+		// Check the name against a regex
+		if !nameRegex.MatchString(name) {
+			return errors.Wrapf(ErrInvalidArg, "name must match regex [a-zA-Z0-9_-]+")
+		}
+
+		ctr.config.Name = name
+
+		return nil
+	}
+}
+
+// WithStopSignal sets the signal that will be sent to stop the container.
+func WithStopSignal(signal syscall.Signal) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return ErrCtrFinalized
+		}
+
+		if signal == 0 {
+			return errors.Wrapf(ErrInvalidArg, "stop signal cannot be 0")
+		} else if signal > 64 {
+			return errors.Wrapf(ErrInvalidArg, "stop signal cannot be greater than 64 (SIGRTMAX)")
+		}
+
+		ctr.config.StopSignal = uint(signal)
+
+		return nil
+	}
+}
+
+// WithStopTimeout sets the time to after initial stop signal is sent to the
+// container, before sending the kill signal.
+func WithStopTimeout(timeout uint) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return ErrCtrFinalized
+		}
+
+		ctr.config.StopTimeout = timeout
+
+		return nil
+	}
+}
+
+// WithStartupTimeout sets the time, in seconds, the container is given to reach
+// the running state. Containers that do not start in time are killed, with the
+// timeout recorded as their stop reason.
+func WithStartupTimeout(timeout uint) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return ErrCtrFinalized
+		}
+
+		ctr.config.StartupTimeout = timeout
+
+		return nil
+	}
+}
+
+// WithStartupTimings has the durations of the phases of the container's setup
+// and start recorded, for diagnosing slow container startup.
+func WithStartupTimings() CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return ErrCtrFinalized
+		}
+
+		ctr.config.RecordStartupTimings = true
+
+		return nil
+	}
+}
+
+// WithHealthCheck sets a command that is run periodically in the container
+// while it runs to check whether it is healthy
+func WithHealthCheck(healthCheck HealthConfig) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return ErrCtrFinalized
+		}
+
+		if _, err := healthCheckCommand(healthCheck.Test); err != nil {
+			return err
+		}
+		if healthCheck.Interval < 0 || healthCheck.Timeout < 0 || healthCheck.StartPeriod < 0 {
+			return errors.Wrapf(ErrInvalidArg, "health check durations must not be negative")
+		}
+		if healthCheck.Retries < 0 {
+			return errors.Wrapf(ErrInvalidArg, "health check retries must not be negative")
+		}
+
+		healthCheck.Test = append([]string{}, healthCheck.Test...)
+		ctr.config.HealthCheck = &healthCheck
+
+		return nil
+	}
+}
+
+// WithRestartPolicy sets when the container is restarted after it exits, one of
+// the RestartPolicy constants. retries limits the number of restarts in a row
+// under the on-failure policy; 0 means no limit.
+// A restart policy cannot be combined with WithAutoRemove().
+func WithRestartPolicy(policy string, retries int) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return ErrCtrFinalized
+		}
+
+		switch policy {
+		case RestartPolicyNo, RestartPolicyOnFailure, RestartPolicyAlways, RestartPolicyUnlessStopped:
+		default:
+			return errors.Wrapf(ErrInvalidArg, "invalid restart policy %q", policy)
+		}
+		if retries < 0 {
+			return errors.Wrapf(ErrInvalidArg, "restart retries must not be negative")
+		}
+		if retries > 0 && policy != RestartPolicyOnFailure {
+			return errors.Wrapf(ErrInvalidArg, "restart retries can only be set for the %s restart policy", RestartPolicyOnFailure)
+		}
+		if policy != RestartPolicyNo && ctr.config.AutoRemove {
+			return errors.Wrapf(ErrInvalidArg, "containers removed automatically cannot have a restart policy")
+		}
+
+		ctr.config.RestartPolicy = policy
+		ctr.config.RestartRetries = retries
+
+		return nil
+	}
+}
+
+// WithRestartBackoff has restarts of the container delayed, starting at base
+// and growing by multiplier with each consecutive restart up to max.
+// Each delay is shortened by a random fraction of up to jitter of itself, so
+// containers that fail together do not all restart at the same time.
+// The backoff is stored in the container's state, so it survives restarts of
+// the process managing the container.
+func WithRestartBackoff(base, max time.Duration, multiplier, jitter float64) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return ErrCtrFinalized
+		}
+
+		if base <= 0 {
+			return errors.Wrapf(ErrInvalidArg, "restart backoff base delay must be positive")
+		}
+		if max < base {
+			return errors.Wrapf(ErrInvalidArg, "restart backoff maximum delay must be at least the base delay")
+		}
+		if multiplier < 1 {
+			return errors.Wrapf(ErrInvalidArg, "restart backoff multiplier must be at least 1")
+		}
+		if jitter < 0 || jitter > 1 {
+			return errors.Wrapf(ErrInvalidArg, "restart backoff jitter must be between 0 and 1")
+		}
+
+		ctr.config.RestartBackoff = &RestartBackoff{
+			BaseDelay:  base,
+			MaxDelay:   max,
+			Multiplier: multiplier,
+			Jitter:     jitter,
+		}
+
+		return nil
+	}
+}
+
+// WithStartPaused has Start() set up the container and create it in the OCI
+// runtime, but hold its process before exec until Resume() is called.
+// Calling Start() on a container that is already created starts it.
+// This allows callers to finish configuring the container's environment, for
+// example its network namespace, before it runs.
+func WithStartPaused() CtrCreateOption {
 	return func(ctr *Container) error {
 		if ctr.valid {
 			return ErrCtrFinalized
 		}
 
-		ctr.config.Stdin = true
+		ctr.config.StartPaused = true
 
 		return nil
 	}
 }
 
-// WithPod adds the container to a pod.
-// Containers which join a pod can only join the Linux namespaces of other
-// containers in the same pod.
-// Containers can only join pods in the same libpod namespace.
-func (r *Runtime) WithPod(pod *Pod) CtrCreateOption {
+// WithCoreDumps enables collection of core dumps when the container's process
+// is killed by a signal that dumps core. The container's core size limit is set
+// to maxSize, and cores are collected into the container's artifacts, removing
+// the oldest cores to keep their total size below maxSize.
+// The host's core pattern must write cores to the working directory of the
+// crashing process, as the kernel's default pattern does.
+func WithCoreDumps(maxSize int64) CtrCreateOption {
 	return func(ctr *Container) error {
 		if ctr.valid {
 			return ErrCtrFinalized
 		}
 
-		if pod == nil {
-			return ErrInvalidArg
+		if maxSize <= 0 {
+			return errors.Wrapf(ErrInvalidArg, "core dump size limit must be greater than 0")
 		}
 
-		ctr.config.Pod = pod.ID()
+		ctr.config.CoreDumpMaxSize = maxSize
 
 		return nil
 	}
 }
 
-// WithLabels adds labels to the container.
-func WithLabels(labels map[string]string) CtrCreateOption {
+// WithCheckpointHooks sets commands run in the container around checkpoints,
+// so that applications such as databases are checkpointed consistently.
+// quiesce is run before the container is checkpointed, and the checkpoint is
+// aborted if it fails. resume is run once the container is restored, or if the
+// checkpoint fails after quiesce succeeded. Either may be empty.
+// If syncFS is set, the container's root filesystem is synced to disk after
+// quiesce and before the checkpoint.
+func WithCheckpointHooks(quiesce, resume []string, syncFS bool) CtrCreateOption {
 	return func(ctr *Container) error {
 		if ctr.valid {
 			return ErrCtrFinalized
 		}
 
-		ctr.config.Labels = make(map[string]string)
-		for key, value := range labels {
-			ctr.config.Labels[key] = value
-		}
+		ctr.config.CheckpointQuiesceCommand = quiesce
+		ctr.config.CheckpointResumeCommand = resume
+		ctr.config.CheckpointSyncFS = syncFS
 
 		return nil
 	}
 }
 
-// WithName sets the container's name.
-func WithName(name string) CtrCreateOption {
+// WithFSAudit has changes to files in the container's root filesystem recorded
+// in its fsaudit.json artifact while it runs, one JSON object per line.
+// Auditing is expensive, so it is bounded: at most rate changes per second are
+// recorded, with a count of those that were not, and auditing stops once the
+// log reaches maxSize bytes. 0 selects DefaultFSAuditMaxSize and
+// DefaultFSAuditRate.
+func WithFSAudit(maxSize int64, rate uint) CtrCreateOption {
 	return func(ctr *Container) error {
 		if ctr.valid {
 			return ErrCtrFinalized
 		}
 
-		// Check the name against a regex
-		if !nameRegex.MatchString(name) {
-			return errors.Wrapf(ErrInvalidArg, "name must match regex [a-zA-Z0-9_-]+")
+		if maxSize < 0 {
+			return errors.Wrapf(ErrInvalidArg, "filesystem audit log size must not be negative")
+		}
+		if maxSize == 0 {
+			maxSize = DefaultFSAuditMaxSize
+		}
+		if rate == 0 {
+			rate = DefaultFSAuditRate
 		}
 
-		ctr.config.Name = name
+		ctr.config.FSAudit = true
+		ctr.config.FSAuditMaxSize = maxSize
+		ctr.config.FSAuditRate = rate
 
 		return nil
 	}
 }
 
-// WithStopSignal sets the signal that will be sent to stop the container.
-func WithStopSignal(signal syscall.Signal) CtrCreateOption {
+// WithEnvSecrets sets environment variables of the container's process to the
+// values of secrets, given as a map of variable names to secret names.
+// The secrets are resolved from the runtime's secret store whenever the
+// container is created in the OCI runtime. Their values are never stored in
+// the container's configuration; they are only in the OCI spec in the
+// container's bundle while the OCI runtime creates the container.
+func WithEnvSecrets(secrets map[string]string) CtrCreateOption {
 	return func(ctr *Container) error {
 		if ctr.valid {
 			return ErrCtrFinalized
 		}
 
-		if signal == 0 {
-			return errors.Wrapf(ErrInvalidArg, "stop signal cannot be 0")
-		} else if signal > 64 {
-			return errors.Wrapf(ErrInvalidArg, "stop signal cannot be greater than 64 (SIGRTMAX)")
+		envSecrets := make(map[string]string, len(secrets))
+		for name, secret := range secrets {
+			if name == "" || strings.ContainsAny(name, "=\x00") {
+				return errors.Wrapf(ErrInvalidArg, "invalid environment variable name %q", name)
+			}
+			if err := validateSecretName(secret); err != nil {
+				return err
+			}
+			envSecrets[name] = secret
 		}
 
-		ctr.config.StopSignal = uint(signal)
+		ctr.config.EnvSecrets = envSecrets
 
 		return nil
 	}
 }
 
-// WithStopTimeout sets the time to after initial stop signal is sent to the
-// container, before sending the kill signal.
-func WithStopTimeout(timeout uint) CtrCreateOption {
+// WithAutoRemove has the container removed once it has exited and been cleaned
+// up, as is done when conmon runs the container's exit command.
+// It cannot be combined with a restart policy.
+func WithAutoRemove() CtrCreateOption {
 	return func(ctr *Container) error {
 		if ctr.valid {
 			return ErrCtrFinalized
 		}
 
-		ctr.config.StopTimeout = timeout
+		if ctr.config.RestartPolicy != "" && ctr.config.RestartPolicy != RestartPolicyNo {
+			return errors.Wrapf(ErrInvalidArg, "containers with a restart policy cannot be removed automatically")
+		}
+
+		ctr.config.AutoRemove = true
 
 		return nil
 	}
@@ -813,6 +1369,87 @@ func WithDependencyCtrs(ctrs []*Container) CtrCreateOption {
 	}
 }
 
+// WithRootfsMountFrom bind-mounts the root filesystem of the given container
+// into the container at dest.
+// The other container's storage is mounted for as long as the container is
+// mounted, and the other container cannot be removed while the container
+// exists.
+// If the container has joined a pod, it can only mount the root filesystems of
+// containers in the same pod.
+func WithRootfsMountFrom(provider *Container, dest string, readOnly bool) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return ErrCtrFinalized
+		}
+
+		if !provider.valid {
+			return ErrCtrRemoved
+		}
+
+		if provider.ID() == ctr.ID() {
+			return errors.Wrapf(ErrInvalidArg, "must specify another container")
+		}
+
+		if ctr.config.Pod != "" && provider.config.Pod != ctr.config.Pod {
+			return errors.Wrapf(ErrInvalidArg, "container has joined pod %s and dependency container %s is not a member of the pod", ctr.config.Pod, provider.ID())
+		}
+
+		if !filepath.IsAbs(dest) {
+			return errors.Wrapf(ErrInvalidArg, "mount destination %q must be an absolute path", dest)
+		}
+
+		for _, m := range ctr.config.RootfsMounts {
+			if m.Destination == filepath.Clean(dest) {
+				return errors.Wrapf(ErrInvalidArg, "a root filesystem is already mounted at %q", dest)
+			}
+		}
+
+		ctr.config.RootfsMounts = append(ctr.config.RootfsMounts, RootfsMount{
+			Container:   provider.ID(),
+			Destination: filepath.Clean(dest),
+			ReadOnly:    readOnly,
+		})
+
+		return nil
+	}
+}
+
+// WithNamedVolumes mounts the given named volumes into the container. Volumes
+// that do not exist are created when the container is mounted.
+func WithNamedVolumes(volumes []*ContainerNamedVolume) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return ErrCtrFinalized
+		}
+
+		dests := make(map[string]bool)
+		for _, m := range ctr.config.NamedVolumes {
+			dests[m.Dest] = true
+		}
+		for _, vol := range volumes {
+			if !volumeNameRegex.MatchString(vol.Name) {
+				return errors.Wrapf(ErrInvalidArg, "invalid volume name %q", vol.Name)
+			}
+			if !filepath.IsAbs(vol.Dest) {
+				return errors.Wrapf(ErrInvalidArg, "mount destination %q must be an absolute path", vol.Dest)
+			}
+			dest := filepath.Clean(vol.Dest)
+			if dests[dest] {
+				return errors.Wrapf(ErrInvalidArg, "a volume is already mounted at %q", dest)
+			}
+			dests[dest] = true
+
+			ctr.config.NamedVolumes = append(ctr.config.NamedVolumes, &ContainerNamedVolume{
+				Name:    vol.Name,
+				Dest:    dest,
+				Options: append([]string{}, vol.Options...),
+			})
+		}
+
+		return nil
+	}
+}
+
 // WithNetNS indicates that the container should be given a new network
 // namespace with a minimal configuration.
 // An optional array of port mappings can be provided.
@@ -894,6 +1531,48 @@ func WithCgroupParent(parent string) CtrCreateOption {
 	}
 }
 
+// WithCgroupMode sets whether libpod will create cgroups for the new container
+// and its conmon process, or adopt the container's cgroup parent as-is.
+// Whether the mode is usable with the host's cgroup setup is checked when the
+// container is created.
+func WithCgroupMode(mode CgroupMode) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return ErrCtrFinalized
+		}
+
+		switch mode {
+		case CgroupModeEnabled, CgroupModeDisabled, CgroupModeNoConmon, CgroupModeSplit:
+		default:
+			return errors.Wrapf(ErrInvalidArg, "invalid cgroup mode %q", mode)
+		}
+
+		ctr.config.CgroupMode = mode
+
+		return nil
+	}
+}
+
+// WithConmonCgroup sets the cgroup conmon is placed in, so its resource usage
+// is not accounted against the container. With the cgroupfs cgroup manager
+// this is a cgroup path, which is created if it does not exist. With the
+// systemd cgroup manager it is a slice.
+func WithConmonCgroup(cgroup string) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return ErrCtrFinalized
+		}
+
+		if cgroup == "" {
+			return errors.Wrapf(ErrInvalidArg, "conmon cgroup must not be empty")
+		}
+
+		ctr.config.ConmonCgroup = cgroup
+
+		return nil
+	}
+}
+
 // WithDNSSearch sets the additional search domains of a container.
 func WithDNSSearch(searchDomains []string) CtrCreateOption {
 	return func(ctr *Container) error {
@@ -935,6 +1614,41 @@ func WithDNSOption(dnsOptions []string) CtrCreateOption {
 	}
 }
 
+// WithDNSCache has the container resolve names through a caching DNS resolver
+// run by libpod in the container's network namespace, instead of querying the
+// nameservers directly. Containers joining the network namespace of another
+// container share that container's resolver.
+// If the resolver cannot be started, the nameservers are used directly.
+func WithDNSCache() CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return ErrCtrFinalized
+		}
+		ctr.config.DNSCache = true
+		return nil
+	}
+}
+
+// WithTimezone sets the timezone of the container.
+// The timezone must be the name of a zone in the host's zoneinfo database
+// (e.g. "America/New_York"), or "local" to use the host's current timezone.
+func WithTimezone(timezone string) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return ErrCtrFinalized
+		}
+
+		if timezone != timezoneLocal {
+			if _, err := zoneinfoPath(timezone); err != nil {
+				return err
+			}
+		}
+
+		ctr.config.Timezone = timezone
+		return nil
+	}
+}
+
 // WithHosts sets additional host:IP for the hosts file.
 func WithHosts(hosts []string) CtrCreateOption {
 	return func(ctr *Container) error {
@@ -946,6 +1660,153 @@ func WithHosts(hosts []string) CtrCreateOption {
 	}
 }
 
+// WithLogFullPolicy sets what libpod will do when the filesystem holding the
+// container's log is full.
+func WithLogFullPolicy(policy LogFullPolicy) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return ErrCtrFinalized
+		}
+
+		switch policy {
+		case LogFullPolicyBlock, LogFullPolicyDrop, LogFullPolicyStop:
+		default:
+			return errors.Wrapf(ErrInvalidArg, "invalid log full policy %q", policy)
+		}
+
+		ctr.config.LogFullPolicy = policy
+		return nil
+	}
+}
+
+// WithLogDriver sets where the container's output is logged, LogDriverFile or
+// LogDriverJournald. With LogDriverJournald, the output conmon writes to the
+// container's log is sent to the journal whenever the container's state is
+// synchronized; the log file is kept, and can be bounded with
+// WithLogRotation().
+func WithLogDriver(driver string) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return ErrCtrFinalized
+		}
+
+		switch driver {
+		case LogDriverFile, LogDriverJournald:
+		default:
+			return errors.Wrapf(ErrInvalidArg, "invalid log driver %q", driver)
+		}
+
+		ctr.config.LogDriver = driver
+		return nil
+	}
+}
+
+// WithMountSourcePolicy enables monitoring of the sources of the container's
+// bind mounts, and sets what libpod will do when one disappears while the
+// container is running.
+// Sources are checked whenever the container's state is synchronized, so this
+// is not enabled by default.
+func WithMountSourcePolicy(policy MountSourcePolicy) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return ErrCtrFinalized
+		}
+
+		switch policy {
+		case MountSourcePolicyLog, MountSourcePolicyEvent, MountSourcePolicyStop:
+		default:
+			return errors.Wrapf(ErrInvalidArg, "invalid mount source policy %q", policy)
+		}
+
+		ctr.config.MountSourcePolicy = policy
+		return nil
+	}
+}
+
+// WithMachineIDMode sets how the container's /etc/machine-id is provided.
+func WithMachineIDMode(mode MachineIDMode) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return ErrCtrFinalized
+		}
+
+		switch mode {
+		case MachineIDModeNone, MachineIDModeHost, MachineIDModeGenerate, MachineIDModeContainerID:
+		default:
+			return errors.Wrapf(ErrInvalidArg, "invalid machine ID mode %q", mode)
+		}
+
+		ctr.config.MachineIDMode = mode
+		return nil
+	}
+}
+
+// WithLogRotation rotates the container's log to <log>.1 when it reaches
+// sizeMax bytes, shifting older rotated logs to <log>.2 and so on, and keeps
+// at most maxFiles rotated logs. With a maxFiles of 0, the log is emptied
+// instead. A sizeMax of 0 disables rotation.
+// The size of the log is checked whenever the container's state is synced.
+func WithLogRotation(sizeMax int64, maxFiles int) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return ErrCtrFinalized
+		}
+
+		if sizeMax < 0 {
+			return errors.Wrapf(ErrInvalidArg, "log size limit must not be negative")
+		}
+		if maxFiles < 0 {
+			return errors.Wrapf(ErrInvalidArg, "number of rotated logs must not be negative")
+		}
+
+		ctr.config.LogSizeMax = sizeMax
+		ctr.config.LogMaxFiles = maxFiles
+		return nil
+	}
+}
+
+// WithSplitLogStreams has the container's stdout and stderr written to
+// separate logs, stdout.log and stderr.log in the container's static directory,
+// in addition to its log. Each stream's log is rotated independently when it
+// reaches maxSize bytes; a maxSize of 0 disables rotation.
+func WithSplitLogStreams(maxSize int64) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return ErrCtrFinalized
+		}
+
+		if maxSize < 0 {
+			return errors.Wrapf(ErrInvalidArg, "log stream size limit must not be negative")
+		}
+
+		ctr.config.SplitLogStreams = true
+		ctr.config.LogStreamMaxSize = maxSize
+		return nil
+	}
+}
+
+// WithLogRateLimit limits how much of the container's output is written to the
+// logs of its streams, to lines and bytes per second; 0 leaves either
+// unlimited. Output beyond the limit is dropped, and replaced by a line noting
+// how many lines were suppressed once the next second's output is written.
+// It only applies if the container's log streams are split with
+// WithSplitLogStreams().
+func WithLogRateLimit(lines, bytes int64) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return ErrCtrFinalized
+		}
+
+		if lines < 0 || bytes < 0 {
+			return errors.Wrapf(ErrInvalidArg, "log rate limits must not be negative")
+		}
+
+		ctr.config.LogRateLimitLines = lines
+		ctr.config.LogRateLimitBytes = bytes
+		return nil
+	}
+}
+
 // WithConmonPidFile specifies the path to the file that receives the pid of
 // conmon.
 func WithConmonPidFile(path string) CtrCreateOption {
@@ -1101,6 +1962,62 @@ func withIsInfra() CtrCreateOption {
 	}
 }
 
+// withMigratedConfig sets the configuration of the container to that of a
+// container migrated from another host, keeping its ID and name. Paths, labels
+// and defaults specific to the other host are cleared, to be set up again for
+// this host.
+func withMigratedConfig(config *ContainerConfig) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return ErrCtrFinalized
+		}
+
+		migrated := *config
+		migrated.Spec = ctr.config.Spec
+		migrated.Namespace = ctr.config.Namespace
+		migrated.StorageDriver = ""
+		migrated.StaticDir = ""
+		migrated.ProcessLabel = ""
+		migrated.MountLabel = ""
+		migrated.ConmonPidFile = ""
+
+		// The SHM directory and log created for the container on the
+		// other host are created again by newContainer
+		if isMigratedShmDir(config) {
+			migrated.ShmDir = ""
+			migrated.Mounts = nil
+			for _, mount := range config.Mounts {
+				if mount != config.ShmDir {
+					migrated.Mounts = append(migrated.Mounts, mount)
+				}
+			}
+		}
+		if config.LogPath == filepath.Join(config.StaticDir, "ctr.log") {
+			migrated.LogPath = ""
+		}
+
+		if config.CgroupParent == CgroupfsDefaultCgroupParent || config.CgroupParent == SystemdDefaultCgroupParent {
+			migrated.CgroupParent = ""
+		}
+
+		ctr.config = &migrated
+		return nil
+	}
+}
+
+// isMigratedShmDir returns whether the SHM directory of a container is the one
+// newContainer created for it
+func isMigratedShmDir(config *ContainerConfig) bool {
+	if config.ShmDir == "" {
+		return false
+	}
+	if config.ShmDir == filepath.Join(config.StaticDir, "shm") {
+		return true
+	}
+	// Containers in user namespaces have it in their UserNSRoot
+	return strings.HasSuffix(config.ShmDir, filepath.Join("containers-root", config.ID, "shm"))
+}
+
 // Pod Creation Options
 
 // WithPodName sets the name of the pod.
@@ -1295,3 +2212,70 @@ func WithInfraContainer() PodCreateOption {
 		return nil
 	}
 }
+
+// Volume Creation Options
+
+// WithVolumeName sets the name of the volume.
+func WithVolumeName(name string) VolumeCreateOption {
+	return func(volume *Volume) error {
+		if volume.valid {
+			return ErrVolumeFinalized
+		}
+
+		if !volumeNameRegex.MatchString(name) {
+			return errors.Wrapf(ErrInvalidArg, "volume name must match regex [a-zA-Z0-9][a-zA-Z0-9_.-]*")
+		}
+
+		volume.config.Name = name
+
+		return nil
+	}
+}
+
+// WithVolumeLabels sets the labels of the volume.
+func WithVolumeLabels(labels map[string]string) VolumeCreateOption {
+	return func(volume *Volume) error {
+		if volume.valid {
+			return ErrVolumeFinalized
+		}
+
+		volume.config.Labels = make(map[string]string)
+		for key, value := range labels {
+			volume.config.Labels[key] = value
+		}
+
+		return nil
+	}
+}
+
+// WithVolumeOptions sets the options of the volume. The options "type",
+// "device" and "o" have a filesystem mounted as the volume's data while
+// containers use it, like the options of Docker's local volume driver.
+func WithVolumeOptions(options map[string]string) VolumeCreateOption {
+	return func(volume *Volume) error {
+		if volume.valid {
+			return ErrVolumeFinalized
+		}
+
+		for key := range options {
+			switch key {
+			case "type", "device", "o":
+			default:
+				return errors.Wrapf(ErrInvalidArg, "unknown volume option %q", key)
+			}
+		}
+		if (options["type"] == "") != (options["device"] == "") {
+			return errors.Wrapf(ErrInvalidArg, "volume options type and device must be given together")
+		}
+		if options["o"] != "" && options["type"] == "" {
+			return errors.Wrapf(ErrInvalidArg, "volume option o requires type and device")
+		}
+
+		volume.config.Options = make(map[string]string)
+		for key, value := range options {
+			volume.config.Options[key] = value
+		}
+
+		return nil
+	}
+}
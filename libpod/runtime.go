@@ -1,6 +1,7 @@
 package libpod
 
 import (
+	"context"
 	"io/ioutil"
 	"os"
 	"os/exec"
@@ -62,6 +63,49 @@ const (
 	DefaultInfraImage = "k8s.gcr.io/pause:3.1"
 	// DefaultInfraCommand to be run in an infra container
 	DefaultInfraCommand = "/pause"
+	// DefaultHostPortRangeStart is the first host port allocated to port
+	// mappings that request any host port
+	DefaultHostPortRangeStart = 30000
+	// DefaultHostPortRangeEnd is the last host port allocated to port
+	// mappings that request any host port
+	DefaultHostPortRangeEnd = 32767
+)
+
+// StateDivergencePolicy determines what libpod does when the OCI runtime
+// reports a container in a state libpod did not expect it to be in, e.g.
+// running when libpod last saw it stopped
+type StateDivergencePolicy string
+
+const (
+	// StateDivergencePolicyAdopt silently adopts the OCI runtime's view
+	// of the container. This is the default.
+	StateDivergencePolicyAdopt StateDivergencePolicy = "adopt"
+	// StateDivergencePolicyLog adopts the OCI runtime's view and logs a
+	// warning
+	StateDivergencePolicyLog StateDivergencePolicy = "log"
+	// StateDivergencePolicyEvent adopts the OCI runtime's view and logs a
+	// structured event naming the container and both states
+	StateDivergencePolicyEvent StateDivergencePolicy = "event"
+	// StateDivergencePolicyRefuse keeps libpod's view of the container
+	// until the divergence is accepted with AcceptDivergentState()
+	StateDivergencePolicyRefuse StateDivergencePolicy = "refuse"
+)
+
+// ResourceCheckPolicy determines whether libpod checks that the host can
+// satisfy a container's memory and CPU resources before creating it in the OCI
+// runtime
+type ResourceCheckPolicy string
+
+const (
+	// ResourceCheckPolicyIgnore does not check containers' resources when
+	// they are created in the OCI runtime. This is the default.
+	ResourceCheckPolicyIgnore ResourceCheckPolicy = "ignore"
+	// ResourceCheckPolicyWarn logs a warning for each of a container's
+	// resources the host cannot satisfy
+	ResourceCheckPolicyWarn ResourceCheckPolicy = "warn"
+	// ResourceCheckPolicyRefuse refuses to create containers whose
+	// resources the host cannot satisfy
+	ResourceCheckPolicyRefuse ResourceCheckPolicy = "refuse"
 )
 
 // A RuntimeOption is a functional option which alters the Runtime created by
@@ -84,6 +128,20 @@ type Runtime struct {
 	lock            sync.RWMutex
 	imageRuntime    *image.Runtime
 	firewallBackend firewall.FirewallBackend
+	// mountSlots holds a value for every container storage mount in
+	// progress, if their number is limited
+	mountSlots chan struct{}
+	// secretStore resolves the secrets injected into containers'
+	// environments
+	secretStore SecretStore
+	// hostPorts tracks the host ports claimed by containers
+	hostPorts hostPortRegistry
+	// lowerLayerSizes caches the total sizes of the immutable layers of
+	// containers' root filesystems, by the ID of the topmost of them
+	lowerLayerSizes     map[string]int64
+	lowerLayerSizesLock sync.Mutex
+	// healthChecks tracks the health checks run by this runtime
+	healthChecks healthCheckRegistry
 }
 
 // RuntimeConfig contains configuration options used to set up the runtime
@@ -118,6 +176,12 @@ type RuntimeConfig struct {
 	// ConmonEnvVars are environment variables to pass to the Conmon binary
 	// when it is launched
 	ConmonEnvVars []string `toml:"conmon_env_vars"`
+	// ConmonCgroup is the cgroup conmon will be placed in for containers
+	// that do not set one. With the cgroupfs manager this is a cgroup
+	// path, with the systemd manager a slice.
+	// If empty, conmon is placed in a cgroup under the container's cgroup
+	// parent.
+	ConmonCgroup string `toml:"conmon_cgroup,omitempty"`
 	// CGroupManager is the CGroup Manager to use
 	// Valid values are "cgroupfs" and "systemd"
 	CgroupManager string `toml:"cgroup_manager"`
@@ -128,8 +192,37 @@ type RuntimeConfig struct {
 	// container files
 	// Must be stored in a tmpfs
 	TmpDir string `toml:"tmp_dir"`
+	// VolumePath is the path to a persistent directory holding the data
+	// of named volumes
+	VolumePath string `toml:"volume_path"`
 	// MaxLogSize is the maximum size of container logfiles
 	MaxLogSize int64 `toml:"max_log_size,omitempty"`
+	// MaxConcurrentMounts is the maximum number of containers whose
+	// storage this runtime will mount at the same time
+	// 0 is unlimited
+	MaxConcurrentMounts uint `toml:"max_concurrent_mounts,omitempty"`
+	// StateDivergencePolicy is what libpod does when the OCI runtime
+	// reports a container in a state libpod did not expect
+	// If empty, StateDivergencePolicyAdopt is used
+	StateDivergencePolicy StateDivergencePolicy `toml:"state_divergence_policy,omitempty"`
+	// ResourceCheckPolicy is whether libpod checks that the host can
+	// satisfy a container's memory and CPU resources before creating it in
+	// the OCI runtime
+	// If empty, ResourceCheckPolicyIgnore is used
+	ResourceCheckPolicy ResourceCheckPolicy `toml:"resource_check_policy,omitempty"`
+	// ExitFileFormat is the format conmon writes containers' exit codes in
+	// If empty, ExitFileFormatAuto is used
+	ExitFileFormat ExitFileFormat `toml:"exit_file_format,omitempty"`
+	// ConmonLogLevel is the level conmon logs its own messages at
+	// If empty, libpod's log level is used
+	ConmonLogLevel string `toml:"conmon_log_level,omitempty"`
+	// ConmonLogToFile is whether conmon logs its own messages to the
+	// conmon.log artifact of each container instead of stderr or syslog
+	ConmonLogToFile bool `toml:"conmon_log_to_file,omitempty"`
+	// EnvSecretsDir is the directory holding the secrets injected into
+	// containers' environments, one file per secret, if no SecretStore
+	// was given
+	EnvSecretsDir string `toml:"env_secrets_dir,omitempty"`
 	// NoPivotRoot sets whether to set no-pivot-root in the OCI runtime
 	NoPivotRoot bool `toml:"no_pivot_root"`
 	// CNIConfigDir sets the directory where CNI configuration files are
@@ -171,6 +264,11 @@ type RuntimeConfig struct {
 	// However, this can cause significant memory usage if a container has
 	// many ports forwarded to it. Disabling this can save memory.
 	EnablePortReservation bool `toml:"enable_port_reservation"`
+	// HostPortRangeStart and HostPortRangeEnd are the range of host ports,
+	// inclusive, allocated to port mappings that request any host port
+	// with host port 0
+	HostPortRangeStart int `toml:"host_port_range_start,omitempty"`
+	HostPortRangeEnd   int `toml:"host_port_range_end,omitempty"`
 	// EnableLabeling indicates wether libpod will support container labeling
 	EnableLabeling bool `toml:"label"`
 }
@@ -206,6 +304,7 @@ var (
 		HooksDir:              []string{hooks.DefaultDir, hooks.OverrideDir},
 		StaticDir:             filepath.Join(storage.DefaultStoreOptions.GraphRoot, "libpod"),
 		TmpDir:                "",
+		VolumePath:            filepath.Join(storage.DefaultStoreOptions.GraphRoot, "volumes"),
 		MaxLogSize:            -1,
 		NoPivotRoot:           false,
 		CNIConfigDir:          "/etc/cni/net.d/",
@@ -213,6 +312,8 @@ var (
 		InfraCommand:          DefaultInfraCommand,
 		InfraImage:            DefaultInfraImage,
 		EnablePortReservation: true,
+		HostPortRangeStart:    DefaultHostPortRangeStart,
+		HostPortRangeEnd:      DefaultHostPortRangeEnd,
 		EnableLabeling:        true,
 	}
 )
@@ -462,12 +563,36 @@ func makeRuntime(runtime *Runtime) (err error) {
 		}
 	}
 
+	switch runtime.config.ExitFileFormat {
+	case "", ExitFileFormatAuto, ExitFileFormatPlain, ExitFileFormatJSON:
+	default:
+		return errors.Wrapf(ErrInvalidArg, "invalid exit file format %q", runtime.config.ExitFileFormat)
+	}
+
+	if runtime.config.HostPortRangeStart == 0 && runtime.config.HostPortRangeEnd == 0 {
+		runtime.config.HostPortRangeStart = DefaultHostPortRangeStart
+		runtime.config.HostPortRangeEnd = DefaultHostPortRangeEnd
+	}
+	if err := validateHostPortRange(runtime.config.HostPortRangeStart, runtime.config.HostPortRangeEnd); err != nil {
+		return err
+	}
+
+	if runtime.config.ConmonLogLevel != "" {
+		if _, err := logrus.ParseLevel(runtime.config.ConmonLogLevel); err != nil {
+			return errors.Wrapf(ErrInvalidArg, "invalid conmon log level %q", runtime.config.ConmonLogLevel)
+		}
+	}
+
 	// Make an OCI runtime to perform container operations
 	ociRuntime, err := newOCIRuntime("runc", runtime.ociRuntimePath,
 		runtime.conmonPath, runtime.config.ConmonEnvVars,
 		runtime.config.CgroupManager, runtime.config.TmpDir,
 		runtime.config.MaxLogSize, runtime.config.NoPivotRoot,
-		runtime.config.EnablePortReservation)
+		runtime.config.EnablePortReservation, runtime.config.ExitFileFormat,
+		conmonLogConfig{
+			level:  runtime.config.ConmonLogLevel,
+			toFile: runtime.config.ConmonLogToFile,
+		})
 	if err != nil {
 		return err
 	}
@@ -482,6 +607,35 @@ func makeRuntime(runtime *Runtime) (err error) {
 		}
 	}
 
+	// Make the volumes directory if it does not exist
+	if err := os.MkdirAll(runtime.config.VolumePath, 0755); err != nil {
+		// The directory is allowed to exist
+		if !os.IsExist(err) {
+			return errors.Wrapf(err, "error creating runtime volumes directory %s",
+				runtime.config.VolumePath)
+		}
+	}
+
+	switch runtime.config.StateDivergencePolicy {
+	case "", StateDivergencePolicyAdopt, StateDivergencePolicyLog, StateDivergencePolicyEvent, StateDivergencePolicyRefuse:
+	default:
+		return errors.Wrapf(ErrInvalidArg, "invalid state divergence policy %q", runtime.config.StateDivergencePolicy)
+	}
+
+	switch runtime.config.ResourceCheckPolicy {
+	case "", ResourceCheckPolicyIgnore, ResourceCheckPolicyWarn, ResourceCheckPolicyRefuse:
+	default:
+		return errors.Wrapf(ErrInvalidArg, "invalid resource check policy %q", runtime.config.ResourceCheckPolicy)
+	}
+
+	if runtime.secretStore == nil && runtime.config.EnvSecretsDir != "" {
+		runtime.secretStore = NewFileSecretStore(runtime.config.EnvSecretsDir)
+	}
+
+	if runtime.config.MaxConcurrentMounts > 0 {
+		runtime.mountSlots = make(chan struct{}, runtime.config.MaxConcurrentMounts)
+	}
+
 	// Make a directory to hold container lockfiles
 	lockDir := filepath.Join(runtime.config.TmpDir, "lock")
 	if err := os.MkdirAll(lockDir, 0755); err != nil {
@@ -689,6 +843,8 @@ func (r *Runtime) refresh(alivePath string) error {
 		ctr.lock.Lock()
 		if err := ctr.refresh(); err != nil {
 			logrus.Errorf("Error refreshing container %s: %v", ctr.ID(), err)
+		} else if ctr.state.State == ContainerStateRunning {
+			r.startHealthCheck(ctr)
 		}
 		ctr.lock.Unlock()
 	}
@@ -774,3 +930,34 @@ func (r *Runtime) generateName() (string, error) {
 func (r *Runtime) ImageRuntime() *image.Runtime {
 	return r.imageRuntime
 }
+
+// acquireMountSlot waits until fewer than the maximum number of concurrent
+// container storage mounts are in progress, or ctx is canceled
+// If it returns nil, releaseMountSlot must be called once the mount completes
+func (r *Runtime) acquireMountSlot(ctx context.Context) error {
+	if r.mountSlots == nil {
+		return nil
+	}
+
+	select {
+	case r.mountSlots <- struct{}{}:
+		return nil
+	default:
+	}
+
+	logrus.Debugf("Maximum of %d concurrent mounts reached, waiting", cap(r.mountSlots))
+	select {
+	case r.mountSlots <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return errors.Wrapf(ctx.Err(), "error waiting to mount container storage")
+	}
+}
+
+// releaseMountSlot allows another container storage mount to proceed
+func (r *Runtime) releaseMountSlot() {
+	if r.mountSlots == nil {
+		return
+	}
+	<-r.mountSlots
+}
@@ -2,12 +2,14 @@ package libpod
 
 import (
 	"context"
+	"io"
 	"os"
 	"path"
 	"path/filepath"
 	"strings"
 	"time"
 
+	"github.com/containers/libpod/pkg/rootless"
 	"github.com/containers/storage"
 	"github.com/containers/storage/pkg/stringid"
 	spec "github.com/opencontainers/runtime-spec/specs-go"
@@ -41,6 +43,20 @@ func (r *Runtime) NewContainer(ctx context.Context, rSpec *spec.Spec, options ..
 	return r.newContainer(ctx, rSpec, options...)
 }
 
+// ImportMigration creates a container from an archive written by
+// Container.ExportForMigration on another host, and restores it.
+// The container keeps its ID and name, and its image must be present on this
+// host. The checkpoint is verified to be restorable on this host before the
+// container is created; if the container cannot be restored, it is removed.
+func (r *Runtime) ImportMigration(ctx context.Context, input io.Reader) (*Container, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if !r.valid {
+		return nil, ErrRuntimeStopped
+	}
+	return r.importMigration(ctx, input)
+}
+
 func (r *Runtime) newContainer(ctx context.Context, rSpec *spec.Spec, options ...CtrCreateOption) (c *Container, err error) {
 	if rSpec == nil {
 		return nil, errors.Wrapf(ErrInvalidArg, "must provide a valid runtime spec to create container")
@@ -60,15 +76,6 @@ func (r *Runtime) newContainer(ctx context.Context, rSpec *spec.Spec, options ..
 
 	ctr.state.BindMounts = make(map[string]string)
 
-	// Path our lock file will reside at
-	lockPath := filepath.Join(r.lockDir, ctr.config.ID)
-	// Grab a lockfile at the given path
-	lock, err := storage.GetLockfile(lockPath)
-	if err != nil {
-		return nil, errors.Wrapf(err, "error creating lockfile for new container")
-	}
-	ctr.lock = lock
-
 	ctr.config.StopTimeout = CtrRemoveTimeout
 
 	// Set namespace based on current runtime namespace
@@ -84,6 +91,28 @@ func (r *Runtime) newContainer(ctx context.Context, rSpec *spec.Spec, options ..
 		}
 	}
 
+	existing, err := r.existingContainer(ctr)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		if ctr.reuseExisting {
+			logrus.Debugf("Reusing existing container %s with name %s", existing.ID(), existing.Name())
+			return existing, nil
+		}
+		return nil, errors.Wrapf(ErrCtrExists, "cannot create container with name %s as container %s already uses it", existing.Name(), existing.ID())
+	}
+
+	// Path our lock file will reside at
+	// The lock is created after the options run, as they may set the ID
+	lockPath := filepath.Join(r.lockDir, ctr.config.ID)
+	// Grab a lockfile at the given path
+	lock, err := storage.GetLockfile(lockPath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error creating lockfile for new container")
+	}
+	ctr.lock = lock
+
 	ctr.valid = true
 	ctr.state.State = ContainerStateConfigured
 
@@ -142,6 +171,33 @@ func (r *Runtime) newContainer(ctx context.Context, rSpec *spec.Spec, options ..
 		return nil, errors.Wrapf(ErrInvalidArg, "unsupported CGroup manager: %s - cannot validate cgroup parent", r.config.CgroupManager)
 	}
 
+	if err := r.validateCgroupMode(ctr); err != nil {
+		return nil, err
+	}
+
+	if err := validateShmHost(ctr); err != nil {
+		return nil, err
+	}
+
+	// Reject dependency cycles now, before they make containers impossible
+	// to start
+	if len(ctr.Dependencies()) > 0 {
+		allCtrs, err := r.state.AllContainers()
+		if err != nil {
+			return nil, errors.Wrapf(err, "error retrieving containers to check dependencies of container %s", ctr.ID())
+		}
+		if err := validateContainerGraph(append(allCtrs, ctr)); err != nil {
+			return nil, err
+		}
+	}
+
+	if ctr.config.ConmonCgroup == "" {
+		ctr.config.ConmonCgroup = r.config.ConmonCgroup
+	}
+	if err := r.validateConmonCgroup(ctr); err != nil {
+		return nil, err
+	}
+
 	// Set up storage for the container
 	if err := ctr.setupStorage(ctx); err != nil {
 		return nil, err
@@ -189,6 +245,35 @@ func (r *Runtime) newContainer(ctx context.Context, rSpec *spec.Spec, options ..
 	return ctr, nil
 }
 
+// existingContainer returns the container in the state with the same ID or name
+// as the given container being created, or nil if there is none
+func (r *Runtime) existingContainer(ctr *Container) (*Container, error) {
+	exists, err := r.state.HasContainer(ctr.ID())
+	if err != nil {
+		return nil, errors.Wrapf(err, "error checking for existing container with ID %s", ctr.ID())
+	}
+	if exists {
+		return r.state.Container(ctr.ID())
+	}
+
+	if ctr.config.Name == "" {
+		return nil, nil
+	}
+	existing, err := r.state.LookupContainer(ctr.config.Name)
+	if err != nil {
+		// No container matched the name, or it is an ambiguous prefix
+		// of several IDs, so no container uses it; any other error
+		// fails adding the container to the state
+		logrus.Debugf("No existing container named %s: %v", ctr.config.Name, err)
+		return nil, nil
+	}
+	// Lookups also match ID prefixes, which do not conflict
+	if existing.Name() != ctr.config.Name {
+		return nil, nil
+	}
+	return existing, nil
+}
+
 // RemoveContainer removes the given container
 // If force is specified, the container will be stopped first
 // Otherwise, RemoveContainer will return an error if the container is running
@@ -414,6 +499,25 @@ func (r *Runtime) GetContainers(filters ...ContainerFilter) ([]*Container, error
 	return ctrsFiltered, nil
 }
 
+// ValidateDependencyGraph checks the dependencies of all containers in the
+// runtime for cycles. If a cycle is found, the returned error contains the IDs
+// of the containers in it.
+func (r *Runtime) ValidateDependencyGraph() error {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	if !r.valid {
+		return ErrRuntimeStopped
+	}
+
+	ctrs, err := r.state.AllContainers()
+	if err != nil {
+		return err
+	}
+
+	return validateContainerGraph(ctrs)
+}
+
 // GetAllContainers is a helper function for GetContainers
 func (r *Runtime) GetAllContainers() ([]*Container, error) {
 	return r.state.AllContainers()
@@ -462,3 +566,136 @@ func (r *Runtime) GetLatestContainer() (*Container, error) {
 	}
 	return ctrs[lastCreatedIndex], nil
 }
+
+// validateCgroupMode checks that the cgroup mode of a new container can be used
+// with the runtime's cgroup manager and the host's cgroup hierarchy
+func (r *Runtime) validateCgroupMode(ctr *Container) error {
+	if ctr.CgroupMode() != CgroupModeSplit {
+		return nil
+	}
+
+	// The systemd cgroup manager always creates a scope for the container,
+	// so there is nothing to adopt
+	if r.config.CgroupManager != CgroupfsCgroupsManager {
+		return errors.Wrapf(ErrInvalidArg, "cgroup mode %s can only be used with the %s cgroup manager", CgroupModeSplit, CgroupfsCgroupsManager)
+	}
+
+	// We will not create the cgroup parent, so it must already exist
+	exists, err := cgroupExists(ctr.config.CgroupParent)
+	if err != nil {
+		return errors.Wrapf(err, "error checking for cgroup parent %s of container %s", ctr.config.CgroupParent, ctr.ID())
+	}
+	if !exists {
+		return errors.Wrapf(ErrInvalidArg, "cgroup parent %s does not exist and cannot be adopted with cgroup mode %s", ctr.config.CgroupParent, CgroupModeSplit)
+	}
+
+	return nil
+}
+
+// validateShmHost checks that a new container using the host's /dev/shm does
+// not have its /dev/shm supplied in another way
+func validateShmHost(ctr *Container) error {
+	if !ctr.config.UseHostShm {
+		return nil
+	}
+
+	if rootless.IsRootless() {
+		return errors.Wrapf(ErrInvalidArg, "the host's /dev/shm cannot be mounted into rootless containers")
+	}
+	if ctr.config.IPCNsCtr != "" {
+		return errors.Wrapf(ErrInvalidArg, "the host's /dev/shm cannot be mounted into a container that joins the IPC namespace of container %s", ctr.config.IPCNsCtr)
+	}
+	if ctr.config.ShmDir != "" {
+		return errors.Wrapf(ErrInvalidArg, "the host's /dev/shm cannot be mounted into a container with SHM directory %s", ctr.config.ShmDir)
+	}
+
+	return nil
+}
+
+// validateConmonCgroup checks that the conmon cgroup of a new container can be
+// used with the runtime's cgroup manager and the container's cgroup mode
+func (r *Runtime) validateConmonCgroup(ctr *Container) error {
+	if ctr.config.ConmonCgroup == "" {
+		return nil
+	}
+
+	switch mode := ctr.CgroupMode(); mode {
+	case CgroupModeEnabled, CgroupModeSplit:
+	default:
+		return errors.Wrapf(ErrInvalidArg, "conmon cgroup cannot be set with cgroup mode %s", mode)
+	}
+
+	switch r.config.CgroupManager {
+	case CgroupfsCgroupsManager:
+		if !filepath.IsAbs(ctr.config.ConmonCgroup) || filepath.Clean(ctr.config.ConmonCgroup) == "/" {
+			return errors.Wrapf(ErrInvalidArg, "conmon cgroup %q must be an absolute cgroup path when using cgroupfs", ctr.config.ConmonCgroup)
+		}
+		if strings.HasSuffix(path.Base(ctr.config.ConmonCgroup), ".slice") {
+			return errors.Wrapf(ErrInvalidArg, "systemd slice received as conmon cgroup when using cgroupfs")
+		}
+		ctr.config.ConmonCgroup = filepath.Clean(ctr.config.ConmonCgroup)
+	case SystemdCgroupsManager:
+		if len(ctr.config.ConmonCgroup) < 6 || !strings.HasSuffix(path.Base(ctr.config.ConmonCgroup), ".slice") {
+			return errors.Wrapf(ErrInvalidArg, "did not receive systemd slice as conmon cgroup when using systemd to manage cgroups")
+		}
+	}
+
+	return nil
+}
+
+// PruneOrphanedArtifacts removes the artifacts directories of containers that
+// remain in storage but are no longer present in the state, for example after
+// a container was removed uncleanly.
+// It returns the number of bytes freed.
+func (r *Runtime) PruneOrphanedArtifacts() (int64, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if !r.valid {
+		return 0, ErrRuntimeStopped
+	}
+
+	// The state cannot see containers in other namespaces, so we could not
+	// tell their artifacts apart from orphaned ones
+	if r.config.Namespace != "" {
+		return 0, errors.Wrapf(ErrNSMismatch, "cannot prune orphaned artifacts while in namespace %s", r.config.Namespace)
+	}
+
+	storageCtrs, err := r.store.Containers()
+	if err != nil {
+		return 0, errors.Wrapf(err, "error retrieving containers from storage")
+	}
+
+	var freed int64
+	for _, storageCtr := range storageCtrs {
+		exists, err := r.state.HasContainer(storageCtr.ID)
+		if err != nil {
+			return freed, errors.Wrapf(err, "error checking for container %s in state", storageCtr.ID)
+		}
+		if exists {
+			continue
+		}
+
+		dir, err := r.store.ContainerDirectory(storageCtr.ID)
+		if err != nil {
+			logrus.Debugf("Unable to get directory of storage container %s: %v", storageCtr.ID, err)
+			continue
+		}
+		artifacts := filepath.Join(dir, artifactsDir)
+		if _, err := os.Stat(artifacts); err != nil {
+			continue
+		}
+
+		size, err := dirSize(artifacts)
+		if err != nil {
+			logrus.Warnf("Unable to determine size of orphaned artifacts directory %s: %v", artifacts, err)
+		}
+		if err := os.RemoveAll(artifacts); err != nil {
+			return freed, errors.Wrapf(err, "error removing orphaned artifacts directory %s", artifacts)
+		}
+		logrus.Debugf("Removed orphaned artifacts directory %s of container %s", artifacts, storageCtr.ID)
+		freed += size
+	}
+
+	return freed, nil
+}
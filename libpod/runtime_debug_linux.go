@@ -0,0 +1,93 @@
+// +build linux
+
+package libpod
+
+import (
+	"context"
+
+	"github.com/containers/libpod/libpod/image"
+	"github.com/containers/storage/pkg/stringid"
+	"github.com/opencontainers/runtime-tools/generate"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// DebugContainer runs an ephemeral container from the given image that joins
+// the PID, network, IPC and UTS namespaces of the target container, attaches
+// the given streams to it, and removes it once it exits.
+// The debug container keeps its own mount namespace so the tools in its image
+// remain available; the target's root filesystem can be reached through
+// /proc/1/root. The target container is not modified, but must be running.
+// If command is empty, /bin/sh is run.
+// The exit code of the debug container is returned.
+func (r *Runtime) DebugContainer(ctx context.Context, target *Container, imageName string, command []string, streams *AttachStreams) (int32, error) {
+	if !r.valid {
+		return -1, ErrRuntimeStopped
+	}
+
+	state, err := target.State()
+	if err != nil {
+		return -1, err
+	}
+	if state != ContainerStateRunning {
+		return -1, errors.Wrapf(ErrCtrStateInvalid, "container %s must be running to be debugged", target.ID())
+	}
+
+	newImage, err := r.ImageRuntime().New(ctx, imageName, "", "", nil, nil, image.SigningOptions{}, false, false)
+	if err != nil {
+		return -1, errors.Wrapf(err, "error retrieving debug image %s", imageName)
+	}
+	data, err := newImage.Inspect(ctx)
+	if err != nil {
+		return -1, err
+	}
+
+	g, err := generate.New("linux")
+	if err != nil {
+		return -1, err
+	}
+	if len(command) == 0 {
+		command = []string{"/bin/sh"}
+	}
+	g.SetProcessArgs(command)
+
+	options := []CtrCreateOption{
+		WithRootFSFromImage(data.ID, imageName, false),
+		WithName(target.Name() + "-debug-" + stringid.GenerateNonCryptoID()[:IDTruncLength]),
+		WithPIDNSFrom(target),
+		WithNetNSFrom(target),
+		WithIPCNSFrom(target),
+		WithUTSNSFrom(target),
+	}
+	if streams != nil && streams.AttachInput {
+		options = append(options, WithStdin())
+	}
+
+	ctr, err := r.NewContainer(ctx, g.Config, options...)
+	if err != nil {
+		return -1, errors.Wrapf(err, "error creating debug container for container %s", target.ID())
+	}
+	defer func() {
+		if err := r.RemoveContainer(ctx, ctr, true); err != nil {
+			logrus.Errorf("Error removing debug container %s: %v", ctr.ID(), err)
+		}
+	}()
+
+	logrus.Debugf("Created debug container %s for container %s", ctr.ID(), target.ID())
+
+	if streams == nil {
+		if err := ctr.Start(ctx); err != nil {
+			return -1, err
+		}
+	} else {
+		attachChan, err := ctr.StartAndAttach(ctx, streams, "", nil)
+		if err != nil {
+			return -1, err
+		}
+		if err := <-attachChan; err != nil {
+			return -1, errors.Wrapf(err, "error attaching to debug container %s", ctr.ID())
+		}
+	}
+
+	return ctr.Wait()
+}
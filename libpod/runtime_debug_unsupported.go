@@ -0,0 +1,13 @@
+// +build !linux
+
+package libpod
+
+import (
+	"context"
+)
+
+// DebugContainer runs an ephemeral container that shares the namespaces of the
+// target container
+func (r *Runtime) DebugContainer(ctx context.Context, target *Container, imageName string, command []string, streams *AttachStreams) (int32, error) {
+	return -1, ErrOSNotSupported
+}
@@ -105,6 +105,8 @@ func (r *Runtime) RemoveImage(ctx context.Context, img *image.Image, force bool)
 		// reponames and no force is applied, we error out.
 		return "", fmt.Errorf("unable to delete %s (must force) - image is referred to in multiple tags", img.ID())
 	}
+	r.invalidateLowerLayersSize(img.TopLayer())
+
 	err = img.Remove(force)
 	if err != nil && errors.Cause(err) == storage.ErrImageUsedByContainer {
 		if errStorage := r.rmStorageContainers(force, img); errStorage == nil {
@@ -167,3 +169,34 @@ func (r *Runtime) Build(ctx context.Context, options imagebuildah.BuildOptions,
 	_, _, err := imagebuildah.BuildDockerfiles(ctx, r.store, options, dockerfiles...)
 	return err
 }
+
+// cachedLowerLayersSize returns the cached total size of the given layer and
+// all of its parents, if it is cached
+func (r *Runtime) cachedLowerLayersSize(layerID string) (int64, bool) {
+	r.lowerLayerSizesLock.Lock()
+	defer r.lowerLayerSizesLock.Unlock()
+
+	size, ok := r.lowerLayerSizes[layerID]
+	return size, ok
+}
+
+// cacheLowerLayersSize caches the total size of the given layer and all of its
+// parents, which never changes as they are immutable
+func (r *Runtime) cacheLowerLayersSize(layerID string, size int64) {
+	r.lowerLayerSizesLock.Lock()
+	defer r.lowerLayerSizesLock.Unlock()
+
+	if r.lowerLayerSizes == nil {
+		r.lowerLayerSizes = make(map[string]int64)
+	}
+	r.lowerLayerSizes[layerID] = size
+}
+
+// invalidateLowerLayersSize removes the cached size of the given layer, when
+// the image it is the top layer of is removed
+func (r *Runtime) invalidateLowerLayersSize(layerID string) {
+	r.lowerLayerSizesLock.Lock()
+	defer r.lowerLayerSizesLock.Unlock()
+
+	delete(r.lowerLayerSizes, layerID)
+}
@@ -0,0 +1,261 @@
+// +build linux
+
+package libpod
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/containers/libpod/libpod/image"
+	"github.com/containers/libpod/pkg/rootless"
+	"github.com/ghodss/yaml"
+	spec "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/opencontainers/runtime-tools/generate"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"k8s.io/api/core/v1"
+)
+
+// PlayKubeYAML creates and starts a pod running the containers of the
+// Kubernetes pod read as YAML from r.
+// Like in Kubernetes, the containers share the network, IPC, and UTS
+// namespaces of the pod, and its PID namespace if shareProcessNamespace is
+// set, unless the pod uses the host's namespaces. Containers are started in
+// the order they are listed.
+// Fields that cannot be converted are logged and returned; the pod is created
+// without them.
+func (r *Runtime) PlayKubeYAML(ctx context.Context, reader io.Reader) (*Pod, []string, error) {
+	if !r.valid {
+		return nil, nil, ErrRuntimeStopped
+	}
+
+	data, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "error reading Kubernetes YAML")
+	}
+	kubePod := new(v1.Pod)
+	if err := yaml.Unmarshal(data, kubePod); err != nil {
+		return nil, nil, errors.Wrapf(err, "error parsing Kubernetes YAML")
+	}
+	if kubePod.Kind != "Pod" {
+		return nil, nil, errors.Wrapf(ErrInvalidArg, "Kubernetes YAML describes a %q, only pods are supported", kubePod.Kind)
+	}
+	if len(kubePod.Spec.Containers) == 0 {
+		return nil, nil, errors.Wrapf(ErrInvalidArg, "Kubernetes pod %s has no containers", kubePod.Name)
+	}
+
+	unsupported := kubeUnsupportedFields(kubePod)
+	for _, field := range unsupported {
+		logrus.Warnf("Ignoring unsupported field %s of Kubernetes pod %s", field, kubePod.Name)
+	}
+
+	podOptions := []PodCreateOption{WithInfraContainer(), WithPodUTS()}
+	if kubePod.Name != "" {
+		podOptions = append(podOptions, WithPodName(kubePod.Name))
+	}
+	if len(kubePod.Labels) > 0 {
+		podOptions = append(podOptions, WithPodLabels(kubePod.Labels))
+	}
+	if !kubePod.Spec.HostNetwork {
+		podOptions = append(podOptions, WithPodNet())
+	}
+	if !kubePod.Spec.HostIPC {
+		podOptions = append(podOptions, WithPodIPC())
+	}
+	if !kubePod.Spec.HostPID && kubePod.Spec.ShareProcessNamespace != nil && *kubePod.Spec.ShareProcessNamespace {
+		podOptions = append(podOptions, WithPodPID())
+	}
+
+	pod, err := r.NewPod(ctx, podOptions...)
+	if err != nil {
+		return nil, unsupported, err
+	}
+
+	ctrs := make([]*Container, 0, len(kubePod.Spec.Containers))
+	for _, kubeCtr := range kubePod.Spec.Containers {
+		ctr, err := r.createKubeContainer(ctx, pod, kubePod, kubeCtr)
+		if err == nil {
+			ctrs = append(ctrs, ctr)
+			continue
+		}
+		if err2 := r.RemovePod(ctx, pod, true, true); err2 != nil {
+			logrus.Errorf("Error removing pod %s after failing to create its containers: %v", pod.ID(), err2)
+		}
+		return nil, unsupported, errors.Wrapf(err, "error creating container %s of Kubernetes pod %s", kubeCtr.Name, kubePod.Name)
+	}
+
+	for _, ctr := range ctrs {
+		if err := ctr.Start(ctx); err != nil {
+			return pod, unsupported, errors.Wrapf(err, "error starting container %s of pod %s", ctr.Name(), pod.Name())
+		}
+	}
+
+	return pod, unsupported, nil
+}
+
+// createKubeContainer creates a container in pod from a container of a
+// Kubernetes pod
+func (r *Runtime) createKubeContainer(ctx context.Context, pod *Pod, kubePod *v1.Pod, kubeCtr v1.Container) (*Container, error) {
+	newImage, err := r.ImageRuntime().New(ctx, kubeCtr.Image, "", "", nil, nil, image.SigningOptions{}, false, false)
+	if err != nil {
+		return nil, err
+	}
+	imageData, err := newImage.Inspect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	imageName := kubeCtr.Image
+	if names := newImage.Names(); len(names) > 0 {
+		imageName = names[0]
+	}
+
+	g, err := generate.New("linux")
+	if err != nil {
+		return nil, err
+	}
+	if rootless.IsRootless() {
+		g.RemoveMount("/dev/pts")
+		g.AddMount(spec.Mount{
+			Destination: "/dev/pts",
+			Type:        "devpts",
+			Source:      "devpts",
+			Options:     []string{"private", "nosuid", "noexec", "newinstance", "ptmxmode=0666", "mode=0620"},
+		})
+	}
+
+	options := []CtrCreateOption{
+		r.WithPod(pod),
+		WithRootFSFromImage(imageData.ID, imageName, false),
+		WithName(kubeCtr.Name),
+	}
+	if len(kubePod.Labels) > 0 {
+		options = append(options, WithLabels(kubePod.Labels))
+	}
+
+	// Kubernetes' command replaces the image's entrypoint, and its args
+	// the image's command
+	entrypoint := kubeCtr.Command
+	command := kubeCtr.Args
+	if imageData.ContainerConfig != nil {
+		if len(entrypoint) == 0 {
+			entrypoint = imageData.ContainerConfig.Entrypoint
+			if len(command) == 0 {
+				command = imageData.ContainerConfig.Cmd
+			}
+		}
+		g.ClearProcessEnv()
+		for _, env := range imageData.ContainerConfig.Env {
+			split := strings.SplitN(env, "=", 2)
+			if len(split) == 2 {
+				g.AddProcessEnv(split[0], split[1])
+			}
+		}
+		if imageData.ContainerConfig.WorkingDir != "" {
+			g.SetProcessCwd(imageData.ContainerConfig.WorkingDir)
+		}
+		if imageData.ContainerConfig.User != "" {
+			options = append(options, WithUser(imageData.ContainerConfig.User))
+		}
+	}
+	args := append(append([]string{}, entrypoint...), command...)
+	if len(args) == 0 {
+		return nil, errors.Wrapf(ErrInvalidArg, "no command specified for container %s", kubeCtr.Name)
+	}
+	g.SetProcessArgs(args)
+	options = append(options, WithEntrypoint(entrypoint), WithCommand(command))
+
+	for _, env := range kubeCtr.Env {
+		if env.ValueFrom == nil {
+			g.AddProcessEnv(env.Name, env.Value)
+		}
+	}
+	if kubeCtr.WorkingDir != "" {
+		g.SetProcessCwd(kubeCtr.WorkingDir)
+	}
+	g.SetProcessTerminal(kubeCtr.TTY)
+	if kubeCtr.Stdin {
+		options = append(options, WithStdin())
+	}
+
+	if limit, ok := kubeCtr.Resources.Limits[v1.ResourceMemory]; ok {
+		g.SetLinuxResourcesMemoryLimit(limit.Value())
+	}
+	if limit, ok := kubeCtr.Resources.Limits[v1.ResourceCPU]; ok {
+		period := uint64(100000)
+		g.SetLinuxResourcesCPUPeriod(period)
+		g.SetLinuxResourcesCPUQuota(limit.MilliValue() * int64(period) / 1000)
+	}
+
+	if kubeCtr.SecurityContext != nil && kubeCtr.SecurityContext.Privileged != nil && *kubeCtr.SecurityContext.Privileged {
+		g.SetupPrivileged(true)
+		options = append(options, WithPrivileged(true))
+	}
+
+	// Host path volumes are bind mounted; other volumes were reported as
+	// unsupported
+	hostPaths := make(map[string]string)
+	for _, vol := range kubePod.Spec.Volumes {
+		if vol.HostPath != nil {
+			hostPaths[vol.Name] = vol.HostPath.Path
+		}
+	}
+	volumes := []string{}
+	for _, m := range kubeCtr.VolumeMounts {
+		source, ok := hostPaths[m.Name]
+		if !ok {
+			logrus.Warnf("Skipping mount of unsupported volume %s in container %s", m.Name, kubeCtr.Name)
+			continue
+		}
+		mountOptions := []string{"rbind", "rw"}
+		if m.ReadOnly {
+			mountOptions = []string{"rbind", "ro"}
+		}
+		g.AddMount(spec.Mount{
+			Destination: m.MountPath,
+			Type:        "bind",
+			Source:      source,
+			Options:     mountOptions,
+		})
+		volumes = append(volumes, m.MountPath)
+	}
+	if len(volumes) > 0 {
+		options = append(options, WithUserVolumes(volumes))
+	}
+
+	// Join the namespaces the pod shares, and use the host's where the pod
+	// asks for them
+	infraID, err := pod.InfraContainerID()
+	if err != nil {
+		return nil, err
+	}
+	infra, err := r.GetContainer(infraID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error retrieving infra container of pod %s", pod.ID())
+	}
+	options = append(options, WithUTSNSFrom(infra))
+	if kubePod.Spec.HostNetwork {
+		if err := g.RemoveLinuxNamespace(string(spec.NetworkNamespace)); err != nil {
+			return nil, err
+		}
+	} else {
+		options = append(options, WithNetNSFrom(infra))
+	}
+	if kubePod.Spec.HostIPC {
+		if err := g.RemoveLinuxNamespace(string(spec.IPCNamespace)); err != nil {
+			return nil, err
+		}
+	} else {
+		options = append(options, WithIPCNSFrom(infra), WithShmDir(infra.ShmDir()))
+	}
+	if kubePod.Spec.HostPID {
+		if err := g.RemoveLinuxNamespace(string(spec.PIDNamespace)); err != nil {
+			return nil, err
+		}
+	} else if pod.SharesPID() {
+		options = append(options, WithPIDNSFrom(infra))
+	}
+
+	return r.NewContainer(ctx, g.Config, options...)
+}
@@ -0,0 +1,14 @@
+// +build !linux
+
+package libpod
+
+import (
+	"context"
+	"io"
+)
+
+// PlayKubeYAML creates and starts a pod running the containers of the
+// Kubernetes pod read as YAML from r
+func (r *Runtime) PlayKubeYAML(ctx context.Context, reader io.Reader) (*Pod, []string, error) {
+	return nil, nil, ErrOSNotSupported
+}
@@ -0,0 +1,239 @@
+package libpod
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/cri-o/ocicni/pkg/ocicni"
+	"github.com/pkg/errors"
+)
+
+// hostPortRegistry tracks the host ports claimed by containers started by
+// this runtime. Claims are also recorded in the containers' state, so
+// containers started by other libpod processes are seen as well.
+type hostPortRegistry struct {
+	lock sync.Mutex
+	// claims maps the IDs of containers to the host ports they claimed
+	claims map[string][]ocicni.PortMapping
+}
+
+// hostPortClaim is a container's claim on host ports
+type hostPortClaim struct {
+	id    string
+	name  string
+	ports []ocicni.PortMapping
+}
+
+// claimHostPorts claims the host ports of the given container's port
+// mappings, failing if another container already claimed any of them
+// Mappings with host port 0 are allocated a free host port from the runtime's
+// host port range, and the resulting mappings are recorded in the container's
+// state.
+func (r *Runtime) claimHostPorts(ctr *Container) error {
+	if len(ctr.config.PortMappings) == 0 {
+		return nil
+	}
+
+	r.hostPorts.lock.Lock()
+	defer r.hostPorts.lock.Unlock()
+
+	claims, err := r.hostPortClaims(ctr.ID())
+	if err != nil {
+		return err
+	}
+
+	if err := checkHostPortConflicts(ctr.ID(), ctr.config.PortMappings, claims); err != nil {
+		return err
+	}
+
+	ports, err := allocateHostPorts(ctr.config.PortMappings, claims, r.config.HostPortRangeStart, r.config.HostPortRangeEnd, hostPortAvailable)
+	if err != nil {
+		return errors.Wrapf(err, "error allocating host ports for container %s", ctr.ID())
+	}
+
+	if r.hostPorts.claims == nil {
+		r.hostPorts.claims = make(map[string][]ocicni.PortMapping)
+	}
+	r.hostPorts.claims[ctr.ID()] = ports
+	ctr.state.PortsClaimed = true
+	ctr.state.PortMappings = ports
+
+	return nil
+}
+
+// releaseHostPorts releases the host ports claimed by the given container
+func (r *Runtime) releaseHostPorts(ctr *Container) {
+	r.hostPorts.lock.Lock()
+	defer r.hostPorts.lock.Unlock()
+
+	delete(r.hostPorts.claims, ctr.ID())
+	ctr.state.PortsClaimed = false
+	ctr.state.PortMappings = nil
+}
+
+// portMappings returns the container's port mappings with the host ports
+// allocated for it, if it holds a claim on them
+func (c *Container) portMappings() []ocicni.PortMapping {
+	if c.state.PortMappings != nil {
+		return c.state.PortMappings
+	}
+	return c.config.PortMappings
+}
+
+// hostPortClaims returns the host port claims of all containers but the one
+// with the given ID
+func (r *Runtime) hostPortClaims(exclude string) ([]hostPortClaim, error) {
+	ctrs, err := r.state.AllContainers()
+	if err != nil {
+		return nil, err
+	}
+
+	claims := []hostPortClaim{}
+	seen := make(map[string]bool)
+	for _, ctr := range ctrs {
+		if ctr.ID() == exclude || len(ctr.config.PortMappings) == 0 {
+			continue
+		}
+		if err := r.state.UpdateContainer(ctr); err != nil {
+			if errors.Cause(err) == ErrNoSuchCtr || errors.Cause(err) == ErrCtrRemoved {
+				continue
+			}
+			return nil, err
+		}
+		// Containers that stopped without being cleaned up no longer
+		// hold their ports
+		switch ctr.state.State {
+		case ContainerStateCreated, ContainerStateRunning, ContainerStatePaused:
+		default:
+			continue
+		}
+		if ctr.state.PortsClaimed || r.hostPorts.claims[ctr.ID()] != nil {
+			claims = append(claims, hostPortClaim{ctr.ID(), ctr.Name(), ctr.portMappings()})
+			seen[ctr.ID()] = true
+		}
+	}
+	for id, ports := range r.hostPorts.claims {
+		if id != exclude && !seen[id] {
+			claims = append(claims, hostPortClaim{id, id, ports})
+		}
+	}
+
+	return claims, nil
+}
+
+// validateHostPortRange validates a range of host ports to allocate
+func validateHostPortRange(start, end int) error {
+	if start < 1 || end > 65535 || start > end {
+		return errors.Wrapf(ErrInvalidArg, "invalid host port range %d-%d", start, end)
+	}
+	return nil
+}
+
+// allocateHostPorts returns the given port mappings, with each one with host
+// port 0 assigned a host port from the range between start and end, inclusive,
+// that conflicts neither with the claims nor the other mappings, and that
+// available reports can be bound
+func allocateHostPorts(ports []ocicni.PortMapping, claims []hostPortClaim, start, end int, available func(ocicni.PortMapping) bool) ([]ocicni.PortMapping, error) {
+	allocated := make([]ocicni.PortMapping, len(ports))
+	copy(allocated, ports)
+
+	for i := range allocated {
+		if allocated[i].HostPort != 0 {
+			continue
+		}
+
+		found := false
+		for port := start; port <= end && !found; port++ {
+			candidate := allocated[i]
+			candidate.HostPort = int32(port)
+			if checkHostPortConflicts("", []ocicni.PortMapping{candidate}, claims) != nil {
+				continue
+			}
+			if checkHostPortConflicts("", []ocicni.PortMapping{candidate}, []hostPortClaim{{ports: allocated}}) != nil {
+				continue
+			}
+			if !available(candidate) {
+				continue
+			}
+			allocated[i] = candidate
+			found = true
+		}
+		if !found {
+			return nil, errors.Wrapf(ErrHostPortsExhausted, "no free %s host port in range %d-%d for container port %d",
+				hostPortProtocol(allocated[i]), start, end, allocated[i].ContainerPort)
+		}
+	}
+
+	return allocated, nil
+}
+
+// hostPortAvailable returns whether the host port of a port mapping can
+// currently be bound on the host
+func hostPortAvailable(port ocicni.PortMapping) bool {
+	addr := net.JoinHostPort(port.HostIP, strconv.Itoa(int(port.HostPort)))
+	if hostPortProtocol(port) == "udp" {
+		conn, err := net.ListenPacket("udp", addr)
+		if err != nil {
+			return false
+		}
+		conn.Close()
+		return true
+	}
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return false
+	}
+	listener.Close()
+	return true
+}
+
+// checkHostPortConflicts returns an error naming the other container if any of
+// the given port mappings of a container conflicts with another claim
+func checkHostPortConflicts(id string, ports []ocicni.PortMapping, claims []hostPortClaim) error {
+	for _, port := range ports {
+		for _, claim := range claims {
+			for _, other := range claim.ports {
+				if hostPortsConflict(port, other) {
+					return errors.Wrapf(ErrPortConflict, "host port %s of container %s is already claimed by container %s (%s)",
+						describeHostPort(port), id, claim.name, claim.id)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// hostPortsConflict returns whether two port mappings claim the same host port
+func hostPortsConflict(a, b ocicni.PortMapping) bool {
+	if a.HostPort == 0 || a.HostPort != b.HostPort {
+		return false
+	}
+	if hostPortProtocol(a) != hostPortProtocol(b) {
+		return false
+	}
+	return isWildcardHostIP(a.HostIP) || isWildcardHostIP(b.HostIP) || a.HostIP == b.HostIP
+}
+
+// hostPortProtocol returns the protocol of a port mapping, TCP by default
+func hostPortProtocol(port ocicni.PortMapping) string {
+	if port.Protocol == "" {
+		return "tcp"
+	}
+	return strings.ToLower(port.Protocol)
+}
+
+// isWildcardHostIP returns whether a host IP binds all addresses
+func isWildcardHostIP(ip string) bool {
+	return ip == "" || ip == "0.0.0.0" || ip == "::"
+}
+
+// describeHostPort formats the host side of a port mapping
+func describeHostPort(port ocicni.PortMapping) string {
+	if isWildcardHostIP(port.HostIP) {
+		return fmt.Sprintf("%d/%s", port.HostPort, hostPortProtocol(port))
+	}
+	return fmt.Sprintf("%s:%d/%s", port.HostIP, port.HostPort, hostPortProtocol(port))
+}
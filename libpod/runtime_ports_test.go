@@ -0,0 +1,59 @@
+package libpod
+
+import (
+	"testing"
+
+	"github.com/cri-o/ocicni/pkg/ocicni"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHostPortsConflict(t *testing.T) {
+	port := ocicni.PortMapping{HostPort: 8080, ContainerPort: 80, Protocol: "tcp"}
+
+	assert.True(t, hostPortsConflict(port, ocicni.PortMapping{HostPort: 8080, ContainerPort: 8000}))
+	assert.True(t, hostPortsConflict(port, ocicni.PortMapping{HostPort: 8080, HostIP: "127.0.0.1", Protocol: "TCP"}))
+	assert.False(t, hostPortsConflict(port, ocicni.PortMapping{HostPort: 8080, Protocol: "udp"}))
+	assert.False(t, hostPortsConflict(port, ocicni.PortMapping{HostPort: 8081}))
+
+	local := ocicni.PortMapping{HostPort: 8080, HostIP: "127.0.0.1"}
+	assert.False(t, hostPortsConflict(local, ocicni.PortMapping{HostPort: 8080, HostIP: "192.168.1.1"}))
+	assert.True(t, hostPortsConflict(local, ocicni.PortMapping{HostPort: 8080, HostIP: "127.0.0.1"}))
+}
+
+func TestCheckHostPortConflicts(t *testing.T) {
+	claims := []hostPortClaim{
+		{id: "abc", name: "web", ports: []ocicni.PortMapping{{HostPort: 8080, ContainerPort: 80}}},
+	}
+
+	assert.NoError(t, checkHostPortConflicts("def", []ocicni.PortMapping{{HostPort: 9090, ContainerPort: 80}}, claims))
+
+	err := checkHostPortConflicts("def", []ocicni.PortMapping{{HostPort: 8080, ContainerPort: 8080}}, claims)
+	assert.Equal(t, ErrPortConflict, errors.Cause(err))
+	assert.Contains(t, err.Error(), "host port 8080/tcp of container def is already claimed by container web (abc)")
+}
+
+func TestAllocateHostPorts(t *testing.T) {
+	claims := []hostPortClaim{
+		{id: "abc", name: "web", ports: []ocicni.PortMapping{{HostPort: 30000, ContainerPort: 80}}},
+	}
+	available := func(port ocicni.PortMapping) bool {
+		return port.HostPort != 30002
+	}
+	ports := []ocicni.PortMapping{
+		{HostPort: 0, ContainerPort: 80},
+		{HostPort: 30001, ContainerPort: 443},
+		{HostPort: 0, ContainerPort: 8080},
+		{HostPort: 0, ContainerPort: 53, Protocol: "udp"},
+	}
+
+	allocated, err := allocateHostPorts(ports, claims, 30000, 30010, available)
+	assert.NoError(t, err)
+	assert.Equal(t, []int32{30003, 30001, 30004, 30000}, []int32{
+		allocated[0].HostPort, allocated[1].HostPort, allocated[2].HostPort, allocated[3].HostPort,
+	})
+	assert.Equal(t, int32(0), ports[0].HostPort)
+
+	_, err = allocateHostPorts(ports, claims, 30000, 30002, available)
+	assert.Equal(t, ErrHostPortsExhausted, errors.Cause(err))
+}
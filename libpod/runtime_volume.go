@@ -0,0 +1,158 @@
+package libpod
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Contains the public Runtime API for volumes
+
+// A VolumeCreateOption is a functional option which alters the Volume created by
+// CreateVolume
+type VolumeCreateOption func(*Volume) error
+
+// VolumeFilter is a function to determine whether a volume is included in command
+// output. Volumes to be outputted are tested using the function. A true return
+// will include the volume, a false return will exclude it.
+type VolumeFilter func(*Volume) bool
+
+// CreateVolume creates a new named volume
+// If no name is given, a random one is generated
+func (r *Runtime) CreateVolume(ctx context.Context, options ...VolumeCreateOption) (*Volume, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if !r.valid {
+		return nil, ErrRuntimeStopped
+	}
+
+	return r.newVolume(ctx, options...)
+}
+
+// RemoveVolume removes a volume and its data
+// If force is specified, the containers using the volume are removed with it
+// Otherwise, a volume used by containers will not be removed
+func (r *Runtime) RemoveVolume(ctx context.Context, v *Volume, force bool) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if !r.valid {
+		return ErrRuntimeStopped
+	}
+
+	if !v.valid {
+		return ErrVolumeRemoved
+	}
+
+	ctrIDs, err := r.state.VolumeInUse(v)
+	if err != nil {
+		return err
+	}
+	if len(ctrIDs) > 0 {
+		if !force {
+			return errors.Wrapf(ErrVolumeBeingUsed, "volume %s is being used by the following container(s): %s", v.Name(), strings.Join(ctrIDs, ", "))
+		}
+
+		for _, id := range ctrIDs {
+			ctr, err := r.state.Container(id)
+			if err != nil {
+				return errors.Wrapf(err, "error retrieving container %s using volume %s", id, v.Name())
+			}
+			if err := r.removeContainer(ctx, ctr, true); err != nil {
+				return errors.Wrapf(err, "error removing container %s using volume %s", id, v.Name())
+			}
+		}
+	}
+
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	if err := v.unmount(); err != nil {
+		return err
+	}
+
+	volDir := filepath.Dir(v.config.MountPoint)
+	if err := os.RemoveAll(volDir); err != nil {
+		return errors.Wrapf(err, "error removing data of volume %s", v.Name())
+	}
+
+	return r.state.RemoveVolume(v)
+}
+
+// VolumeInUse returns the IDs of the containers using the volume with the
+// given name
+func (r *Runtime) VolumeInUse(name string) ([]string, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	if !r.valid {
+		return nil, ErrRuntimeStopped
+	}
+
+	volume, err := r.state.Volume(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.state.VolumeInUse(volume)
+}
+
+// GetVolume retrieves a volume by its name
+func (r *Runtime) GetVolume(name string) (*Volume, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	if !r.valid {
+		return nil, ErrRuntimeStopped
+	}
+
+	return r.state.Volume(name)
+}
+
+// HasVolume checks to see if a volume with the given name exists
+func (r *Runtime) HasVolume(name string) (bool, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	if !r.valid {
+		return false, ErrRuntimeStopped
+	}
+
+	return r.state.HasVolume(name)
+}
+
+// Volumes retrieves all volumes
+// Filters can be provided which will determine which volumes are included in
+// the output. Multiple filters are handled by ANDing their output, so only
+// volumes matching all filters are returned
+func (r *Runtime) Volumes(filters ...VolumeFilter) ([]*Volume, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	if !r.valid {
+		return nil, ErrRuntimeStopped
+	}
+
+	volumes, err := r.state.AllVolumes()
+	if err != nil {
+		return nil, err
+	}
+
+	volsFiltered := make([]*Volume, 0, len(volumes))
+	for _, vol := range volumes {
+		include := true
+		for _, filter := range filters {
+			include = include && filter(vol)
+		}
+
+		if include {
+			volsFiltered = append(volsFiltered, vol)
+		}
+	}
+
+	return volsFiltered, nil
+}
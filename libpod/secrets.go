@@ -0,0 +1,64 @@
+package libpod
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// SecretStore resolves the values of the secrets injected into containers'
+// environments when they are created in the OCI runtime
+type SecretStore interface {
+	// Lookup returns the value of the secret with the given name
+	Lookup(name string) (string, error)
+}
+
+// fileSecretStore is a SecretStore keeping each secret in a file named after
+// it, in a single directory
+type fileSecretStore struct {
+	dir string
+}
+
+// NewFileSecretStore returns a SecretStore reading each secret from the file
+// named after it in dir. A single trailing newline is removed from the value.
+func NewFileSecretStore(dir string) SecretStore {
+	return &fileSecretStore{dir: dir}
+}
+
+// Lookup returns the value of the secret with the given name
+func (s *fileSecretStore) Lookup(name string) (string, error) {
+	if err := validateSecretName(name); err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(s.dir, name)
+	info, err := os.Stat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", errors.Wrapf(ErrNoSuchSecret, "no secret %q in %s", name, s.dir)
+		}
+		return "", errors.Wrapf(err, "error accessing secret %q", name)
+	}
+	if info.Mode().Perm()&0077 != 0 {
+		logrus.Warnf("Secret file %s is accessible by other users", path)
+	}
+
+	value, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", errors.Wrapf(err, "error reading secret %q", name)
+	}
+
+	return strings.TrimSuffix(string(value), "\n"), nil
+}
+
+// validateSecretName checks that a secret name can be used as a file name
+func validateSecretName(name string) error {
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, "/\x00") {
+		return errors.Wrapf(ErrInvalidArg, "invalid secret name %q", name)
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package libpod
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileSecretStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "libpod_test_")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "token"), []byte("s3cr3t\n"), 0600))
+	store := NewFileSecretStore(dir)
+
+	value, err := store.Lookup("token")
+	assert.NoError(t, err)
+	assert.Equal(t, "s3cr3t", value)
+
+	_, err = store.Lookup("missing")
+	assert.Equal(t, ErrNoSuchSecret, errors.Cause(err))
+
+	_, err = store.Lookup("../token")
+	assert.Equal(t, ErrInvalidArg, errors.Cause(err))
+}
@@ -127,4 +127,23 @@ type State interface {
 	// If a namespace has been set, only pods in that namespace will be
 	// returned.
 	AllPods() ([]*Pod, error)
+
+	// Volume retrieves a volume given its name.
+	// Volumes are not part of namespaces.
+	Volume(name string) (*Volume, error)
+	// HasVolume checks if a volume with the given name is present in the
+	// state.
+	HasVolume(name string) (bool, error)
+	// AddVolume adds a volume to the state.
+	// The volume's name must be unique.
+	AddVolume(volume *Volume) error
+	// RemoveVolume removes a volume from the state.
+	// Containers using the volume are not checked, use VolumeInUse first.
+	RemoveVolume(volume *Volume) error
+	// VolumeInUse returns the IDs of the containers using the given
+	// volume, in all namespaces.
+	// A volume cannot be removed if containers use it.
+	VolumeInUse(volume *Volume) ([]string, error)
+	// Retrieves all volumes presently in the state.
+	AllVolumes() ([]*Volume, error)
 }
@@ -3517,3 +3517,140 @@ func TestSaveAndUpdatePodSameNamespace(t *testing.T) {
 		testPodsEqual(t, testPod, statePod, false)
 	})
 }
+
+func TestGetVolumeDoesNotExist(t *testing.T) {
+	runForAllStates(t, func(t *testing.T, state State, lockPath string) {
+		_, err := state.Volume("test")
+		assert.Error(t, err)
+	})
+}
+
+func TestAddAndGetVolume(t *testing.T) {
+	runForAllStates(t, func(t *testing.T, state State, lockPath string) {
+		testVol, err := getTestVolume("test", lockPath)
+		assert.NoError(t, err)
+
+		err = state.AddVolume(testVol)
+		assert.NoError(t, err)
+
+		stateVol, err := state.Volume("test")
+		assert.NoError(t, err)
+		assert.Equal(t, testVol.config.Name, stateVol.config.Name)
+		assert.Equal(t, testVol.config.MountPoint, stateVol.config.MountPoint)
+		assert.Equal(t, testVol.config.Labels, stateVol.config.Labels)
+
+		exists, err := state.HasVolume("test")
+		assert.NoError(t, err)
+		assert.True(t, exists)
+	})
+}
+
+func TestAddVolumeDupNameFails(t *testing.T) {
+	runForAllStates(t, func(t *testing.T, state State, lockPath string) {
+		testVol1, err := getTestVolume("test", lockPath)
+		assert.NoError(t, err)
+
+		testVol2, err := getTestVolume("test", lockPath)
+		assert.NoError(t, err)
+
+		err = state.AddVolume(testVol1)
+		assert.NoError(t, err)
+
+		err = state.AddVolume(testVol2)
+		assert.Error(t, err)
+	})
+}
+
+func TestRemoveVolume(t *testing.T) {
+	runForAllStates(t, func(t *testing.T, state State, lockPath string) {
+		testVol, err := getTestVolume("test", lockPath)
+		assert.NoError(t, err)
+
+		err = state.AddVolume(testVol)
+		assert.NoError(t, err)
+
+		err = state.RemoveVolume(testVol)
+		assert.NoError(t, err)
+		assert.False(t, testVol.valid)
+
+		exists, err := state.HasVolume("test")
+		assert.NoError(t, err)
+		assert.False(t, exists)
+
+		allVols, err := state.AllVolumes()
+		assert.NoError(t, err)
+		assert.Equal(t, 0, len(allVols))
+	})
+}
+
+func TestVolumeInUse(t *testing.T) {
+	runForAllStates(t, func(t *testing.T, state State, lockPath string) {
+		testVol, err := getTestVolume("test", lockPath)
+		assert.NoError(t, err)
+
+		err = state.AddVolume(testVol)
+		assert.NoError(t, err)
+
+		testCtr1, err := getTestCtr1(lockPath)
+		assert.NoError(t, err)
+		testCtr1.config.NamedVolumes = []*ContainerNamedVolume{{Name: "test", Dest: "/data"}}
+
+		testCtr2, err := getTestCtr2(lockPath)
+		assert.NoError(t, err)
+
+		err = state.AddContainer(testCtr1)
+		assert.NoError(t, err)
+
+		err = state.AddContainer(testCtr2)
+		assert.NoError(t, err)
+
+		ctrIDs, err := state.VolumeInUse(testVol)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{testCtr1.ID()}, ctrIDs)
+	})
+}
+
+func TestVolumeInUseCtrAddedBeforeVolume(t *testing.T) {
+	runForAllStates(t, func(t *testing.T, state State, lockPath string) {
+		testCtr, err := getTestCtr1(lockPath)
+		assert.NoError(t, err)
+		testCtr.config.NamedVolumes = []*ContainerNamedVolume{{Name: "test", Dest: "/data"}}
+
+		err = state.AddContainer(testCtr)
+		assert.NoError(t, err)
+
+		testVol, err := getTestVolume("test", lockPath)
+		assert.NoError(t, err)
+
+		err = state.AddVolume(testVol)
+		assert.NoError(t, err)
+
+		ctrIDs, err := state.VolumeInUse(testVol)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{testCtr.ID()}, ctrIDs)
+	})
+}
+
+func TestVolumeNotInUseAfterCtrRemoved(t *testing.T) {
+	runForAllStates(t, func(t *testing.T, state State, lockPath string) {
+		testVol, err := getTestVolume("test", lockPath)
+		assert.NoError(t, err)
+
+		err = state.AddVolume(testVol)
+		assert.NoError(t, err)
+
+		testCtr, err := getTestCtr1(lockPath)
+		assert.NoError(t, err)
+		testCtr.config.NamedVolumes = []*ContainerNamedVolume{{Name: "test", Dest: "/data"}}
+
+		err = state.AddContainer(testCtr)
+		assert.NoError(t, err)
+
+		err = state.RemoveContainer(testCtr)
+		assert.NoError(t, err)
+
+		ctrIDs, err := state.VolumeInUse(testVol)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, len(ctrIDs))
+	})
+}
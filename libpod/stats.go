@@ -71,6 +71,11 @@ func (c *Container) GetContainerStats(previousStats *ContainerStats) (*Container
 	if netStats != nil {
 		stats.NetInput = netStats.TxBytes
 		stats.NetOutput = netStats.RxBytes
+		stats.NetRxBytes = netStats.RxBytes
+		stats.NetTxBytes = netStats.TxBytes
+		stats.NetRxPackets = netStats.RxPackets
+		stats.NetTxPackets = netStats.TxPackets
+		stats.NetNsOwner = netStats.Owner
 	} else {
 		stats.NetInput = 0
 		stats.NetOutput = 0
@@ -79,6 +84,46 @@ func (c *Container) GetContainerStats(previousStats *ContainerStats) (*Container
 	return stats, nil
 }
 
+// GetPressureStats gets the pressure stall information of a given container
+// from the cpu.pressure, memory.pressure and io.pressure files of its cgroup.
+// ErrPressureNotSupported is returned on cgroup v1 hosts and when PSI is not
+// enabled in the kernel.
+func (c *Container) GetPressureStats() (*PressureStats, error) {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+		if err := c.syncContainer(); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.state.State != ContainerStateRunning {
+		return nil, ErrCtrStateInvalid
+	}
+
+	cgroupPath, err := c.CGroupPath()
+	if err != nil {
+		return nil, err
+	}
+
+	stats := new(PressureStats)
+	stats.ContainerID = c.ID()
+	stats.Name = c.Name()
+	for resource, pressure := range map[string]*ResourcePressure{
+		"cpu":    &stats.CPU,
+		"memory": &stats.Memory,
+		"io":     &stats.IO,
+	} {
+		read, err := cgroupPressure(cgroupPath, resource)
+		if err != nil {
+			return nil, err
+		}
+		*pressure = *read
+	}
+
+	return stats, nil
+}
+
 // getMemory limit returns the memory limit for a given cgroup
 // If the configured memory limit is larger than the total memory on the sys, the
 // physical system memory size is returned
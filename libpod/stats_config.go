@@ -15,4 +15,45 @@ type ContainerStats struct {
 	BlockInput  uint64
 	BlockOutput uint64
 	PIDs        uint64
+	// Network counters, summed over all interfaces in the container's
+	// network namespace except loopback
+	NetRxBytes   uint64
+	NetTxBytes   uint64
+	NetRxPackets uint64
+	NetTxPackets uint64
+	// NetNsOwner is the ID of the container that owns the network
+	// namespace the network counters were read from. If the container
+	// shares the network namespace of another container, the counters
+	// cover all traffic of that namespace, not just this container's.
+	// It is "" if the container has no network namespace.
+	NetNsOwner string
+}
+
+// PressureStats contains the pressure stall information of a running
+// container's cgroup
+type PressureStats struct {
+	ContainerID string
+	Name        string
+	CPU         ResourcePressure
+	Memory      ResourcePressure
+	IO          ResourcePressure
+}
+
+// ResourcePressure is the pressure stall information of one resource
+type ResourcePressure struct {
+	// Some is the share of time some tasks were stalled on the resource
+	Some PressureValues
+	// Full is the share of time all tasks were stalled on the resource.
+	// It is nil if the kernel does not report it, as for CPU on older
+	// kernels.
+	Full *PressureValues
+}
+
+// PressureValues are the percentages of time tasks were stalled over the last
+// 10, 60, and 300 seconds, and the total stall time in microseconds
+type PressureValues struct {
+	Avg10  float64
+	Avg60  float64
+	Avg300 float64
+	Total  uint64
 }
@@ -6,3 +6,8 @@ package libpod
 func (c *Container) GetContainerStats(previousStats *ContainerStats) (*ContainerStats, error) {
 	return nil, ErrOSNotSupported
 }
+
+// GetPressureStats gets the pressure stall information of a given container
+func (c *Container) GetPressureStats() (*PressureStats, error) {
+	return nil, ErrOSNotSupported
+}
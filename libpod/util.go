@@ -2,6 +2,7 @@ package libpod
 
 import (
 	"fmt"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"sort"
@@ -17,6 +18,14 @@ import (
 	"github.com/pkg/errors"
 )
 
+const (
+	// zoneinfoDir is the location of the host's zoneinfo database
+	zoneinfoDir = "/usr/share/zoneinfo"
+	// timezoneLocal is the timezone of containers that use the host's
+	// timezone
+	timezoneLocal = "local"
+)
+
 // Runtime API constants
 const (
 	// DefaultTransport is a prefix that we apply to an image name
@@ -61,6 +70,56 @@ func GetPolicyContext(path string) (*signature.PolicyContext, error) {
 	return signature.NewPolicyContext(policy)
 }
 
+// parsePressure parses the pressure stall information of a resource in the
+// format of the cgroup v2 *.pressure files and /proc/pressure
+func parsePressure(contents string) (*ResourcePressure, error) {
+	pressure := new(ResourcePressure)
+	seenSome := false
+	for _, line := range strings.Split(strings.TrimSpace(contents), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		values := new(PressureValues)
+		for _, field := range fields[1:] {
+			kv := strings.SplitN(field, "=", 2)
+			if len(kv) != 2 {
+				return nil, errors.Errorf("invalid pressure field %q", field)
+			}
+			var err error
+			switch kv[0] {
+			case "avg10":
+				values.Avg10, err = strconv.ParseFloat(kv[1], 64)
+			case "avg60":
+				values.Avg60, err = strconv.ParseFloat(kv[1], 64)
+			case "avg300":
+				values.Avg300, err = strconv.ParseFloat(kv[1], 64)
+			case "total":
+				values.Total, err = strconv.ParseUint(kv[1], 10, 64)
+			}
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid pressure field %q", field)
+			}
+		}
+
+		switch fields[0] {
+		case "some":
+			pressure.Some = *values
+			seenSome = true
+		case "full":
+			pressure.Full = values
+		default:
+			return nil, errors.Errorf("invalid pressure line %q", line)
+		}
+	}
+	if !seenSome {
+		return nil, errors.Errorf("pressure information has no \"some\" line")
+	}
+
+	return pressure, nil
+}
+
 // RemoveScientificNotationFromFloat returns a float without any
 // scientific notation if the number has any.
 // golang does not handle conversion of float64s that have scientific
@@ -178,3 +237,54 @@ func GetV1CGroups(excludes []string) cgroups.Hierarchy {
 		return filtered, nil
 	}
 }
+
+// readPidFile reads a PID from the given file
+func readPidFile(path string) (int, error) {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return 0, errors.Wrapf(err, "error reading pid file %s", path)
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(contents)))
+	if err != nil {
+		return 0, errors.Wrapf(err, "error parsing pid file %s", path)
+	}
+	return pid, nil
+}
+
+// zoneinfoPath returns the path of the given timezone in the host's zoneinfo
+// database, validating that the timezone exists
+func zoneinfoPath(timezone string) (string, error) {
+	if timezone == "" || filepath.IsAbs(timezone) || strings.HasPrefix(filepath.Clean(timezone), "..") {
+		return "", errors.Wrapf(ErrInvalidArg, "invalid timezone %q", timezone)
+	}
+
+	path := filepath.Join(zoneinfoDir, timezone)
+	info, err := os.Stat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", errors.Wrapf(ErrInvalidArg, "timezone %q not found in %s", timezone, zoneinfoDir)
+		}
+		return "", errors.Wrapf(err, "error looking up timezone %q", timezone)
+	}
+	if info.IsDir() {
+		return "", errors.Wrapf(ErrInvalidArg, "invalid timezone %q", timezone)
+	}
+
+	return path, nil
+}
+
+// dirSize returns the total size in bytes of the regular files under the given
+// directory
+func dirSize(dir string) (int64, error) {
+	var size int64
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.Mode().IsRegular() {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size, err
+}
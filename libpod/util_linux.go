@@ -4,14 +4,22 @@ package libpod
 
 import (
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/containerd/cgroups"
 	spec "github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
+	"golang.org/x/sys/unix"
 )
 
+// cgroupRoot is the mountpoint of the host's cgroup hierarchies
+const cgroupRoot = "/sys/fs/cgroup"
+
 // systemdSliceFromPath makes a new systemd slice under the given parent with
 // the given name.
 // The parent must be a slice. The name must NOT include ".slice"
@@ -67,3 +75,89 @@ func assembleSystemdCgroupName(baseSlice, newSlice string) (string, error) {
 
 	return final, nil
 }
+
+// isCgroup2UnifiedMode returns whether the host uses the unified cgroup v2
+// hierarchy instead of the v1 per-controller hierarchies
+func isCgroup2UnifiedMode() (bool, error) {
+	var st unix.Statfs_t
+	if err := unix.Statfs(cgroupRoot, &st); err != nil {
+		return false, errors.Wrapf(err, "error getting filesystem information for %s", cgroupRoot)
+	}
+	return st.Type == unix.CGROUP2_SUPER_MAGIC, nil
+}
+
+// cgroupExists returns whether the given cgroupfs cgroup exists on the host.
+// On cgroup v1 hosts, the memory controller hierarchy is checked.
+func cgroupExists(cgroupPath string) (bool, error) {
+	unified, err := isCgroup2UnifiedMode()
+	if err != nil {
+		return false, err
+	}
+
+	dir := filepath.Join(cgroupRoot, cgroupPath)
+	if !unified {
+		dir = filepath.Join(cgroupRoot, "memory", cgroupPath)
+	}
+
+	if _, err := os.Stat(dir); err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, errors.Wrapf(err, "error accessing cgroup %s", dir)
+	}
+	return true, nil
+}
+
+// cgroupPeakMemory returns the peak memory usage of the given cgroupfs cgroup,
+// from memory.peak on cgroup v2 hosts and memory.max_usage_in_bytes on cgroup
+// v1 hosts
+func cgroupPeakMemory(cgroupPath string) (uint64, error) {
+	unified, err := isCgroup2UnifiedMode()
+	if err != nil {
+		return 0, err
+	}
+
+	file := filepath.Join(cgroupRoot, cgroupPath, "memory.peak")
+	if !unified {
+		file = filepath.Join(cgroupRoot, "memory", cgroupPath, "memory.max_usage_in_bytes")
+	}
+
+	contents, err := ioutil.ReadFile(file)
+	if err != nil {
+		return 0, errors.Wrapf(err, "error reading peak memory usage of cgroup %s", cgroupPath)
+	}
+	return parseCgroupMemoryValue(string(contents))
+}
+
+// cgroupPressure returns the pressure stall information of the given resource,
+// cpu, memory, or io, of the given cgroupfs cgroup
+func cgroupPressure(cgroupPath, resource string) (*ResourcePressure, error) {
+	unified, err := isCgroup2UnifiedMode()
+	if err != nil {
+		return nil, err
+	}
+	if !unified {
+		return nil, errors.Wrapf(ErrPressureNotSupported, "host does not use cgroup v2")
+	}
+
+	file := filepath.Join(cgroupRoot, cgroupPath, resource+".pressure")
+	contents, err := ioutil.ReadFile(file)
+	if err != nil {
+		// The files are missing, or cannot be read, if PSI is
+		// disabled in the kernel
+		if pathErr, ok := err.(*os.PathError); os.IsNotExist(err) || (ok && pathErr.Err == unix.EOPNOTSUPP) {
+			return nil, errors.Wrapf(ErrPressureNotSupported, "%s pressure of cgroup %s is not available", resource, cgroupPath)
+		}
+		return nil, errors.Wrapf(err, "error reading %s pressure of cgroup %s", resource, cgroupPath)
+	}
+	return parsePressure(string(contents))
+}
+
+// parseCgroupMemoryValue parses a memory usage value of a cgroup file
+func parseCgroupMemoryValue(contents string) (uint64, error) {
+	value, err := strconv.ParseUint(strings.TrimSpace(contents), 10, 64)
+	if err != nil {
+		return 0, errors.Wrapf(err, "error parsing cgroup memory value %q", strings.TrimSpace(contents))
+	}
+	return value, nil
+}
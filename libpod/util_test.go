@@ -1,8 +1,13 @@
 package libpod
 
 import (
-	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestRemoveScientificNotationFromFloat(t *testing.T) {
@@ -14,3 +19,68 @@ func TestRemoveScientificNotationFromFloat(t *testing.T) {
 		assert.Equal(t, result, results[i])
 	}
 }
+
+func TestReadPidFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "libpod_test_")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	pidPath := filepath.Join(dir, "test.pid")
+	_, err = readPidFile(pidPath)
+	assert.True(t, os.IsNotExist(errors.Cause(err)))
+
+	assert.NoError(t, ioutil.WriteFile(pidPath, []byte("1234\n"), 0644))
+	pid, err := readPidFile(pidPath)
+	assert.NoError(t, err)
+	assert.Equal(t, 1234, pid)
+
+	assert.NoError(t, ioutil.WriteFile(pidPath, []byte("notapid"), 0644))
+	_, err = readPidFile(pidPath)
+	assert.Error(t, err)
+}
+
+func TestZoneinfoPath(t *testing.T) {
+	for _, tz := range []string{"", "/etc/passwd", "../../etc/passwd", "Not/AZone"} {
+		_, err := zoneinfoPath(tz)
+		assert.Error(t, err, tz)
+	}
+
+	if _, err := os.Stat(filepath.Join(zoneinfoDir, "UTC")); err != nil {
+		t.Skip("no zoneinfo database on this host")
+	}
+	path, err := zoneinfoPath("UTC")
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(zoneinfoDir, "UTC"), path)
+}
+
+func TestDirSize(t *testing.T) {
+	dir, err := ioutil.TempDir("", "libpod_test_")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "sub"), 0755))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "a"), make([]byte, 100), 0644))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "sub", "b"), make([]byte, 23), 0644))
+	assert.NoError(t, os.Symlink("a", filepath.Join(dir, "link")))
+
+	size, err := dirSize(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(123), size)
+}
+
+func TestParsePressure(t *testing.T) {
+	pressure, err := parsePressure("some avg10=1.50 avg60=0.25 avg300=0.00 total=12345\nfull avg10=0.10 avg60=0.00 avg300=0.00 total=678\n")
+	assert.NoError(t, err)
+	assert.Equal(t, PressureValues{Avg10: 1.5, Avg60: 0.25, Total: 12345}, pressure.Some)
+	assert.Equal(t, &PressureValues{Avg10: 0.1, Total: 678}, pressure.Full)
+
+	// Older kernels only report some for CPU
+	pressure, err = parsePressure("some avg10=0.00 avg60=0.00 avg300=0.00 total=0\n")
+	assert.NoError(t, err)
+	assert.Nil(t, pressure.Full)
+
+	_, err = parsePressure("")
+	assert.Error(t, err)
+	_, err = parsePressure("some avg10=abc")
+	assert.Error(t, err)
+}
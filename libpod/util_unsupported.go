@@ -21,3 +21,11 @@ func deleteSystemdCgroup(path string) error {
 func assembleSystemdCgroupName(baseSlice, newSlice string) (string, error) {
 	return "", errors.Wrapf(ErrOSNotSupported, "cgroups are not supported on non-linux OSes")
 }
+
+func isCgroup2UnifiedMode() (bool, error) {
+	return false, errors.Wrapf(ErrOSNotSupported, "cgroups are not supported on non-linux OSes")
+}
+
+func cgroupExists(cgroupPath string) (bool, error) {
+	return false, errors.Wrapf(ErrOSNotSupported, "cgroups are not supported on non-linux OSes")
+}
@@ -0,0 +1,72 @@
+package libpod
+
+import (
+	"time"
+
+	"github.com/containers/storage"
+)
+
+// volumeLockPrefix is prepended to the names of volumes to get the names of
+// their lockfiles, which share a directory with those of containers and pods
+const volumeLockPrefix = "volume-"
+
+// Volume is a named volume managed by libpod, whose data lives in the runtime's
+// volume path and outlives the containers using it
+// ffjson: skip
+type Volume struct {
+	config *VolumeConfig
+
+	valid   bool
+	runtime *Runtime
+	lock    storage.Locker
+}
+
+// VolumeConfig holds the volume's configuration
+type VolumeConfig struct {
+	// Name of the volume
+	Name string `json:"name"`
+	// Labels contains labels applied to the volume
+	Labels map[string]string `json:"labels"`
+	// MountPoint is the directory holding the volume's data, which is
+	// bind mounted into the containers using it
+	MountPoint string `json:"mountPoint"`
+	// Options are the options the volume was created with. If "type" is
+	// set, the filesystem of that type given by "device" is mounted on
+	// MountPoint with the mount options in "o" while containers use it.
+	Options map[string]string `json:"options"`
+	// Time the volume was created
+	CreatedTime time.Time `json:"createdAt"`
+}
+
+// Name retrieves the volume's name
+func (v *Volume) Name() string {
+	return v.config.Name
+}
+
+// Labels returns the volume's labels
+func (v *Volume) Labels() map[string]string {
+	labels := make(map[string]string)
+	for key, value := range v.config.Labels {
+		labels[key] = value
+	}
+	return labels
+}
+
+// MountPoint returns the directory holding the volume's data
+func (v *Volume) MountPoint() string {
+	return v.config.MountPoint
+}
+
+// Options returns the options the volume was created with
+func (v *Volume) Options() map[string]string {
+	options := make(map[string]string)
+	for key, value := range v.config.Options {
+		options[key] = value
+	}
+	return options
+}
+
+// CreatedTime returns the time the volume was created
+func (v *Volume) CreatedTime() time.Time {
+	return v.config.CreatedTime
+}
@@ -0,0 +1,120 @@
+package libpod
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/containers/storage"
+	"github.com/containers/storage/pkg/mount"
+	"github.com/containers/storage/pkg/stringid"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// Creates a new volume and adds it to the state
+// The runtime lock is not taken, so containers can create their missing volumes
+// while they are mounted
+func (r *Runtime) newVolume(ctx context.Context, options ...VolumeCreateOption) (_ *Volume, err error) {
+	volume := new(Volume)
+	volume.config = new(VolumeConfig)
+	volume.config.Labels = make(map[string]string)
+	volume.config.Options = make(map[string]string)
+	volume.config.CreatedTime = time.Now()
+	volume.runtime = r
+
+	for _, option := range options {
+		if err := option(volume); err != nil {
+			return nil, errors.Wrapf(err, "error running volume create option")
+		}
+	}
+
+	if volume.config.Name == "" {
+		volume.config.Name = stringid.GenerateNonCryptoID()
+	}
+
+	exists, err := r.state.HasVolume(volume.config.Name)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, errors.Wrapf(ErrVolumeExists, "volume with name %s already exists", volume.config.Name)
+	}
+
+	// Path our lock file will reside at
+	lockPath := filepath.Join(r.lockDir, volumeLockPrefix+volume.config.Name)
+	// Grab a lockfile at the given path
+	lock, err := storage.GetLockfile(lockPath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error creating lockfile for new volume")
+	}
+	volume.lock = lock
+
+	volDir := filepath.Join(r.config.VolumePath, volume.config.Name)
+	volume.config.MountPoint = filepath.Join(volDir, "_data")
+	if err := os.MkdirAll(volume.config.MountPoint, 0755); err != nil {
+		return nil, errors.Wrapf(err, "error creating directory of volume %s", volume.config.Name)
+	}
+	defer func() {
+		if err != nil {
+			if err2 := os.RemoveAll(volDir); err2 != nil {
+				logrus.Errorf("Error removing directory of volume %s: %v", volume.config.Name, err2)
+			}
+		}
+	}()
+
+	volume.valid = true
+
+	if err := r.state.AddVolume(volume); err != nil {
+		return nil, errors.Wrapf(err, "error adding volume to state")
+	}
+
+	return volume, nil
+}
+
+// mount mounts the filesystem given in the volume's options on its mount
+// point, if it has one and it is not mounted already
+// The volume must be locked
+func (v *Volume) mount() error {
+	if v.config.Options["type"] == "" {
+		return nil
+	}
+
+	mounted, err := mount.Mounted(v.config.MountPoint)
+	if err != nil {
+		return errors.Wrapf(err, "unable to determine if volume %s is mounted", v.Name())
+	}
+	if mounted {
+		return nil
+	}
+
+	if err := mount.Mount(v.config.Options["device"], v.config.MountPoint, v.config.Options["type"], v.config.Options["o"]); err != nil {
+		return errors.Wrapf(err, "error mounting volume %s", v.Name())
+	}
+
+	return nil
+}
+
+// unmount unmounts the filesystem given in the volume's options from its mount
+// point, if it is mounted
+// The volume must be locked
+func (v *Volume) unmount() error {
+	if v.config.Options["type"] == "" {
+		return nil
+	}
+
+	mounted, err := mount.Mounted(v.config.MountPoint)
+	if err != nil {
+		return errors.Wrapf(err, "unable to determine if volume %s is mounted", v.Name())
+	}
+	if !mounted {
+		return nil
+	}
+
+	if err := mount.Unmount(v.config.MountPoint); err != nil {
+		return errors.Wrapf(err, "error unmounting volume %s", v.Name())
+	}
+
+	return nil
+}
@@ -17,3 +17,10 @@ func CheckForCriu() bool {
 	}
 	return result
 }
+
+// GetCriuVersion returns the version of the CRIU binary on this host,
+// encoded as major*10000 + minor*100 + sublevel (e.g. 31100 for 3.11)
+func GetCriuVersion() (int, error) {
+	c := criu.MakeCriu()
+	return c.GetCriuVersion()
+}
@@ -173,6 +173,9 @@ type ContainerInspectData struct {
 	ExitCommand     []string               `json:"ExitCommand"`
 	Namespace       string                 `json:"Namespace"`
 	IsInfra         bool                   `json:"IsInfra"`
+	CgroupMode      string                 `json:"CgroupMode"`
+	Timezone        string                 `json:"Timezone,omitempty"`
+	EnvSecrets      map[string]string      `json:"EnvSecrets,omitempty"`
 }
 
 // ContainerInspectState represents the state of a container.
@@ -189,6 +192,7 @@ type ContainerInspectState struct {
 	Error      string    `json:"Error"` // TODO
 	StartedAt  time.Time `json:"StartedAt"`
 	FinishedAt time.Time `json:"FinishedAt"`
+	PeakMemory uint64    `json:"PeakMemory,omitempty"`
 }
 
 // NetworkSettings holds information about the newtwork settings of the container